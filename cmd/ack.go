@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ackJsonOutput    bool
+	ackAuthor        string
+	ackEmoji         string
+	ackIssueComments bool
+	ackDryRun        bool
+)
+
+var ackCmd = &cobra.Command{
+	Use:   "ack [pr-reference]",
+	Short: "React to every unresolved comment from a reviewer",
+	Long: `Acknowledge a reviewer's feedback in one go by adding a reaction to each
+of their comments in unresolved threads, signaling that it was seen before
+the fixes land. Hidden comments are skipped, and reacting twice with the
+same emoji has no effect, so repeated runs are safe.
+
+--emoji takes a reaction (+1, -1, laugh, confused, heart, hooray, rocket,
+eyes), its shortcode name (thumbsup, tada), or the emoji itself.
+--issue-comments also reacts to the reviewer's general PR comments.
+
+If no PR reference is given, finds the PR for the current branch.
+
+PR reference can be:
+  - Full URL: https://github.com/owner/repo/pull/123
+  - Short form: owner/repo/123
+  - Just number: 123 (when in a repo context)
+  - Omitted: uses current branch's PR
+
+Examples:
+  gh pr-comments ack --author alice
+  gh pr-comments ack --author alice --emoji eyes --dry-run
+  gh pr-comments ack owner/repo/123 --author "coderabbitai[bot]" --emoji +1 --issue-comments`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runAck,
+}
+
+func init() {
+	ackCmd.Flags().BoolVar(&ackJsonOutput, "json", false, "Output in JSON format")
+	ackCmd.Flags().StringVar(&ackAuthor, "author", "", "Reviewer whose comments to react to")
+	ackCmd.Flags().StringVar(&ackEmoji, "emoji", "+1", "Reaction to add (+1/-1/laugh/confused/heart/hooray/rocket/eyes)")
+	ackCmd.Flags().BoolVar(&ackIssueComments, "issue-comments", false, "Also react to the reviewer's general PR comments")
+	ackCmd.Flags().BoolVar(&ackDryRun, "dry-run", false, "Show which comments would get a reaction")
+	_ = ackCmd.MarkFlagRequired("author")
+	ackCmd.RegisterFlagCompletionFunc("author", completeAuthors)
+	ackCmd.RegisterFlagCompletionFunc("emoji", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"+1", "-1", "laugh", "confused", "heart", "hooray", "rocket", "eyes"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	rootCmd.AddCommand(ackCmd)
+}
+
+type ackResult struct {
+	CommentID int64  `json:"comment_id"`
+	Type      string `json:"type"`
+	Location  string `json:"location,omitempty"`
+	Body      string `json:"body"`
+	Reaction  string `json:"reaction"`
+	Action    string `json:"action"`
+	Success   bool   `json:"success"`
+	Error     string `json:"error,omitempty"`
+}
+
+func runAck(cmd *cobra.Command, args []string) error {
+	reaction, err := github.ParseReaction(ackEmoji)
+	if err != nil {
+		return err
+	}
+
+	if err := requireLiveData("ack"); err != nil {
+		return err
+	}
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
+	if err != nil {
+		return err
+	}
+
+	reviewComments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	var results []ackResult
+	for _, c := range reviewComments {
+		if !strings.EqualFold(c.User.Login, ackAuthor) || c.IsResolved || c.IsMinimized {
+			continue
+		}
+		results = append(results, ackResult{CommentID: c.ID, Type: "review_comment", Location: c.Location(), Body: c.Body})
+	}
+	if ackIssueComments {
+		issueComments, err := client.GetIssueComments(prRef.Owner, prRef.Repo, prRef.Number)
+		if err != nil {
+			return err
+		}
+		for _, c := range issueComments {
+			if !strings.EqualFold(c.User.Login, ackAuthor) || c.IsMinimized {
+				continue
+			}
+			results = append(results, ackResult{CommentID: c.ID, Type: "issue_comment", Body: c.Body})
+		}
+	}
+
+	failed := 0
+	for i := range results {
+		r := &results[i]
+		r.Reaction = reaction
+		if ackDryRun {
+			r.Action = "would_react"
+			r.Success = true
+			continue
+		}
+		r.Action = "reacted"
+		if err := client.AddReaction(prRef.Owner, prRef.Repo, r.Type, r.CommentID, reaction); err != nil {
+			r.Error = err.Error()
+			failed++
+			continue
+		}
+		r.Success = true
+		journalAction(prRef, journal.Entry{Action: "react", CommentID: r.CommentID, Reason: reaction})
+	}
+
+	if ackJsonOutput {
+		if results == nil {
+			results = []ackResult{}
+		}
+		if err := writeJSON(results); err != nil {
+			return err
+		}
+	} else if len(results) == 0 {
+		fmt.Printf("No unresolved comments from %s\n", ackAuthor)
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tTYPE\tLOCATION\tSTATUS\tBODY")
+		for _, r := range results {
+			status := r.Action
+			if !r.Success {
+				status = "failed: " + r.Error
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", r.CommentID, r.Type, r.Location, status, github.TruncateString(strings.Join(strings.Fields(r.Body), " "), 50))
+		}
+		w.Flush()
+		if ackDryRun {
+			fmt.Printf("\nWould react with %s to %d comment(s)\n", reaction, len(results))
+		} else {
+			fmt.Printf("\nReacted with %s to %d of %d comment(s)\n", reaction, len(results)-failed, len(results))
+		}
+	}
+
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d reaction(s) failed", failed)
+	}
+	return nil
+}
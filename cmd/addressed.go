@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/trailer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	addressedPR         string
+	addressedJsonOutput bool
+)
+
+var addressedCmd = &cobra.Command{
+	Use:   "addressed",
+	Short: "Find unresolved comments that later commits likely fix",
+	Long: `Match unresolved review comments against the PR commits made after them
+to find comments that probably already have a fix waiting to be resolved.
+
+A commit matches a comment when, from strongest to weakest:
+  mentioned - its message references the comment ID, e.g. with a
+              "Resolves-Comment: <id>" trailer or "addresses #<id>"
+  lines     - it changes lines at or near the commented line
+  file      - it changes the commented file
+
+Only the strongest match per comment is shown in the table; --json lists
+every matching commit.
+
+Examples:
+  gh pr-comments addressed
+  gh pr-comments addressed --pr owner/repo/123 --json`,
+	Args: cobra.NoArgs,
+	RunE: runAddressed,
+}
+
+func init() {
+	addressedCmd.Flags().StringVar(&addressedPR, "pr", "", "PR reference (e.g., owner/repo/123 or just 123)")
+	addressedCmd.RegisterFlagCompletionFunc("pr", completePRs)
+	addressedCmd.Flags().BoolVar(&addressedJsonOutput, "json", false, "Output in JSON format")
+	rootCmd.AddCommand(addressedCmd)
+}
+
+// addressedNearby is how many lines away from a comment a change may start
+// and still count as touching the commented lines.
+const addressedNearby = 3
+
+var matchStrength = map[string]int{"mentioned": 3, "lines": 2, "file": 1}
+
+type addressedMatch struct {
+	SHA     string `json:"sha"`
+	Subject string `json:"subject"`
+	Match   string `json:"match"`
+}
+
+type addressedComment struct {
+	ID       int64            `json:"id"`
+	Location string           `json:"location"`
+	Author   string           `json:"author"`
+	URL      string           `json:"url"`
+	Matches  []addressedMatch `json:"matches"`
+}
+
+func runAddressed(cmd *cobra.Command, args []string) error {
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	var prArgs []string
+	if addressedPR != "" {
+		prArgs = []string{addressedPR}
+	}
+
+	prRef, err := client.ResolvePRReference(prArgs)
+	if err != nil {
+		return fmt.Errorf("could not determine PR: %w\nPlease specify a PR with --pr or run from a branch with an associated PR", err)
+	}
+
+	comments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	var open []github.ReviewComment
+	for _, c := range comments {
+		if c.InReplyToID == 0 && !c.IsResolved {
+			open = append(open, c)
+		}
+	}
+	if len(open) == 0 {
+		if addressedJsonOutput {
+			return writeJSON(nil)
+		}
+		fmt.Println("No unresolved review comments.")
+		return nil
+	}
+
+	earliest := open[0].CreatedAt
+	for _, c := range open {
+		if c.CreatedAt.Before(earliest) {
+			earliest = c.CreatedAt
+		}
+	}
+
+	prCommits, err := client.GetPRCommits(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+	var commits []*github.PRCommit
+	for _, pc := range prCommits {
+		if !pc.Commit.Committer.Date.After(earliest) {
+			continue
+		}
+		detail, err := client.GetCommit(prRef.Owner, prRef.Repo, pc.SHA)
+		if err != nil {
+			return err
+		}
+		commits = append(commits, detail)
+	}
+
+	var results []addressedComment
+	for _, c := range open {
+		result := addressedComment{
+			ID:       c.ID,
+			Location: c.Location(),
+			Author:   c.User.Login,
+			URL:      c.HTMLURL,
+		}
+		for _, commit := range commits {
+			if !commit.Commit.Committer.Date.After(c.CreatedAt) {
+				continue
+			}
+			if match := matchCommitToComment(commit, c); match != "" {
+				result.Matches = append(result.Matches, addressedMatch{
+					SHA:     commit.SHA,
+					Subject: commit.Subject(),
+					Match:   match,
+				})
+			}
+		}
+		if len(result.Matches) == 0 {
+			continue
+		}
+		sort.SliceStable(result.Matches, func(i, j int) bool {
+			return matchStrength[result.Matches[i].Match] > matchStrength[result.Matches[j].Match]
+		})
+		results = append(results, result)
+	}
+
+	if addressedJsonOutput {
+		if results == nil {
+			results = []addressedComment{}
+		}
+		return writeJSON(results)
+	}
+
+	if len(results) == 0 {
+		fmt.Printf("None of the %d unresolved comment(s) match a later commit.\n", len(open))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tLOCATION\tMATCH\tCOMMIT\tSUBJECT")
+	for _, r := range results {
+		best := r.Matches[0]
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n",
+			r.ID, r.Location, best.Match, best.SHA[:7], github.TruncateString(best.Subject, 50))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("\n%d of %d unresolved comment(s) likely addressed\n", len(results), len(open))
+	return nil
+}
+
+// matchCommitToComment returns the strongest kind of match between a commit
+// and a comment, or "" when they are unrelated.
+func matchCommitToComment(commit *github.PRCommit, c github.ReviewComment) string {
+	for _, id := range trailer.MentionedComments(commit.Commit.Message) {
+		if id == c.ID {
+			return "mentioned"
+		}
+	}
+
+	for _, f := range commit.Files {
+		if f.Filename != c.Path {
+			continue
+		}
+		line := c.CurrentLine()
+		if c.OriginalLine != nil {
+			line = *c.OriginalLine
+		}
+		if line > 0 && patchTouchesLine(f.Patch, line) {
+			return "lines"
+		}
+		return "file"
+	}
+	return ""
+}
+
+var hunkHeader = regexp.MustCompile(`(?m)^@@ -(\d+)(?:,(\d+))? \+\d+(?:,\d+)? @@`)
+
+// patchTouchesLine reports whether any hunk in patch changes old-side lines
+// within addressedNearby of line.
+func patchTouchesLine(patch string, line int) bool {
+	for _, m := range hunkHeader.FindAllStringSubmatch(patch, -1) {
+		start, _ := strconv.Atoi(m[1])
+		count := 1
+		if m[2] != "" {
+			count, _ = strconv.Atoi(m[2])
+		}
+		end := start + count - 1
+		if line >= start-addressedNearby && line <= end+addressedNearby {
+			return true
+		}
+	}
+	return false
+}
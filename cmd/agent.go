@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// agentFormatVersion is bumped whenever the agent digest changes in a way
+// that could break a consumer parsing it.
+const agentFormatVersion = 1
+
+// writeAgentDigest writes comments in the compact "--format agent" layout.
+// The layout is a documented, stable contract (see README, "Agent Format"):
+// one header block, then one block per comment, ordered by PR, kind (review
+// comments before issue comments), file, line, and ID.
+func writeAgentDigest(w io.Writer, scope string, comments []unifiedComment) {
+	sorted := make([]unifiedComment, len(comments))
+	copy(sorted, comments)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.PR != b.PR {
+			return a.PR < b.PR
+		}
+		if a.Type != b.Type {
+			return a.Type == "review_comment"
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.EndLine != b.EndLine {
+			return a.EndLine < b.EndLine
+		}
+		return a.ID < b.ID
+	})
+
+	fmt.Fprintf(w, "gh-pr-comments agent v%d\n", agentFormatVersion)
+	fmt.Fprintf(w, "scope %s\n", scope)
+	fmt.Fprintf(w, "items %d\n", len(sorted))
+	for _, c := range sorted {
+		fmt.Fprintln(w)
+		fields := []string{agentKind(c.Type), fmt.Sprintf("%d", c.ID)}
+		if c.PR != 0 {
+			fields = append(fields, fmt.Sprintf("pr=%d", c.PR))
+		}
+		fields = append(fields, agentLocation(c), agentState(c), "@"+c.Author)
+		if c.Type == "review_comment" {
+			fields = append(fields, fmt.Sprintf("replies=%d", c.ReplyCount))
+		}
+		fmt.Fprintln(w, strings.Join(fields, " "))
+		fmt.Fprintf(w, "  body: %s\n", todoSummary(c.Body))
+		for _, r := range c.Replies {
+			fmt.Fprintf(w, "  reply %d @%s: %s\n", r.ID, r.Author, todoSummary(r.Body))
+		}
+		fmt.Fprintf(w, "  next: %s\n", strings.Join(agentNextActions(c), "; "))
+	}
+}
+
+func agentKind(commentType string) string {
+	if commentType == "issue_comment" {
+		return "ic"
+	}
+	return "rc"
+}
+
+func agentLocation(c unifiedComment) string {
+	switch {
+	case c.File == "":
+		return "-"
+	case c.Subject == "file" || c.EndLine == 0:
+		return c.File
+	case c.StartLine != c.EndLine:
+		return fmt.Sprintf("%s:%d-%d", c.File, c.StartLine, c.EndLine)
+	default:
+		return fmt.Sprintf("%s:%d", c.File, c.EndLine)
+	}
+}
+
+// agentState is a comma-separated list of state words: resolved or
+// unresolved (review comments only), then outdated and hidden when they
+// apply; "-" when none apply.
+func agentState(c unifiedComment) string {
+	var states []string
+	if c.Type == "review_comment" {
+		if c.Resolved == "true" {
+			states = append(states, "resolved")
+		} else {
+			states = append(states, "unresolved")
+		}
+		if c.Outdated == "true" {
+			states = append(states, "outdated")
+		}
+	}
+	if c.Hidden {
+		states = append(states, "hidden")
+	}
+	if len(states) == 0 {
+		return "-"
+	}
+	return strings.Join(states, ",")
+}
+
+// agentNextActions suggests gh pr-comments invocations for a comment. An
+// outdated unresolved thread was likely addressed, so resolving comes first.
+func agentNextActions(c unifiedComment) []string {
+	id := fmt.Sprintf("%d", c.ID)
+	switch {
+	case c.Hidden || c.Resolved == "true":
+		return []string{"none"}
+	case c.Type == "issue_comment":
+		return []string{"view " + id}
+	case c.Outdated == "true":
+		return []string{"resolve " + id, "reply " + id}
+	default:
+		return []string{"reply " + id, "resolve " + id}
+	}
+}
@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/journal"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	applyDryRun     bool
+	applyJsonOutput bool
+)
+
+var applyCmd = &cobra.Command{
+	Use:   "apply <plan.yaml>",
+	Short: "Execute a manifest written by 'plan'",
+	Long: `Execute the actions in a plan manifest and print a summary report.
+
+Every action is checked before anything is changed: the plan must be valid
+YAML, every comment must exist on the PR, hide reasons must be valid, and
+replies must have a body. If any check fails, nothing is executed.
+
+Actions then run in order as one transaction. If one fails, the remaining
+actions are not attempted and the ones already applied are rolled back in
+reverse order: resolved threads are unresolved, hidden comments are shown
+again, and posted replies are deleted. Actions whose rollback fails are
+reported as rollback_failed and need to be reverted by hand. Resolve actions
+on threads that are already resolved are reported as skipped
+(already_resolved) rather than executed.
+
+Examples:
+  gh pr-comments apply plan.yaml --dry-run
+  gh pr-comments apply plan.yaml
+  gh pr-comments apply plan.yaml --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runApply,
+}
+
+func init() {
+	applyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "Validate the plan and show what would be done")
+	applyCmd.Flags().BoolVar(&applyJsonOutput, "json", false, "Output in JSON format")
+	rootCmd.AddCommand(applyCmd)
+}
+
+type applyResult struct {
+	Action    string `json:"action"`
+	CommentID int64  `json:"comment_id"`
+	Status    string `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+}
+
+// applyTarget is what an action needs to know about its comment.
+type applyTarget struct {
+	nodeID   string
+	threadID string
+	resolved bool
+	hidden   bool
+	review   bool
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("read plan: %w", err)
+	}
+	var plan batchPlan
+	if err := yaml.Unmarshal(data, &plan); err != nil {
+		return fmt.Errorf("parse plan: %w", err)
+	}
+	if plan.PR == "" {
+		return fmt.Errorf("plan has no pr field")
+	}
+
+	if err := requireLiveData("apply"); err != nil {
+		return err
+	}
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	prRef, err := client.ResolvePRReference([]string{plan.PR})
+	if err != nil {
+		return fmt.Errorf("could not determine PR %s: %w", plan.PR, err)
+	}
+
+	targets, err := loadApplyTargets(client, prRef)
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+	for i, a := range plan.Actions {
+		if err := a.validate(); err != nil {
+			problems = append(problems, fmt.Sprintf("action %d: %v", i+1, err))
+			continue
+		}
+		t, ok := targets[a.CommentID]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("action %d: comment %d not found in PR %d", i+1, a.CommentID, prRef.Number))
+		case a.Action == "resolve" && t.threadID == "":
+			problems = append(problems, fmt.Sprintf("action %d: comment %d is not part of a review thread", i+1, a.CommentID))
+		case a.Action == "reply" && !t.review:
+			problems = append(problems, fmt.Sprintf("action %d: comment %d is an issue comment and cannot be replied to", i+1, a.CommentID))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("plan is invalid, nothing was applied:\n  %s", strings.Join(problems, "\n  "))
+	}
+
+	var results []applyResult
+	var applied []appliedAction
+	failed := false
+	for _, a := range plan.Actions {
+		result := applyResult{Action: a.Action, CommentID: a.CommentID}
+		switch {
+		case failed:
+			result.Status = "not_attempted"
+		case a.Action == "resolve" && targets[a.CommentID].resolved:
+			result.Status = "skipped"
+			result.Detail = skipAlreadyResolved
+		case applyDryRun:
+			result.Status = "would_apply"
+		default:
+			detail, done, err := executePlannedAction(client, prRef, a, targets[a.CommentID])
+			result.Detail = detail
+			if err != nil {
+				result.Status = "failed"
+				result.Detail = err.Error()
+				failed = true
+			} else {
+				result.Status = "applied"
+				done.result = len(results)
+				applied = append(applied, done)
+			}
+		}
+		results = append(results, result)
+	}
+
+	if failed {
+		for i := len(applied) - 1; i >= 0; i-- {
+			r := &results[applied[i].result]
+			if err := applied[i].undo(); err != nil {
+				r.Status = "rollback_failed"
+				r.Detail = err.Error()
+			} else {
+				r.Status = "rolled_back"
+			}
+		}
+	}
+	// Only what is still in effect on GitHub is worth undoing later.
+	for _, a := range applied {
+		if results[a.result].Status != "rolled_back" && a.entry.Action != "" {
+			journalAction(prRef, a.entry)
+		}
+	}
+
+	if applyJsonOutput {
+		if results == nil {
+			results = []applyResult{}
+		}
+		return writeJSON(results)
+	}
+
+	counts := make(map[string]int)
+	for _, r := range results {
+		counts[r.Status]++
+		line := fmt.Sprintf("%-13s %-8s %d", r.Status, r.Action, r.CommentID)
+		if r.Detail != "" {
+			line += "  " + r.Detail
+		}
+		if r.Status == "failed" || r.Status == "rollback_failed" {
+			fmt.Fprintln(os.Stderr, line)
+		} else {
+			fmt.Println(line)
+		}
+	}
+
+	fmt.Println(strings.Repeat("─", 40))
+	if applyDryRun {
+		fmt.Printf("Dry run: %d action(s) would be applied\n", counts["would_apply"])
+		return nil
+	}
+	if failed {
+		fmt.Printf("Failed: %d, rolled back: %d, rollback failed: %d, skipped: %d, not attempted: %d\n", counts["failed"], counts["rolled_back"], counts["rollback_failed"], counts["skipped"], counts["not_attempted"])
+		cmd.SilenceUsage = true
+		if counts["rollback_failed"] > 0 {
+			return fmt.Errorf("apply stopped at the first failure and %d action(s) could not be rolled back", counts["rollback_failed"])
+		}
+		return fmt.Errorf("apply stopped at the first failure; applied actions were rolled back")
+	}
+	fmt.Printf("Applied: %d, skipped: %d\n", counts["applied"], counts["skipped"])
+	return nil
+}
+
+func loadApplyTargets(client *github.Client, prRef *github.PRReference) (map[int64]applyTarget, error) {
+	reviewComments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+	issueComments, err := client.GetIssueComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+	threads, err := client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, fmt.Errorf("get review threads: %w", err)
+	}
+
+	commentToThread := make(map[int64]string)
+	resolvedThreads := make(map[string]bool)
+	for _, t := range threads {
+		resolvedThreads[t.ID] = t.IsResolved
+		for _, id := range t.CommentIDs {
+			commentToThread[id] = t.ID
+		}
+	}
+
+	targets := make(map[int64]applyTarget)
+	for _, c := range reviewComments {
+		threadID := commentToThread[c.ID]
+		targets[c.ID] = applyTarget{nodeID: c.NodeID, threadID: threadID, resolved: resolvedThreads[threadID], hidden: c.IsMinimized, review: true}
+	}
+	for _, c := range issueComments {
+		targets[c.ID] = applyTarget{nodeID: c.NodeID, hidden: c.IsMinimized}
+	}
+	return targets, nil
+}
+
+// appliedAction is an executed plan action: how to reverse it if a later
+// action fails, and how to journal it if none does.
+type appliedAction struct {
+	result int
+	undo   func() error
+	entry  journal.Entry
+}
+
+// executePlannedAction runs an action and returns a detail for the report
+// and what is needed to roll it back or journal it.
+func executePlannedAction(client *github.Client, prRef *github.PRReference, a plannedAction, t applyTarget) (string, appliedAction, error) {
+	switch a.Action {
+	case "resolve":
+		done := appliedAction{
+			undo:  func() error { return client.UnresolveThread(t.threadID) },
+			entry: journal.Entry{Action: "resolve", ThreadID: t.threadID},
+		}
+		return t.threadID, done, client.ResolveThread(t.threadID)
+	case "hide":
+		classifier, _ := github.ParseClassifier(a.Reason)
+		done := appliedAction{
+			undo: func() error {
+				if t.hidden {
+					// It was hidden before; leave it that way.
+					return nil
+				}
+				return client.UnminimizeComment(t.nodeID)
+			},
+			entry: journal.Entry{Action: "hide", NodeID: t.nodeID, Reason: string(classifier)},
+		}
+		if t.hidden {
+			done.entry = journal.Entry{}
+		}
+		return a.Reason, done, client.MinimizeComment(t.nodeID, classifier)
+	case "reply":
+		reply, err := client.ReplyToReviewComment(prRef.Owner, prRef.Repo, prRef.Number, a.CommentID, withSignature(a.Body))
+		if err != nil {
+			return "", appliedAction{}, err
+		}
+		done := appliedAction{
+			undo:  func() error { return client.DeleteReviewComment(prRef.Owner, prRef.Repo, reply.ID) },
+			entry: journal.Entry{Action: "reply", CommentID: reply.ID, URL: reply.HTMLURL},
+		}
+		return reply.HTMLURL, done, nil
+	}
+	return "", appliedAction{}, fmt.Errorf("invalid action: %s", a.Action)
+}
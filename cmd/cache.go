@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/STRRL/gh-pr-comments/internal/cache"
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cacheStatusJsonOutput bool
+	cacheClearAll         bool
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect and manage the local API response cache",
+}
+
+var cacheStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show what is cached per PR, its age, and disk usage",
+	Long: `Summarize the local response cache per PR: how many responses are stored,
+how many are still fresh (younger than GH_PR_COMMENTS_CACHE_TTL), the age of
+the newest and oldest, and the disk space they use. Repository-level reads,
+such as commit diffs, are listed under the repository.
+
+Examples:
+  gh pr-comments cache status
+  gh pr-comments cache status --json`,
+	Args: cobra.NoArgs,
+	RunE: runCacheStatus,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear [pr-reference]",
+	Short: "Remove a PR's cached responses, or everything with --all",
+	Long: `Remove the cached responses for a pull request, so the next command reads
+it from GitHub. --all empties the whole cache instead.
+
+If no PR reference is given, finds the PR for the current branch.
+
+PR reference can be:
+  - Full URL: https://github.com/owner/repo/pull/123
+  - Short form: owner/repo/123
+  - Just number: 123 (when in a repo context)
+  - Omitted: uses current branch's PR
+
+Examples:
+  gh pr-comments cache clear
+  gh pr-comments cache clear owner/repo/123
+  gh pr-comments cache clear --all`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runCacheClear,
+}
+
+var cacheGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Remove expired and unreadable cache entries",
+	Long: `Delete cached responses older than GH_PR_COMMENTS_CACHE_TTL, entries that
+cannot be read, and temp files left by interrupted writes. Expired entries
+are only served by --offline, so this reclaims disk space. When the cache
+is not enabled (GH_PR_COMMENTS_CACHE_TTL unset or 0), every entry counts as
+expired.
+
+Examples:
+  gh pr-comments cache gc
+  GH_PR_COMMENTS_CACHE_TTL=1h gh pr-comments cache gc`,
+	Args: cobra.NoArgs,
+	RunE: runCacheGC,
+}
+
+func init() {
+	cacheStatusCmd.Flags().BoolVar(&cacheStatusJsonOutput, "json", false, "Output in JSON format")
+	cacheClearCmd.Flags().BoolVar(&cacheClearAll, "all", false, "Clear every cached response")
+	cacheCmd.AddCommand(cacheStatusCmd, cacheClearCmd, cacheGCCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
+
+// cacheScope aggregates the cached responses of one PR or repository.
+type cacheScope struct {
+	Scope   string    `json:"scope"`
+	Entries int       `json:"entries"`
+	Fresh   int       `json:"fresh"`
+	Bytes   int64     `json:"bytes"`
+	Oldest  time.Time `json:"oldest"`
+	Newest  time.Time `json:"newest"`
+}
+
+type cacheStatus struct {
+	Dir     string       `json:"dir"`
+	TTL     string       `json:"ttl"`
+	Entries int          `json:"entries"`
+	Bytes   int64        `json:"bytes"`
+	Scopes  []cacheScope `json:"scopes"`
+}
+
+func runCacheStatus(cmd *cobra.Command, args []string) error {
+	ttl, err := cache.TTL()
+	if err != nil {
+		return err
+	}
+	infos, err := cache.List()
+	if err != nil {
+		return err
+	}
+
+	status := cacheStatus{Dir: cache.Dir(), TTL: ttl.String(), Scopes: []cacheScope{}}
+	byScope := make(map[string]*cacheScope)
+	for _, info := range infos {
+		name := info.PR
+		if name == "" {
+			name = "(other)"
+		}
+		s, ok := byScope[name]
+		if !ok {
+			s = &cacheScope{Scope: name, Oldest: info.StoredAt}
+			byScope[name] = s
+		}
+		s.Entries++
+		s.Bytes += info.Size
+		if time.Since(info.StoredAt) < ttl {
+			s.Fresh++
+		}
+		// infos are sorted oldest first.
+		s.Newest = info.StoredAt
+		status.Entries++
+		status.Bytes += info.Size
+	}
+	for _, s := range byScope {
+		status.Scopes = append(status.Scopes, *s)
+	}
+	sort.Slice(status.Scopes, func(i, j int) bool { return status.Scopes[i].Newest.After(status.Scopes[j].Newest) })
+
+	if cacheStatusJsonOutput {
+		return writeJSON(status)
+	}
+
+	fmt.Printf("Cache: %s (TTL %s)\n", status.Dir, status.TTL)
+	if status.Entries == 0 {
+		fmt.Println("The cache is empty.")
+		return nil
+	}
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SCOPE\tENTRIES\tFRESH\tSIZE\tNEWEST\tOLDEST")
+	for _, s := range status.Scopes {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\n",
+			s.Scope, s.Entries, s.Fresh, formatBytes(s.Bytes), cacheAge(s.Newest), cacheAge(s.Oldest))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("\n%d response(s), %s\n", status.Entries, formatBytes(status.Bytes))
+	return nil
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	if cacheClearAll {
+		if len(args) > 0 {
+			return fmt.Errorf("--all cannot be combined with a PR reference")
+		}
+		if err := cache.Clear(); err != nil {
+			return err
+		}
+		fmt.Println("Cleared the cache.")
+		return nil
+	}
+
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
+	if err != nil {
+		return err
+	}
+
+	infos, err := cache.List()
+	if err != nil {
+		return err
+	}
+	scope := fmt.Sprintf("%s/%s#%d", prRef.Owner, prRef.Repo, prRef.Number)
+	var keys []string
+	var freed int64
+	for _, info := range infos {
+		if info.PR == scope {
+			keys = append(keys, info.Key)
+			freed += info.Size
+		}
+	}
+	if err := cache.Remove(keys); err != nil {
+		return err
+	}
+	fmt.Printf("Cleared %d cached response(s) for %s (%s).\n", len(keys), scope, formatBytes(freed))
+	return nil
+}
+
+func runCacheGC(cmd *cobra.Command, args []string) error {
+	ttl, err := cache.TTL()
+	if err != nil {
+		return err
+	}
+	removed, freed, err := cache.GC(ttl)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Removed %d stale file(s), freed %s.\n", removed, formatBytes(freed))
+	return nil
+}
+
+// cacheAge renders how long ago t was, to the second.
+func cacheAge(t time.Time) string {
+	if t.IsZero() {
+		return "unknown"
+	}
+	return time.Since(t).Round(time.Second).String() + " ago"
+}
+
+// formatBytes renders n using binary units.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -1,12 +1,12 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/journal"
 	"github.com/spf13/cobra"
 )
 
@@ -49,8 +49,9 @@ Examples:
 
   # Get JSON output
   gh pr-comments cleanup --json`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runCleanup,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runCleanup,
 }
 
 func init() {
@@ -78,12 +79,16 @@ type CleanupOutput struct {
 }
 
 func runCleanup(cmd *cobra.Command, args []string) error {
+	if err := requireLiveData("cleanup"); err != nil {
+		return err
+	}
 	client, err := github.NewClient()
 	if err != nil {
 		return err
 	}
+	client.RequireCompleteStatus()
 
-	prRef, err := client.ResolvePRReference(args)
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
 	if err != nil {
 		return err
 	}
@@ -136,15 +141,14 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 				output.Failed = append(output.Failed, c)
 			} else {
 				successful = append(successful, c)
+				journalAction(prRef, journal.Entry{Action: "hide", NodeID: c.Review.NodeID, Reason: string(github.ClassifierResolved)})
 			}
 		}
 		output.Minimized = successful
 	}
 
 	if cleanupJsonOutput {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(output)
+		return writeJSON(output)
 	}
 
 	printCleanupResults(output, cleanupDryRun)
@@ -176,7 +180,10 @@ func identifyCleanupCandidates(reviews []github.Review, comments []github.Review
 			ResolvedCount: resolvedCount,
 		}
 
-		if total == 0 {
+		if r.IsMinimized {
+			candidate.CanMinimize = false
+			candidate.Reason = "already hidden"
+		} else if total == 0 {
 			candidate.CanMinimize = false
 			candidate.Reason = "no inline comments"
 		} else if resolvedCount < total {
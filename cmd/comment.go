@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	commentBody       string
+	commentPR         string
+	commentLine       int
+	commentStartLine  int
+	commentSide       string
+	commentSubject    string
+	commentJsonOutput bool
+)
+
+var commentCmd = &cobra.Command{
+	Use:   "comment <path>",
+	Short: "Add a review comment to a file or line",
+	Long: `Add a new review comment on a pull request file.
+
+Without --line, the comment is attached to the whole file (a file-level
+comment). With --line, it is attached to that line of the PR head; add
+--start-line to comment on a range.
+
+The comment is posted against the PR's current head commit. @mentions of
+users who are neither collaborators nor taking part in the PR are warned
+about.
+
+Examples:
+  # File-level comment
+  gh pr-comments comment cmd/list.go --body "Consider splitting this file"
+
+  # Line comment
+  gh pr-comments comment cmd/list.go --line 42 --body "Nit: rename"
+
+  # Multi-line comment on the old side of the diff
+  gh pr-comments comment cmd/list.go --start-line 10 --line 24 --side LEFT --body "Why remove this?"
+
+  # Body from stdin
+  echo "Looks off" | gh pr-comments comment main.go --line 7`,
+	Args: cobra.ExactArgs(1),
+	RunE: runComment,
+}
+
+func init() {
+	commentCmd.Flags().StringVar(&commentBody, "body", "", "Comment body (reads from stdin if not provided)")
+	commentCmd.Flags().StringVar(&commentPR, "pr", "", "PR reference (e.g., owner/repo/123 or just 123)")
+	commentCmd.RegisterFlagCompletionFunc("pr", completePRs)
+	commentCmd.RegisterFlagCompletionFunc("body", completeBodyMentions)
+	commentCmd.Flags().IntVar(&commentLine, "line", 0, "Line to comment on (omit for a file-level comment)")
+	commentCmd.Flags().IntVar(&commentStartLine, "start-line", 0, "First line of a multi-line comment")
+	commentCmd.Flags().StringVar(&commentSide, "side", "RIGHT", "Side of the diff (RIGHT for new code, LEFT for removed code)")
+	commentCmd.Flags().StringVar(&commentSubject, "subject", "", "Comment subject (file/line, inferred from --line when omitted)")
+	commentCmd.Flags().BoolVar(&commentJsonOutput, "json", false, "Output in JSON format")
+	commentCmd.RegisterFlagCompletionFunc("side", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"RIGHT\tNew version of the file", "LEFT\tOld version of the file"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	commentCmd.RegisterFlagCompletionFunc("subject", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"file\tComment on the whole file", "line\tComment on specific lines"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	rootCmd.AddCommand(commentCmd)
+}
+
+func runComment(cmd *cobra.Command, args []string) error {
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	subject := commentSubject
+	if subject == "" {
+		subject = "line"
+		if commentLine == 0 {
+			subject = "file"
+		}
+	}
+	switch subject {
+	case "file":
+		if commentLine != 0 || commentStartLine != 0 {
+			return fmt.Errorf("--line and --start-line cannot be used with a file-level comment")
+		}
+	case "line":
+		if commentLine == 0 {
+			return fmt.Errorf("--line is required for a line comment")
+		}
+		if commentStartLine != 0 && commentStartLine >= commentLine {
+			return fmt.Errorf("--start-line must be less than --line")
+		}
+	default:
+		return fmt.Errorf("invalid subject: %s (valid: file, line)", subject)
+	}
+
+	body, err := readBody(commentBody, "comment body")
+	if err != nil {
+		return err
+	}
+
+	var prArgs []string
+	if commentPR != "" {
+		prArgs = []string{commentPR}
+	}
+
+	prRef, err := client.ResolvePRReference(prArgs)
+	if err != nil {
+		return fmt.Errorf("could not determine PR: %w\nPlease specify a PR with --pr or run from a branch with an associated PR", err)
+	}
+
+	pr, err := client.GetPullRequest(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	warnUnknownMentions(client, prRef, body)
+
+	input := github.NewReviewComment{
+		Body:     body,
+		CommitID: pr.Head.SHA,
+		Path:     args[0],
+	}
+	if subject == "file" {
+		input.SubjectType = "file"
+	} else {
+		input.Line = commentLine
+		input.StartLine = commentStartLine
+		input.Side = strings.ToUpper(commentSide)
+	}
+
+	created, err := client.CreateReviewComment(prRef.Owner, prRef.Repo, prRef.Number, input)
+	if err != nil {
+		return err
+	}
+	journalAction(prRef, journal.Entry{Action: "comment", CommentID: created.ID, URL: created.HTMLURL})
+
+	if commentJsonOutput {
+		return writeJSON(created)
+	}
+
+	fmt.Println("Comment created successfully!")
+	fmt.Println(strings.Repeat("─", 60))
+	fmt.Printf("ID:      %d\n", created.ID)
+	fmt.Printf("File:    %s\n", created.Location())
+	fmt.Printf("URL:     %s\n", created.HTMLURL)
+	fmt.Println(strings.Repeat("─", 60))
+	return nil
+}
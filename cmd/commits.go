@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var commitsJsonOutput bool
+
+var commitsCmd = &cobra.Command{
+	Use:   "commits [pr-reference]",
+	Short: "List comments left on individual commits of a pull request",
+	Long: `List commit comments: comments attached to a single commit of the pull
+request rather than to the PR diff. They do not show up in 'list' or 'tree'
+because GitHub stores them separately.
+
+If no PR reference is given, finds the PR for the current branch.
+
+PR reference can be:
+  - Full URL: https://github.com/owner/repo/pull/123
+  - Short form: owner/repo/123
+  - Just number: 123 (when in a repo context)
+  - Omitted: uses current branch's PR
+
+Examples:
+  gh pr-comments commits
+  gh pr-comments commits owner/repo/123
+  gh pr-comments commits --json`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runCommits,
+}
+
+func init() {
+	commitsCmd.Flags().BoolVar(&commitsJsonOutput, "json", false, "Output in JSON format")
+	rootCmd.AddCommand(commitsCmd)
+}
+
+type commitWithComments struct {
+	SHA      string                 `json:"sha"`
+	Subject  string                 `json:"subject"`
+	Comments []github.CommitComment `json:"comments"`
+}
+
+func runCommits(cmd *cobra.Command, args []string) error {
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
+	if err != nil {
+		return err
+	}
+
+	commits, err := client.GetPRCommits(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	var results []commitWithComments
+	total := 0
+	for _, c := range commits {
+		comments, err := client.GetCommitComments(prRef.Owner, prRef.Repo, c.SHA)
+		if err != nil {
+			return err
+		}
+		if len(comments) == 0 {
+			continue
+		}
+		results = append(results, commitWithComments{
+			SHA:      c.SHA,
+			Subject:  c.Subject(),
+			Comments: comments,
+		})
+		total += len(comments)
+	}
+
+	if commitsJsonOutput {
+		return writeJSON(results)
+	}
+
+	if total == 0 {
+		fmt.Printf("No commit comments found across %d commit(s).\n", len(commits))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "COMMIT\tID\tFILE\tLINE\tAUTHOR\tBODY")
+	for _, r := range results {
+		for _, c := range r.Comments {
+			line := ""
+			if c.Line != nil {
+				line = fmt.Sprintf("%d", *c.Line)
+			}
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\n",
+				r.SHA[:7], c.ID, c.Path, line, c.User.Login, github.TruncateString(c.Body, 40))
+		}
+	}
+	return w.Flush()
+}
@@ -2,22 +2,33 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 
 	"github.com/STRRL/gh-pr-comments/internal/github"
 	"github.com/spf13/cobra"
 )
 
+// completionClient applies the global flags, such as --cwd, and returns a
+// client for a completion function.
+func completionClient() (*github.Client, error) {
+	if err := applyRootFlags(); err != nil {
+		return nil, err
+	}
+	return github.NewClient()
+}
+
 func completeCommentIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if len(args) != 0 {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	client, err := github.NewClient()
+	client, err := completionClient()
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	prRef, err := client.ResolvePRReference(args)
+	prRef, err := client.ResolvePRReference(completionPRArgs(cmd, args))
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -46,12 +57,12 @@ func completeCommentIDs(cmd *cobra.Command, args []string, toComplete string) ([
 }
 
 func completeReviewCommentIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	client, err := github.NewClient()
+	client, err := completionClient()
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	prRef, err := client.ResolvePRReference(args)
+	prRef, err := client.ResolvePRReference(completionPRArgs(cmd, args))
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -71,12 +82,12 @@ func completeReviewCommentIDs(cmd *cobra.Command, args []string, toComplete stri
 }
 
 func completeReviewIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	client, err := github.NewClient()
+	client, err := completionClient()
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	prRef, err := client.ResolvePRReference(args)
+	prRef, err := client.ResolvePRReference(completionPRArgs(cmd, args))
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -97,3 +108,173 @@ func completeReviewIDs(cmd *cobra.Command, args []string, toComplete string) ([]
 
 	return completions, cobra.ShellCompDirectiveNoFileComp
 }
+
+// completePRs lists the open pull requests in the current repository.
+func completePRs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	client, err := completionClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	owner, repo, err := client.GetCurrentRepo()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	prs, err := client.ListPullRequests(owner, repo, "open")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var completions []string
+	for _, pr := range prs {
+		completion := fmt.Sprintf("%d\t[%s] %s", pr.Number, pr.User.Login, github.TruncateString(pr.Title, 50))
+		completions = append(completions, completion)
+	}
+
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeAuthors lists the authors of review and issue comments on the PR,
+// most active first, with their comment counts.
+func completeAuthors(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	client, err := completionClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	prRef, err := client.ResolvePRReference(completionPRArgs(cmd, args))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	counts := make(map[string]int)
+	if reviewComments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number); err == nil {
+		for _, c := range reviewComments {
+			counts[c.User.Login]++
+		}
+	}
+	if issueComments, err := client.GetIssueComments(prRef.Owner, prRef.Repo, prRef.Number); err == nil {
+		for _, c := range issueComments {
+			counts[c.User.Login]++
+		}
+	}
+
+	authors := make([]string, 0, len(counts))
+	for login := range counts {
+		authors = append(authors, login)
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if counts[authors[i]] != counts[authors[j]] {
+			return counts[authors[i]] > counts[authors[j]]
+		}
+		return authors[i] < authors[j]
+	})
+
+	var completions []string
+	for _, login := range authors {
+		completions = append(completions, fmt.Sprintf("%s\t%d comment(s)", login, counts[login]))
+	}
+
+	// Keep the most-active-first order instead of letting the shell sort.
+	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// completeCommentedFiles lists the file paths that have review comments on
+// the PR, with their comment counts.
+func completeCommentedFiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	client, err := completionClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	prRef, err := client.ResolvePRReference(completionPRArgs(cmd, args))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	reviewComments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	counts := make(map[string]int)
+	for _, c := range reviewComments {
+		counts[c.Path]++
+	}
+
+	paths := make([]string, 0, len(counts))
+	for path := range counts {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var completions []string
+	for _, path := range paths {
+		completions = append(completions, fmt.Sprintf("%s\t%d comment(s)", path, counts[path]))
+	}
+
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeThreadIDs lists the PR's review thread IDs, unresolved first, with
+// their location, resolution state, and a preview of the first comment.
+func completeThreadIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	client, err := completionClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	prRef, err := client.ResolvePRReference(completionPRArgs(cmd, args))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	threads, err := client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	sort.SliceStable(threads, func(i, j int) bool {
+		return !threads[i].IsResolved && threads[j].IsResolved
+	})
+
+	var completions []string
+	for _, t := range threads {
+		state := "unresolved"
+		if t.IsResolved {
+			state = "resolved"
+		}
+		desc := fmt.Sprintf("[%s] %s", state, t.Location())
+		if len(t.Comments) > 0 {
+			desc += ": " + github.TruncateString(t.Comments[0].Body, 40)
+		}
+		completions = append(completions, fmt.Sprintf("%s\t%s", t.ID, desc))
+	}
+
+	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// prArgAnnotation marks a command that takes a PR reference as a positional
+// argument. Its value is the argument's index.
+const prArgAnnotation = "pr-arg"
+
+// prArgAt returns the annotations for a command whose positional argument at
+// index is a PR reference.
+func prArgAt(index int) map[string]string {
+	return map[string]string{prArgAnnotation: strconv.Itoa(index)}
+}
+
+// completionPRArgs prefers an already-typed --pr value over the positional
+// arguments when completing values that depend on the PR. Positional
+// arguments are only used by commands annotated with prArgAt.
+func completionPRArgs(cmd *cobra.Command, args []string) []string {
+	if f := cmd.Flags().Lookup("pr"); f != nil && f.Value.String() != "" {
+		return []string{f.Value.String()}
+	}
+	index, err := strconv.Atoi(cmd.Annotations[prArgAnnotation])
+	if err != nil || index >= len(args) {
+		return nil
+	}
+	return args[index : index+1]
+}
@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	daemonEvery     time.Duration
+	daemonRepos     []string
+	daemonTasks     []string
+	daemonRules     string
+	daemonOnce      bool
+	daemonDryRun    bool
+	daemonLogFormat string
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Periodically run cleanup and enforce across repositories",
+	Long: `Run comment hygiene on a schedule: every --every, for each open pull
+request in each repository in --repos, run the selected tasks:
+
+  cleanup   hide reviews whose inline comments are all resolved
+  enforce   apply the policy rules (see 'enforce --help')
+
+The rules come from --rules, or from each repository's
+` + defaultRulesFile + ` on its default branch; repositories without
+rules skip enforce.
+
+Every action and error is logged to stderr as a structured record
+(--log-format text or json). The first cycle starts immediately; --once
+runs a single cycle and exits, for use from cron. Ctrl-C or SIGTERM stops
+after the current pull request.
+
+Examples:
+  gh pr-comments daemon --repos owner/a,owner/b
+  gh pr-comments daemon --every 1h --repos owner/a --tasks enforce --rules rules.yaml
+  gh pr-comments daemon --repos owner/a --once --dry-run --log-format json`,
+	Args: cobra.NoArgs,
+	RunE: runDaemon,
+}
+
+func init() {
+	daemonCmd.Flags().DurationVar(&daemonEvery, "every", 15*time.Minute, "Time between cycles")
+	daemonCmd.Flags().StringSliceVar(&daemonRepos, "repos", nil, "Repositories to process (owner/repo, comma-separated)")
+	daemonCmd.Flags().StringSliceVar(&daemonTasks, "tasks", []string{"cleanup", "enforce"}, "Tasks to run (cleanup/enforce)")
+	daemonCmd.Flags().StringVar(&daemonRules, "rules", "", "Policy file for enforce (default each repository's "+defaultRulesFile+")")
+	daemonCmd.Flags().BoolVar(&daemonOnce, "once", false, "Run one cycle and exit")
+	daemonCmd.Flags().BoolVar(&daemonDryRun, "dry-run", false, "Log what would be done without changing anything")
+	daemonCmd.Flags().StringVar(&daemonLogFormat, "log-format", "text", "Log format (text/json)")
+	_ = daemonCmd.MarkFlagRequired("repos")
+	rootCmd.AddCommand(daemonCmd)
+}
+
+// hygieneDaemon holds what persists between cycles.
+type hygieneDaemon struct {
+	client *github.Client
+	log    *slog.Logger
+	tasks  map[string]bool
+	policy *commentPolicy
+}
+
+func runDaemon(cmd *cobra.Command, args []string) error {
+	if daemonEvery <= 0 {
+		return fmt.Errorf("--every must be positive")
+	}
+	var handler slog.Handler
+	switch daemonLogFormat {
+	case "text":
+		handler = slog.NewTextHandler(os.Stderr, nil)
+	case "json":
+		handler = slog.NewJSONHandler(os.Stderr, nil)
+	default:
+		return fmt.Errorf("invalid --log-format value: %s (valid: text, json)", daemonLogFormat)
+	}
+
+	d := &hygieneDaemon{log: slog.New(handler), tasks: make(map[string]bool)}
+	for _, t := range daemonTasks {
+		if t != "cleanup" && t != "enforce" {
+			return fmt.Errorf("invalid --tasks value: %s (valid: cleanup, enforce)", t)
+		}
+		d.tasks[t] = true
+	}
+	for _, r := range daemonRepos {
+		if owner, repo, ok := strings.Cut(r, "/"); !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+			return fmt.Errorf("invalid repository: %s (expected owner/repo)", r)
+		}
+	}
+	if daemonRules != "" {
+		policy, err := loadPolicy(daemonRules)
+		if err != nil {
+			return err
+		}
+		d.policy = policy
+	}
+
+	if err := requireLiveData("daemon"); err != nil {
+		return err
+	}
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+	client.RequireCompleteStatus()
+	d.client = client
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	cmd.SilenceUsage = true
+
+	d.log.Info("daemon started", "repos", daemonRepos, "tasks", daemonTasks, "every", daemonEvery.String(), "dry_run", daemonDryRun)
+	ticker := time.NewTicker(daemonEvery)
+	defer ticker.Stop()
+	for {
+		d.cycle(ctx)
+		if daemonOnce {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			d.log.Info("daemon stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *hygieneDaemon) cycle(ctx context.Context) {
+	start := time.Now()
+	prs, actions, failures := 0, 0, 0
+	for _, fullName := range daemonRepos {
+		owner, repo, _ := strings.Cut(fullName, "/")
+		log := d.log.With("repo", fullName)
+
+		policy := d.policy
+		if d.tasks["enforce"] && policy == nil {
+			policy = d.repoPolicy(owner, repo, log)
+		}
+
+		open, err := d.client.ListPullRequests(owner, repo, "open")
+		if err != nil {
+			log.Error("list pull requests failed", "error", err)
+			failures++
+			continue
+		}
+		for _, pr := range open {
+			if ctx.Err() != nil {
+				return
+			}
+			prs++
+			prRef := &github.PRReference{Owner: owner, Repo: repo, Number: pr.Number}
+			prLog := log.With("pr", pr.Number)
+			if d.tasks["cleanup"] {
+				n, f := d.cleanup(prRef, prLog)
+				actions, failures = actions+n, failures+f
+			}
+			if d.tasks["enforce"] && policy != nil {
+				n, f := d.enforce(prRef, policy, prLog)
+				actions, failures = actions+n, failures+f
+			}
+		}
+	}
+	d.log.Info("cycle finished", "prs", prs, "actions", actions, "failures", failures, "duration", time.Since(start).Round(time.Millisecond).String())
+}
+
+// repoPolicy fetches the repository's rules from its default branch, or
+// returns nil when it has none or they are invalid.
+func (d *hygieneDaemon) repoPolicy(owner, repo string, log *slog.Logger) *commentPolicy {
+	data, err := d.client.GetFileContent(owner, repo, defaultRulesFile, "")
+	if github.IsNotFound(err) {
+		log.Debug("no rules file, skipping enforce")
+		return nil
+	}
+	if err != nil {
+		log.Error("read rules failed", "error", err)
+		return nil
+	}
+	policy, err := parsePolicy(data, owner+"/"+repo+":"+defaultRulesFile)
+	if err != nil {
+		log.Error("invalid rules", "error", err)
+		return nil
+	}
+	return policy
+}
+
+func (d *hygieneDaemon) cleanup(prRef *github.PRReference, log *slog.Logger) (actions, failures int) {
+	reviews, err := d.client.GetReviews(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		log.Error("cleanup failed", "error", err)
+		return 0, 1
+	}
+	comments, err := d.client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		log.Error("cleanup failed", "error", err)
+		return 0, 1
+	}
+	for _, c := range identifyCleanupCandidates(reviews, comments) {
+		if !c.CanMinimize {
+			continue
+		}
+		attrs := []any{"task", "cleanup", "review_id", c.Review.ID, "reviewer", c.Review.User.Login}
+		if daemonDryRun {
+			log.Info("would hide review", attrs...)
+			actions++
+			continue
+		}
+		if err := d.client.MinimizeComment(c.Review.NodeID, github.ClassifierResolved); err != nil {
+			log.Error("hide review failed", append(attrs, "error", err)...)
+			failures++
+			continue
+		}
+		journalAction(prRef, journal.Entry{Action: "hide", NodeID: c.Review.NodeID, Reason: string(github.ClassifierResolved)})
+		log.Info("hid review", attrs...)
+		actions++
+	}
+	return actions, failures
+}
+
+func (d *hygieneDaemon) enforce(prRef *github.PRReference, policy *commentPolicy, log *slog.Logger) (actions, failures int) {
+	results, err := enforcePolicy(d.client, prRef, policy, daemonDryRun)
+	if err != nil {
+		log.Error("enforce failed", "error", err)
+		return 0, 1
+	}
+	for _, r := range results {
+		attrs := []any{"task", "enforce", "rule", r.Rule, "action", r.Action, "comment_id", r.CommentID, "status", r.Status}
+		if r.Detail != "" {
+			attrs = append(attrs, "detail", r.Detail)
+		}
+		if r.Status == "failed" {
+			log.Error("rule action failed", attrs...)
+			failures++
+			continue
+		}
+		log.Info("rule action", attrs...)
+		actions++
+	}
+	return actions, failures
+}
@@ -0,0 +1,313 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/journal"
+	"github.com/STRRL/gh-pr-comments/internal/textsim"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dedupePR         string
+	dedupeJsonOutput bool
+	dedupeDryRun     bool
+	dedupeThreshold  float64
+	dedupeRegion     int
+	dedupeAuthor     string
+	dedupeAcrossPRs  bool
+	dedupeBotsFile   string
+	dedupeScan       int
+)
+
+var dedupeCmd = &cobra.Command{
+	Use:   "dedupe",
+	Short: "Hide near-duplicate comments",
+	Long: `Find near-duplicate comments and hide all but the oldest of each group.
+
+Two comments are duplicates when they have the same author, their bodies are
+at least --threshold similar (0-1, comparing the words they use), and, for
+review comments, they are on the same file within --region lines of each
+other. This is common when a review bot runs again on every push.
+
+Replies are never treated as duplicates. Hidden comments are marked with the
+"duplicate" reason.
+
+With --across-prs, general comments by bots are instead compared with the
+other open PRs of the repository (up to --scan of them, newest first), to
+cut license, DCO, and coverage boilerplate posted on every PR. A bot comment
+is a duplicate when the same bot posted an earlier comment with the same
+marker and a body at least --threshold similar on another PR; every such
+copy on this PR is hidden. The marker is the first hidden HTML comment
+(<!-- ... -->) in the body; comments without one are left alone.
+
+Which bots are considered is configured per bot in --bots, or in
+` + defaultBotsFile + ` at the root of the repository. Without the file,
+every bot is considered with the defaults:
+
+  bots:
+    - author: codecov[bot]
+      marker: '<!-- codecov -->'   # regular expression; default: first HTML comment
+      threshold: 0.6               # default: --threshold
+    - author: cla-assistant[bot]
+
+Examples:
+  # Preview duplicate groups
+  gh pr-comments dedupe --dry-run
+
+  # Only consider a single bot, with a stricter threshold
+  gh pr-comments dedupe --author "coderabbitai[bot]" --threshold 0.95
+
+  # Dedupe another PR
+  gh pr-comments dedupe --pr owner/repo/123
+
+  # Hide bot boilerplate already posted on other open PRs
+  gh pr-comments dedupe --across-prs --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runDedupe,
+}
+
+func init() {
+	dedupeCmd.Flags().StringVar(&dedupePR, "pr", "", "PR reference (e.g., owner/repo/123 or just 123)")
+	dedupeCmd.RegisterFlagCompletionFunc("pr", completePRs)
+	dedupeCmd.Flags().BoolVar(&dedupeJsonOutput, "json", false, "Output in JSON format")
+	dedupeCmd.Flags().BoolVar(&dedupeDryRun, "dry-run", false, "Show duplicate groups without hiding anything")
+	dedupeCmd.Flags().Float64Var(&dedupeThreshold, "threshold", 0.9, "Minimum body similarity (0-1) to count as a duplicate")
+	dedupeCmd.Flags().IntVar(&dedupeRegion, "region", 5, "Maximum line distance between review comments on the same file")
+	dedupeCmd.Flags().StringVar(&dedupeAuthor, "author", "", "Only consider comments by this author")
+	dedupeCmd.Flags().BoolVar(&dedupeAcrossPRs, "across-prs", false, "Hide bot comments repeated from other open PRs")
+	dedupeCmd.Flags().StringVar(&dedupeBotsFile, "bots", "", "Per-bot settings for --across-prs (default "+defaultBotsFile+" in the repository)")
+	dedupeCmd.Flags().IntVar(&dedupeScan, "scan", 30, "Maximum number of other open PRs to compare with (--across-prs)")
+	rootCmd.AddCommand(dedupeCmd)
+}
+
+type dedupeCandidate struct {
+	ID        int64     `json:"id"`
+	NodeID    string    `json:"-"`
+	Type      string    `json:"type"`
+	Author    string    `json:"author"`
+	Location  string    `json:"location,omitempty"`
+	Body      string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	PR        int       `json:"pr,omitempty"`
+
+	path string
+	line int
+}
+
+type dedupeDuplicate struct {
+	dedupeCandidate
+	Similarity float64 `json:"similarity"`
+	Action     string  `json:"action"`
+	Success    bool    `json:"success"`
+	Error      string  `json:"error,omitempty"`
+}
+
+type dedupeGroup struct {
+	Keep       dedupeCandidate   `json:"keep"`
+	Duplicates []dedupeDuplicate `json:"duplicates"`
+}
+
+func runDedupe(cmd *cobra.Command, args []string) error {
+	if dedupeThreshold <= 0 || dedupeThreshold > 1 {
+		return fmt.Errorf("--threshold must be greater than 0 and at most 1")
+	}
+	var bots *botDedupeConfig
+	if dedupeAcrossPRs {
+		if dedupeScan < 1 {
+			return fmt.Errorf("--scan must be at least 1")
+		}
+		var err error
+		if bots, err = loadBotDedupeConfig(dedupeBotsFile); err != nil {
+			return err
+		}
+	}
+
+	if err := requireLiveData("dedupe"); err != nil {
+		return err
+	}
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	var prArgs []string
+	if dedupePR != "" {
+		prArgs = []string{dedupePR}
+	}
+
+	prRef, err := client.ResolvePRReference(prArgs)
+	if err != nil {
+		return fmt.Errorf("could not determine PR: %w\nPlease specify a PR with --pr or run from a branch with an associated PR", err)
+	}
+
+	issueComments, err := client.GetIssueComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	var groups []dedupeGroup
+	if dedupeAcrossPRs {
+		groups, err = findCrossPRDuplicates(client, prRef, issueComments, bots)
+	} else {
+		groups, err = findPRDuplicates(client, prRef, issueComments)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !dedupeDryRun {
+		for gi := range groups {
+			for di := range groups[gi].Duplicates {
+				d := &groups[gi].Duplicates[di]
+				d.Action = "hide"
+				if err := client.MinimizeComment(d.NodeID, github.ClassifierDuplicate); err != nil {
+					d.Error = err.Error()
+				} else {
+					d.Success = true
+					journalAction(prRef, journal.Entry{Action: "hide", NodeID: d.NodeID, Reason: string(github.ClassifierDuplicate)})
+				}
+			}
+		}
+	}
+
+	if dedupeJsonOutput {
+		if groups == nil {
+			groups = []dedupeGroup{}
+		}
+		return writeJSON(groups)
+	}
+
+	if len(groups) == 0 {
+		fmt.Println("No duplicate comments found.")
+		return nil
+	}
+
+	total, failed := 0, 0
+	for _, g := range groups {
+		fmt.Printf("Keep %d (%s by %s) %s\n", g.Keep.ID, g.Keep.Type, g.Keep.Author, g.Keep.Location)
+		for _, d := range g.Duplicates {
+			total++
+			switch {
+			case dedupeDryRun:
+				fmt.Printf("  Would hide %d (%.0f%% similar)\n", d.ID, d.Similarity*100)
+			case d.Success:
+				fmt.Printf("  Hidden %d (%.0f%% similar)\n", d.ID, d.Similarity*100)
+			default:
+				failed++
+				fmt.Printf("  Failed: comment %d - %s\n", d.ID, d.Error)
+			}
+		}
+	}
+
+	fmt.Println(strings.Repeat("─", 40))
+	if dedupeDryRun {
+		fmt.Printf("Dry run: %d duplicate(s) in %d group(s) would be hidden\n", total, len(groups))
+	} else {
+		fmt.Printf("Processed: %d succeeded, %d failed\n", total-failed, failed)
+	}
+	return nil
+}
+
+// findPRDuplicates groups near-duplicate comments within one PR.
+func findPRDuplicates(client *github.Client, prRef *github.PRReference, issueComments []github.IssueComment) ([]dedupeGroup, error) {
+	reviewComments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []dedupeCandidate
+	for _, c := range reviewComments {
+		if c.InReplyToID != 0 {
+			continue
+		}
+		candidates = append(candidates, dedupeCandidate{
+			ID:        c.ID,
+			NodeID:    c.NodeID,
+			Type:      "review_comment",
+			Author:    c.User.Login,
+			Location:  c.Location(),
+			Body:      c.Body,
+			CreatedAt: c.CreatedAt,
+			path:      c.Path,
+			line:      c.CurrentLine(),
+		})
+	}
+	for _, c := range issueComments {
+		candidates = append(candidates, dedupeCandidate{
+			ID:        c.ID,
+			NodeID:    c.NodeID,
+			Type:      "issue_comment",
+			Author:    c.User.Login,
+			Body:      c.Body,
+			CreatedAt: c.CreatedAt,
+		})
+	}
+
+	return findDuplicateGroups(candidates), nil
+}
+
+// findDuplicateGroups compares each comment, oldest first, against the
+// comment kept for each earlier group and joins the first group it matches.
+// Only groups with at least one duplicate are returned.
+func findDuplicateGroups(candidates []dedupeCandidate) []dedupeGroup {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].CreatedAt.Before(candidates[j].CreatedAt)
+	})
+
+	var groups []dedupeGroup
+	for _, c := range candidates {
+		if dedupeAuthor != "" && !strings.EqualFold(c.Author, dedupeAuthor) {
+			continue
+		}
+		matched := false
+		for gi := range groups {
+			keep := groups[gi].Keep
+			if !sameRegion(keep, c) {
+				continue
+			}
+			if sim := textsim.Similarity(keep.Body, c.Body); sim >= dedupeThreshold {
+				groups[gi].Duplicates = append(groups[gi].Duplicates, dedupeDuplicate{
+					dedupeCandidate: c,
+					Similarity:      sim,
+					Action:          "would_hide",
+					Success:         true,
+				})
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			groups = append(groups, dedupeGroup{Keep: c})
+		}
+	}
+
+	var result []dedupeGroup
+	for _, g := range groups {
+		if len(g.Duplicates) > 0 {
+			result = append(result, g)
+		}
+	}
+	return result
+}
+
+func sameRegion(a, b dedupeCandidate) bool {
+	if a.Type != b.Type || a.Author != b.Author {
+		return false
+	}
+	if a.Type != "review_comment" {
+		return true
+	}
+	if a.path != b.path {
+		return false
+	}
+	diff := a.line - b.line
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= dedupeRegion
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/marker"
+	"github.com/STRRL/gh-pr-comments/internal/textsim"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultBotsFile = ".github/pr-comments-bots.yaml"
+
+// botDedupeConfig is the YAML file that says which bots 'dedupe
+// --across-prs' considers and how their comments are recognized.
+type botDedupeConfig struct {
+	Bots []botDedupeRule `yaml:"bots"`
+}
+
+type botDedupeRule struct {
+	Author    string  `yaml:"author"`
+	Marker    string  `yaml:"marker,omitempty"`
+	Threshold float64 `yaml:"threshold,omitempty"`
+
+	marker *regexp.Regexp
+}
+
+// loadBotDedupeConfig reads the bots file. A missing default file is not an
+// error: every bot is considered, with the default marker and threshold.
+func loadBotDedupeConfig(path string) (*botDedupeConfig, error) {
+	explicit := path != ""
+	if !explicit {
+		root, err := github.GetRepoRoot()
+		if err != nil {
+			return &botDedupeConfig{}, nil
+		}
+		path = filepath.Join(root, defaultBotsFile)
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !explicit {
+		return &botDedupeConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read bots: %w", err)
+	}
+
+	var config botDedupeConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parse bots %s: %w", path, err)
+	}
+	for i := range config.Bots {
+		b := &config.Bots[i]
+		if b.Author == "" {
+			return nil, fmt.Errorf("%s: bot %d has no author", path, i+1)
+		}
+		if b.Threshold < 0 || b.Threshold > 1 {
+			return nil, fmt.Errorf("%s: bot %s: threshold must be between 0 and 1", path, b.Author)
+		}
+		if b.Marker != "" {
+			re, err := regexp.Compile(b.Marker)
+			if err != nil {
+				return nil, fmt.Errorf("%s: bot %s: invalid marker: %w", path, b.Author, err)
+			}
+			b.marker = re
+		}
+	}
+	return &config, nil
+}
+
+// ruleFor returns the rule for a comment author, or nil when the author's
+// comments are not deduplicated across PRs. Without configured bots, every
+// bot gets the default rule.
+func (c *botDedupeConfig) ruleFor(user github.User) *botDedupeRule {
+	if len(c.Bots) == 0 {
+		if user.IsBot() {
+			return &botDedupeRule{Author: user.Login}
+		}
+		return nil
+	}
+	for i := range c.Bots {
+		if strings.EqualFold(c.Bots[i].Author, user.Login) {
+			return &c.Bots[i]
+		}
+	}
+	return nil
+}
+
+// markerOf returns the text that identifies the kind of comment body is,
+// or "" when it has no marker.
+func (r *botDedupeRule) markerOf(body string) string {
+	if r.marker != nil {
+		return r.marker.FindString(body)
+	}
+	return marker.Of(body)
+}
+
+func (r *botDedupeRule) threshold() float64 {
+	if r.Threshold > 0 {
+		return r.Threshold
+	}
+	return dedupeThreshold
+}
+
+// findCrossPRDuplicates groups the bot comments on the current PR with an
+// earlier comment by the same bot, carrying the same marker and a similar
+// body, on another open PR of the repository. The earliest copy anywhere is
+// kept, so every comment on the current PR that repeats it is a duplicate.
+func findCrossPRDuplicates(client *github.Client, prRef *github.PRReference, comments []github.IssueComment, config *botDedupeConfig) ([]dedupeGroup, error) {
+	type subject struct {
+		comment github.IssueComment
+		rule    *botDedupeRule
+		marker  string
+	}
+	var subjects []subject
+	for _, c := range comments {
+		if c.IsMinimized || (dedupeAuthor != "" && !strings.EqualFold(c.User.Login, dedupeAuthor)) {
+			continue
+		}
+		rule := config.ruleFor(c.User)
+		if rule == nil {
+			continue
+		}
+		if marker := rule.markerOf(c.Body); marker != "" {
+			subjects = append(subjects, subject{c, rule, marker})
+		}
+	}
+	if len(subjects) == 0 {
+		return nil, nil
+	}
+
+	prs, err := client.ListPullRequests(prRef.Owner, prRef.Repo, "open")
+	if err != nil {
+		return nil, err
+	}
+	var others []dedupeCandidate
+	scanned := 0
+	for _, pr := range prs {
+		if pr.Number == prRef.Number {
+			continue
+		}
+		if scanned == dedupeScan {
+			break
+		}
+		scanned++
+		prComments, err := client.GetIssueComments(prRef.Owner, prRef.Repo, pr.Number)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range prComments {
+			if config.ruleFor(c.User) != nil {
+				others = append(others, dedupeCandidate{
+					ID:        c.ID,
+					NodeID:    c.NodeID,
+					Type:      "issue_comment",
+					Author:    c.User.Login,
+					Location:  fmt.Sprintf("#%d", pr.Number),
+					Body:      c.Body,
+					CreatedAt: c.CreatedAt,
+					PR:        pr.Number,
+				})
+			}
+		}
+	}
+
+	var groups []dedupeGroup
+	byKeep := make(map[int64]int)
+	for _, s := range subjects {
+		var keep *dedupeCandidate
+		var similarity float64
+		for i := range others {
+			o := &others[i]
+			if !strings.EqualFold(o.Author, s.comment.User.Login) || !o.CreatedAt.Before(s.comment.CreatedAt) {
+				continue
+			}
+			if keep != nil && !o.CreatedAt.Before(keep.CreatedAt) {
+				continue
+			}
+			if s.rule.markerOf(o.Body) != s.marker {
+				continue
+			}
+			if sim := textsim.Similarity(o.Body, s.comment.Body); sim >= s.rule.threshold() {
+				keep, similarity = o, sim
+			}
+		}
+		if keep == nil {
+			continue
+		}
+		gi, ok := byKeep[keep.ID]
+		if !ok {
+			gi = len(groups)
+			byKeep[keep.ID] = gi
+			groups = append(groups, dedupeGroup{Keep: *keep})
+		}
+		groups[gi].Duplicates = append(groups[gi].Duplicates, dedupeDuplicate{
+			dedupeCandidate: dedupeCandidate{
+				ID:        s.comment.ID,
+				NodeID:    s.comment.NodeID,
+				Type:      "issue_comment",
+				Author:    s.comment.User.Login,
+				Body:      s.comment.Body,
+				CreatedAt: s.comment.CreatedAt,
+			},
+			Similarity: similarity,
+			Action:     "would_hide",
+			Success:    true,
+		})
+	}
+	return groups, nil
+}
@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffBase    string
+	diffNoColor bool
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [pr-reference]",
+	Short: "Show the local diff with review comments inline",
+	Long: `Render 'git diff base...HEAD' with the PR's review threads interleaved
+below the lines they are anchored to, a terminal approximation of the
+Files Changed tab. Resolved threads are dimmed; file-level threads follow
+the file header.
+
+The base is the PR's base commit when it exists locally, otherwise
+upstream/<base> or origin/<base>; --base overrides it. Comments are
+anchored by their lines at the PR head, so they line up when HEAD is the
+pushed head. Threads that cannot be placed (outdated ones, or lines the
+local diff does not show) are listed at the end.
+
+If no PR reference is given, finds the PR for the current branch.
+
+PR reference can be:
+  - Full URL: https://github.com/owner/repo/pull/123
+  - Short form: owner/repo/123
+  - Just number: 123 (when in a repo context)
+  - Omitted: uses current branch's PR
+
+Examples:
+  gh pr-comments diff
+  gh pr-comments diff --base origin/release-1.2
+  gh pr-comments diff | less -R`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runDiff,
+}
+
+func init() {
+	diffCmd.Flags().StringVar(&diffBase, "base", "", "Revision to diff against (default the PR's base)")
+	diffCmd.Flags().BoolVar(&diffNoColor, "no-color", false, "Disable diff coloring")
+	rootCmd.AddCommand(diffCmd)
+}
+
+// diffThread is a review thread placed on a diff line.
+type diffThread struct {
+	root    github.ReviewComment
+	replies []github.ReviewComment
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
+	if err != nil {
+		return err
+	}
+
+	pr, err := client.GetPullRequest(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	base := diffBase
+	if base == "" {
+		base, err = localBase(pr)
+		if err != nil {
+			return err
+		}
+	}
+	patch, err := github.Diff(base)
+	if err != nil {
+		return err
+	}
+
+	comments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	if head, err := github.RevParse("HEAD"); err == nil && head != pr.Head.SHA {
+		fmt.Fprintf(os.Stderr, "Warning: HEAD (%s) is not the PR head (%s); comments may not line up\n", shortCommit(head), shortCommit(pr.Head.SHA))
+	}
+
+	unplaced := writeAnnotatedDiff(os.Stdout, patch, diffThreads(comments), colorEnabled(diffNoColor))
+	if len(unplaced) > 0 {
+		fmt.Printf("\n%d thread(s) not shown in the diff:\n", len(unplaced))
+		for _, t := range unplaced {
+			state := ""
+			if t.root.IsResolved {
+				state = ", resolved"
+			}
+			if t.root.IsOutdated() {
+				state += ", outdated"
+			}
+			fmt.Printf("  %s (%d%s) %s: %s\n", t.root.Location(), t.root.ID, state, t.root.User.Login, github.TruncateString(t.root.Body, 60))
+		}
+	}
+	return nil
+}
+
+// localBase picks a local revision for the PR's base: its base commit if
+// fetched, else the base branch on the upstream or origin remote.
+func localBase(pr *github.PullRequest) (string, error) {
+	candidates := []string{pr.Base.SHA, "upstream/" + pr.Base.Ref, "origin/" + pr.Base.Ref}
+	for _, rev := range candidates {
+		if _, err := github.RevParse(rev); err == nil {
+			return rev, nil
+		}
+	}
+	return "", fmt.Errorf("base %s is not available locally; run 'git fetch' or pass --base", pr.Base.Ref)
+}
+
+// diffThreads groups review comments into threads, oldest first.
+func diffThreads(comments []github.ReviewComment) []*diffThread {
+	sorted := append([]github.ReviewComment(nil), comments...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].CreatedAt.Before(sorted[j].CreatedAt) })
+
+	byRoot := make(map[int64]*diffThread)
+	var threads []*diffThread
+	for _, c := range sorted {
+		if c.InReplyToID == 0 {
+			t := &diffThread{root: c}
+			byRoot[c.ID] = t
+			threads = append(threads, t)
+		}
+	}
+	for _, c := range sorted {
+		if t, ok := byRoot[c.InReplyToID]; ok {
+			t.replies = append(t.replies, c)
+		}
+	}
+	return threads
+}
+
+// diffAnchor identifies a line on one side of a file's diff.
+type diffAnchor struct {
+	path string
+	side string
+	line int
+}
+
+// hunkHeaderPattern matches a hunk header and captures the first old and new
+// line numbers.
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// writeAnnotatedDiff writes patch with each thread printed below the line it
+// is anchored to, and returns the threads it could not place.
+func writeAnnotatedDiff(w io.Writer, patch string, threads []*diffThread, color bool) []*diffThread {
+	anchored := make(map[diffAnchor][]*diffThread)
+	fileLevel := make(map[string][]*diffThread)
+	placed := make(map[*diffThread]bool)
+	for _, t := range threads {
+		switch {
+		case t.root.IsFileLevel():
+			fileLevel[t.root.Path] = append(fileLevel[t.root.Path], t)
+		case t.root.Line != nil:
+			side := t.root.Side
+			if side == "" {
+				side = "RIGHT"
+			}
+			a := diffAnchor{t.root.Path, side, *t.root.Line}
+			anchored[a] = append(anchored[a], t)
+		}
+	}
+	emit := func(ts []*diffThread) {
+		for _, t := range ts {
+			writeDiffThread(w, t, color)
+			placed[t] = true
+		}
+	}
+
+	var path string
+	var oldLine, newLine int
+	inHunk := false
+	for _, line := range strings.Split(strings.TrimSuffix(patch, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			inHunk = false
+			fmt.Fprintln(w, paint(line, ansiBold, color))
+			continue
+		case !inHunk && strings.HasPrefix(line, "--- "):
+			path = strings.TrimPrefix(strings.TrimPrefix(line, "--- "), "a/")
+			fmt.Fprintln(w, paint(line, ansiBold, color))
+			continue
+		case !inHunk && strings.HasPrefix(line, "+++ "):
+			if p := strings.TrimPrefix(line, "+++ "); p != "/dev/null" {
+				path = strings.TrimPrefix(p, "b/")
+			}
+			fmt.Fprintln(w, paint(line, ansiBold, color))
+			emit(fileLevel[path])
+			continue
+		}
+		if m := hunkHeaderPattern.FindStringSubmatch(line); m != nil {
+			inHunk = true
+			oldLine, _ = strconv.Atoi(m[1])
+			newLine, _ = strconv.Atoi(m[2])
+			fmt.Fprintln(w, paint(line, ansiCyan, color))
+			continue
+		}
+		if !inHunk || line == "" {
+			fmt.Fprintln(w, line)
+			continue
+		}
+
+		var at []*diffThread
+		switch line[0] {
+		case '+':
+			fmt.Fprintln(w, paint(line, ansiGreen, color))
+			at = anchored[diffAnchor{path, "RIGHT", newLine}]
+			newLine++
+		case '-':
+			fmt.Fprintln(w, paint(line, ansiRed, color))
+			at = anchored[diffAnchor{path, "LEFT", oldLine}]
+			oldLine++
+		case ' ':
+			fmt.Fprintln(w, line)
+			at = slices.Concat(anchored[diffAnchor{path, "LEFT", oldLine}], anchored[diffAnchor{path, "RIGHT", newLine}])
+			oldLine++
+			newLine++
+		default:
+			fmt.Fprintln(w, line)
+		}
+		emit(at)
+	}
+
+	var unplaced []*diffThread
+	for _, t := range threads {
+		if !placed[t] {
+			unplaced = append(unplaced, t)
+		}
+	}
+	return unplaced
+}
+
+// writeDiffThread prints a thread as an indented block under a diff line.
+func writeDiffThread(w io.Writer, t *diffThread, color bool) {
+	writeLine := func(line string) {
+		if t.root.IsResolved {
+			line = paint(line, ansiDim, color)
+		}
+		fmt.Fprintln(w, line)
+	}
+	for i, c := range append([]github.ReviewComment{t.root}, t.replies...) {
+		prefix := "    ┃ "
+		if i > 0 {
+			prefix = "    ┃   "
+		}
+		header := fmt.Sprintf("%s%s · %s · %d", prefix, c.User.Login, c.CreatedAt.Format("2006-01-02 15:04"), c.ID)
+		if i == 0 && t.root.IsResolved {
+			header += " (resolved)"
+		}
+		writeLine(header)
+		for _, line := range strings.Split(strings.TrimSpace(c.Body), "\n") {
+			writeLine(strings.TrimRight(prefix+line, " "))
+		}
+	}
+}
+
+// paint wraps s in an ANSI style when color is on.
+func paint(s, style string, color bool) string {
+	if !color {
+		return s
+	}
+	return style + s + ansiReset
+}
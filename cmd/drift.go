@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	driftJsonOutput bool
+	driftResolveAll bool
+)
+
+var driftCmd = &cobra.Command{
+	Use:   "drift [pr-reference]",
+	Short: "List unresolved threads whose commented lines have since changed",
+	Long: `Compare the lines each unresolved review thread points at, as they were
+when the thread was opened, with the same file at the PR head. Threads whose
+lines were modified or deleted (or whose file was deleted) are listed as
+drifted: the code under discussion moved on, so they are likely addressed
+and are candidates for resolution.
+
+File-level threads drift when anything in the file changed. Threads on
+removed lines (the left side of the diff) are skipped.
+
+--resolve-all-drifted resolves every drifted thread; review the list first.
+
+If no PR reference is given, finds the PR for the current branch.
+
+PR reference can be:
+  - Full URL: https://github.com/owner/repo/pull/123
+  - Short form: owner/repo/123
+  - Just number: 123 (when in a repo context)
+  - Omitted: uses current branch's PR
+
+Examples:
+  gh pr-comments drift
+  gh pr-comments drift owner/repo/123 --json
+  gh pr-comments drift --resolve-all-drifted`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runDrift,
+}
+
+func init() {
+	driftCmd.Flags().BoolVar(&driftJsonOutput, "json", false, "Output in JSON format")
+	driftCmd.Flags().BoolVar(&driftResolveAll, "resolve-all-drifted", false, "Resolve every drifted thread")
+	rootCmd.AddCommand(driftCmd)
+}
+
+// driftedThread is an unresolved thread whose lines changed since it was
+// opened. Change is modified or file_deleted.
+type driftedThread struct {
+	ThreadID  string `json:"thread_id"`
+	CommentID int64  `json:"comment_id"`
+	Location  string `json:"location"`
+	Author    string `json:"author"`
+	Body      string `json:"body"`
+	Change    string `json:"change"`
+	Diff      string `json:"diff,omitempty"`
+	Resolved  bool   `json:"resolved"`
+}
+
+func runDrift(cmd *cobra.Command, args []string) error {
+	if driftResolveAll {
+		if err := requireLiveData("drift --resolve-all-drifted"); err != nil {
+			return err
+		}
+	}
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
+	if err != nil {
+		return err
+	}
+
+	pr, err := client.GetPullRequest(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	threads, err := client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return fmt.Errorf("get review threads: %w", err)
+	}
+
+	comments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int64]github.ReviewComment)
+	for _, c := range comments {
+		byID[c.ID] = c
+	}
+
+	files := newFileVersions(client, prRef)
+	var drifted []driftedThread
+	unresolved := 0
+	for _, t := range threads {
+		if t.IsResolved || len(t.CommentIDs) == 0 {
+			continue
+		}
+		unresolved++
+		root, ok := byID[t.CommentIDs[0]]
+		if !ok || root.Side == "LEFT" {
+			continue
+		}
+		diff, deleted, err := files.regionChange(root, pr.Head.SHA)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping thread on %s: %v\n", t.Location(), err)
+			continue
+		}
+		if diff == "" {
+			continue
+		}
+		change := "modified"
+		if deleted {
+			change = "file_deleted"
+			diff = ""
+		}
+		drifted = append(drifted, driftedThread{
+			ThreadID:  t.ID,
+			CommentID: root.ID,
+			Location:  root.Location(),
+			Author:    root.User.Login,
+			Body:      root.Body,
+			Change:    change,
+			Diff:      diff,
+		})
+	}
+
+	failed := 0
+	if driftResolveAll {
+		for i := range drifted {
+			if err := client.ResolveThread(drifted[i].ThreadID); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to resolve thread %s: %v\n", drifted[i].ThreadID, err)
+				failed++
+				continue
+			}
+			journalAction(prRef, journal.Entry{Action: "resolve", ThreadID: drifted[i].ThreadID})
+			drifted[i].Resolved = true
+		}
+	}
+
+	if driftJsonOutput {
+		if drifted == nil {
+			drifted = []driftedThread{}
+		}
+		if err := writeJSON(drifted); err != nil {
+			return err
+		}
+		return driftResolveError(cmd, failed)
+	}
+
+	if len(drifted) == 0 {
+		fmt.Printf("None of the %d unresolved thread(s) have drifted.\n", unresolved)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "THREAD\tID\tLOCATION\tCHANGE\tAUTHOR\tBODY")
+	for _, d := range drifted {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\n",
+			d.ThreadID, d.CommentID, d.Location, d.Change, d.Author, github.TruncateString(d.Body, 40))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	resolved := 0
+	for _, d := range drifted {
+		if d.Resolved {
+			resolved++
+		}
+	}
+	fmt.Printf("\n%d of %d unresolved thread(s) drifted", len(drifted), unresolved)
+	if driftResolveAll {
+		fmt.Printf("; resolved %d", resolved)
+	}
+	fmt.Println()
+	if !driftResolveAll {
+		fmt.Println("Run with --resolve-all-drifted to resolve them, or 'view --evolution <id>' to inspect one.")
+	}
+	return driftResolveError(cmd, failed)
+}
+
+// driftResolveError fails the command when --resolve-all-drifted could not
+// resolve every drifted thread.
+func driftResolveError(cmd *cobra.Command, failed int) error {
+	if failed == 0 {
+		return nil
+	}
+	cmd.SilenceUsage = true
+	return fmt.Errorf("%d drifted thread(s) could not be resolved", failed)
+}
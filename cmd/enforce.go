@@ -0,0 +1,518 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/journal"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultRulesFile = ".github/pr-comments-rules.yaml"
+
+var (
+	enforceRulesFile  string
+	enforceDryRun     bool
+	enforceJsonOutput bool
+)
+
+var enforceCmd = &cobra.Command{
+	Use:   "enforce [pr-reference]",
+	Short: "Apply the team's hide/resolve/reply rules to a PR",
+	Long: `Run the rules in a YAML policy file against a pull request's comments and
+hide, resolve, or reply to every comment they match. Use --dry-run to see
+what would happen first.
+
+The policy is read from --rules, or from ` + defaultRulesFile + ` at the
+root of the repository:
+
+  rules:
+    - name: stale-bot-nits
+      match:
+        author: coderabbitai[bot]   # exact login, case-insensitive
+        body: '(?i)^nit'            # regular expression
+        older_than: 3d              # Go duration, or a number of days
+        outdated: true
+      action: hide                  # hide, resolve, or reply
+      reason: outdated              # hide reason
+    - name: ping-stale-threads
+      match:
+        resolved: false
+        older_than: 14d
+      action: reply
+      body: '@{{.Author}} is this still relevant?'
+
+Match keys (all optional, all must hold): author, bot, body, file,
+older_than (age of the comment), outdated, resolved, and type
+(review_comment/issue_comment). Unresolved comments are matched unless
+resolved is set.
+
+Reply bodies are Go templates with .Author, .Location, .File, .ID, .URL,
+and .Age. Each reply carries a hidden marker naming its rule, so a rule
+replies to a thread only once. Resolve and reply consider only the first
+comment of each thread; hide skips comments that are already hidden.
+
+Every matching rule runs, in file order. Failures are reported and do not
+stop the remaining actions.
+
+If no PR reference is given, finds the PR for the current branch.
+
+PR reference can be:
+  - Full URL: https://github.com/owner/repo/pull/123
+  - Short form: owner/repo/123
+  - Just number: 123 (when in a repo context)
+  - Omitted: uses current branch's PR
+
+Examples:
+  gh pr-comments enforce --dry-run
+  gh pr-comments enforce owner/repo/123 --rules team-rules.yaml
+  gh pr-comments enforce --json`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runEnforce,
+}
+
+func init() {
+	enforceCmd.Flags().StringVar(&enforceRulesFile, "rules", "", "Policy file (default "+defaultRulesFile+" in the repository)")
+	enforceCmd.Flags().BoolVar(&enforceDryRun, "dry-run", false, "Show what would be done without changing anything")
+	enforceCmd.Flags().BoolVar(&enforceJsonOutput, "json", false, "Output in JSON format")
+	rootCmd.AddCommand(enforceCmd)
+}
+
+// commentPolicy is the YAML policy file read by 'enforce'.
+type commentPolicy struct {
+	Rules []policyRule `yaml:"rules"`
+}
+
+type policyRule struct {
+	Name   string      `yaml:"name"`
+	Match  policyMatch `yaml:"match"`
+	Action string      `yaml:"action"`
+	Reason string      `yaml:"reason,omitempty"`
+	Body   string      `yaml:"body,omitempty"`
+
+	body      *regexp.Regexp
+	olderThan time.Duration
+	template  *template.Template
+}
+
+type policyMatch struct {
+	Author    string `yaml:"author,omitempty"`
+	Bot       *bool  `yaml:"bot,omitempty"`
+	Body      string `yaml:"body,omitempty"`
+	File      string `yaml:"file,omitempty"`
+	OlderThan string `yaml:"older_than,omitempty"`
+	Outdated  *bool  `yaml:"outdated,omitempty"`
+	Resolved  *bool  `yaml:"resolved,omitempty"`
+	Type      string `yaml:"type,omitempty"`
+}
+
+// policySubject is a comment as the rules see it.
+type policySubject struct {
+	ID       int64
+	NodeID   string
+	ThreadID string
+	Type     string
+	Author   github.User
+	Body     string
+	File     string
+	Location string
+	URL      string
+	Created  time.Time
+	Outdated bool
+	Resolved bool
+	Hidden   bool
+	IsRoot   bool
+	Thread   []github.ThreadComment
+}
+
+// policyReplyData is what reply templates can refer to.
+type policyReplyData struct {
+	Author   string
+	Location string
+	File     string
+	ID       int64
+	URL      string
+	Age      string
+}
+
+type enforceResult struct {
+	Rule      string `json:"rule"`
+	Action    string `json:"action"`
+	CommentID int64  `json:"comment_id"`
+	Status    string `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+}
+
+// loadPolicy reads and checks a policy file.
+func loadPolicy(path string) (*commentPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read rules: %w", err)
+	}
+	return parsePolicy(data, path)
+}
+
+// parsePolicy checks a policy read from source, which names it in errors.
+func parsePolicy(data []byte, source string) (*commentPolicy, error) {
+	var policy commentPolicy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("parse rules %s: %w", source, err)
+	}
+	if len(policy.Rules) == 0 {
+		return nil, fmt.Errorf("%s has no rules", source)
+	}
+	for i := range policy.Rules {
+		if err := policy.Rules[i].compile(i); err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+	}
+	return &policy, nil
+}
+
+func (r *policyRule) compile(i int) error {
+	if r.Name == "" {
+		r.Name = fmt.Sprintf("rule-%d", i+1)
+	}
+	switch r.Action {
+	case "hide":
+		if r.Reason == "" {
+			r.Reason = "resolved"
+		}
+		if _, err := github.ParseClassifier(r.Reason); err != nil {
+			return fmt.Errorf("rule %s: %w", r.Name, err)
+		}
+	case "resolve":
+	case "reply":
+		if strings.TrimSpace(r.Body) == "" {
+			return fmt.Errorf("rule %s: reply has no body", r.Name)
+		}
+		t, err := template.New(r.Name).Option("missingkey=error").Parse(r.Body)
+		if err != nil {
+			return fmt.Errorf("rule %s: invalid body template: %w", r.Name, err)
+		}
+		r.template = t
+	default:
+		return fmt.Errorf("rule %s: invalid action: %s (valid: hide, resolve, reply)", r.Name, r.Action)
+	}
+
+	switch r.Match.Type {
+	case "", "review_comment", "issue_comment":
+	default:
+		return fmt.Errorf("rule %s: invalid type: %s (valid: review_comment, issue_comment)", r.Name, r.Match.Type)
+	}
+	if r.Action != "hide" && r.Match.Type == "issue_comment" {
+		return fmt.Errorf("rule %s: issue comments can only be hidden", r.Name)
+	}
+	if r.Match.Body != "" {
+		re, err := regexp.Compile(r.Match.Body)
+		if err != nil {
+			return fmt.Errorf("rule %s: invalid body pattern: %w", r.Name, err)
+		}
+		r.body = re
+	}
+	if r.Match.OlderThan != "" {
+		d, err := parseAge(r.Match.OlderThan)
+		if err != nil {
+			return fmt.Errorf("rule %s: %w", r.Name, err)
+		}
+		r.olderThan = d
+	}
+	return nil
+}
+
+// parseAge accepts a Go duration or a whole number of days such as "7d".
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err == nil && n >= 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid older_than value: %s (expected e.g. 36h or 7d)", s)
+	}
+	return d, nil
+}
+
+func (r *policyRule) matches(s policySubject, now time.Time) bool {
+	m := r.Match
+	if r.Action != "hide" && (s.Type != "review_comment" || !s.IsRoot) {
+		return false
+	}
+	if r.Action == "hide" && s.Hidden {
+		return false
+	}
+	if m.Type != "" && s.Type != m.Type {
+		return false
+	}
+	if m.Author != "" && !strings.EqualFold(s.Author.Login, m.Author) {
+		return false
+	}
+	if m.Bot != nil && s.Author.IsBot() != *m.Bot {
+		return false
+	}
+	if r.body != nil && !r.body.MatchString(s.Body) {
+		return false
+	}
+	if m.File != "" && (s.File == "" || !matchFile(m.File, s.File)) {
+		return false
+	}
+	if r.olderThan > 0 && now.Sub(s.Created) < r.olderThan {
+		return false
+	}
+	if m.Outdated != nil && s.Outdated != *m.Outdated {
+		return false
+	}
+	wantResolved := m.Resolved != nil && *m.Resolved
+	if s.Type == "review_comment" && s.Resolved != wantResolved {
+		return false
+	}
+	return true
+}
+
+// policyMarker tags replies posted by a rule so it never replies twice.
+func policyMarker(rule string) string {
+	return fmt.Sprintf("<!-- gh-pr-comments-rule: %s -->", rule)
+}
+
+func runEnforce(cmd *cobra.Command, args []string) error {
+	if err := requireLiveData("enforce"); err != nil {
+		return err
+	}
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
+	if err != nil {
+		return err
+	}
+
+	rulesFile := enforceRulesFile
+	if rulesFile == "" {
+		root, err := github.GetRepoRoot()
+		if err != nil {
+			return fmt.Errorf("no --rules given and not in a git repository: %w", err)
+		}
+		rulesFile = filepath.Join(root, defaultRulesFile)
+	}
+	policy, err := loadPolicy(rulesFile)
+	if err != nil {
+		return err
+	}
+
+	results, err := enforcePolicy(client, prRef, policy, enforceDryRun)
+	if err != nil {
+		return err
+	}
+
+	if enforceJsonOutput {
+		return writeJSON(results)
+	}
+	failed := printEnforceResults(results, enforceDryRun)
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d action(s) failed", failed)
+	}
+	return nil
+}
+
+// enforcePolicy runs every rule of policy against the PR's comments.
+func enforcePolicy(client *github.Client, prRef *github.PRReference, policy *commentPolicy, dryRun bool) ([]enforceResult, error) {
+	subjects, err := loadPolicySubjects(client, prRef)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	results := []enforceResult{}
+	for i := range policy.Rules {
+		rule := &policy.Rules[i]
+		for j := range subjects {
+			s := &subjects[j]
+			if !rule.matches(*s, now) {
+				continue
+			}
+			result := enforceResult{Rule: rule.Name, Action: rule.Action, CommentID: s.ID}
+			var body string
+			switch rule.Action {
+			case "hide":
+				result.Detail = rule.Reason
+			case "reply":
+				if threadHasMarker(s.Thread, policyMarker(rule.Name)) {
+					continue
+				}
+				var b strings.Builder
+				err := rule.template.Execute(&b, policyReplyData{
+					Author:   s.Author.Login,
+					Location: s.Location,
+					File:     s.File,
+					ID:       s.ID,
+					URL:      s.URL,
+					Age:      now.Sub(s.Created).Round(time.Hour).String(),
+				})
+				if err != nil {
+					result.Status, result.Detail = "failed", err.Error()
+					results = append(results, result)
+					continue
+				}
+				result.Detail = b.String()
+				body = b.String() + "\n\n" + policyMarker(rule.Name)
+			}
+			if dryRun {
+				result.Status = "would_apply"
+				results = append(results, result)
+				continue
+			}
+
+			var err error
+			var entry journal.Entry
+			switch rule.Action {
+			case "hide":
+				classifier, _ := github.ParseClassifier(rule.Reason)
+				err = client.MinimizeComment(s.NodeID, classifier)
+				entry = journal.Entry{Action: "hide", NodeID: s.NodeID, Reason: string(classifier)}
+			case "resolve":
+				err = client.ResolveThread(s.ThreadID)
+				entry = journal.Entry{Action: "resolve", ThreadID: s.ThreadID}
+			case "reply":
+				var reply *github.ReviewComment
+				reply, err = client.ReplyToReviewComment(prRef.Owner, prRef.Repo, prRef.Number, s.ID, withSignature(body))
+				if err == nil {
+					result.Detail = reply.HTMLURL
+					entry = journal.Entry{Action: "reply", CommentID: reply.ID, URL: reply.HTMLURL}
+				}
+			}
+			if err == nil {
+				journalAction(prRef, entry)
+			}
+			if err != nil {
+				result.Status, result.Detail = "failed", err.Error()
+			} else {
+				result.Status = "applied"
+				// Later rules see the new state, so they don't repeat the action.
+				switch rule.Action {
+				case "hide":
+					s.Hidden = true
+				case "resolve":
+					s.Resolved = true
+				}
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+func threadHasMarker(thread []github.ThreadComment, marker string) bool {
+	for _, c := range thread {
+		if strings.Contains(c.Body, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadPolicySubjects gathers the PR's review and issue comments with the
+// thread state the rules match on.
+func loadPolicySubjects(client *github.Client, prRef *github.PRReference) ([]policySubject, error) {
+	reviewComments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+	issueComments, err := client.GetIssueComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+	threads, err := client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, fmt.Errorf("get review threads: %w", err)
+	}
+	threadOf := make(map[int64]*github.ReviewThread)
+	for i := range threads {
+		for _, id := range threads[i].CommentIDs {
+			threadOf[id] = &threads[i]
+		}
+	}
+
+	var subjects []policySubject
+	for _, c := range reviewComments {
+		s := policySubject{
+			ID:       c.ID,
+			NodeID:   c.NodeID,
+			Type:     "review_comment",
+			Author:   c.User,
+			Body:     c.Body,
+			File:     c.Path,
+			Location: c.Location(),
+			URL:      c.HTMLURL,
+			Created:  c.CreatedAt,
+			Outdated: c.IsOutdated(),
+			Resolved: c.IsResolved,
+			Hidden:   c.IsMinimized,
+			IsRoot:   c.InReplyToID == 0,
+		}
+		if t, ok := threadOf[c.ID]; ok {
+			s.ThreadID = t.ID
+			s.Thread = t.Comments
+		} else {
+			// Without a thread there is nothing to resolve or reply in.
+			s.IsRoot = false
+		}
+		subjects = append(subjects, s)
+	}
+	for _, c := range issueComments {
+		subjects = append(subjects, policySubject{
+			ID:       c.ID,
+			NodeID:   c.NodeID,
+			Type:     "issue_comment",
+			Author:   c.User,
+			Body:     c.Body,
+			Location: "(conversation)",
+			URL:      c.HTMLURL,
+			Created:  c.CreatedAt,
+			Hidden:   c.IsMinimized,
+		})
+	}
+	return subjects, nil
+}
+
+// printEnforceResults prints one line per action and a summary, and
+// returns the number of failures.
+func printEnforceResults(results []enforceResult, dryRun bool) int {
+	if len(results) == 0 {
+		fmt.Println("No comments matched any rule.")
+		return 0
+	}
+	counts := make(map[string]int)
+	for _, r := range results {
+		counts[r.Status]++
+		line := fmt.Sprintf("%-12s %-8s %-12d %s", r.Status, r.Action, r.CommentID, r.Rule)
+		if r.Detail != "" {
+			line += "  " + github.TruncateString(r.Detail, 60)
+		}
+		if r.Status == "failed" {
+			fmt.Fprintln(os.Stderr, line)
+		} else {
+			fmt.Println(line)
+		}
+	}
+	fmt.Println(strings.Repeat("─", 40))
+	if dryRun {
+		fmt.Printf("Dry run: %d action(s) would be applied\n", counts["would_apply"])
+		return 0
+	}
+	fmt.Printf("Applied: %d, failed: %d\n", counts["applied"], counts["failed"])
+	return counts["failed"]
+}
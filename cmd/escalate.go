@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	escalatePR         string
+	escalateRepo       string
+	escalateTitle      string
+	escalateLabels     []string
+	escalateNoReply    bool
+	escalateJsonOutput bool
+)
+
+var escalateCmd = &cobra.Command{
+	Use:   "escalate <comment-id>",
+	Short: "Open an issue for feedback deferred to a follow-up",
+	Long: `Open a GitHub issue from a comment, then reply to the comment with a link
+to the issue.
+
+The issue contains the comment body, its permalink, and the code it was
+made on. It is created in the PR's repository unless --repo is given.
+
+Examples:
+  gh pr-comments escalate 2621968472
+  gh pr-comments escalate 2621968472 --repo owner/tracker --label tech-debt
+  gh pr-comments escalate 2621968472 --title "Handle retries in the poller" --no-reply`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runEscalate,
+	ValidArgsFunction: completeCommentIDs,
+}
+
+func init() {
+	escalateCmd.Flags().StringVar(&escalatePR, "pr", "", "PR reference (e.g., owner/repo/123 or just 123)")
+	escalateCmd.RegisterFlagCompletionFunc("pr", completePRs)
+	escalateCmd.Flags().StringVar(&escalateRepo, "repo", "", "Repository to open the issue in (owner/repo)")
+	escalateCmd.Flags().StringVar(&escalateTitle, "title", "", "Issue title (defaults to a summary of the comment)")
+	escalateCmd.Flags().StringSliceVar(&escalateLabels, "label", nil, "Label to add to the issue (repeatable)")
+	escalateCmd.Flags().BoolVar(&escalateNoReply, "no-reply", false, "Do not reply to the comment with the issue link")
+	escalateCmd.Flags().BoolVar(&escalateJsonOutput, "json", false, "Output in JSON format")
+	rootCmd.AddCommand(escalateCmd)
+}
+
+func runEscalate(cmd *cobra.Command, args []string) error {
+	commentID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid comment ID: %s", args[0])
+	}
+
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	var prArgs []string
+	if escalatePR != "" {
+		prArgs = []string{escalatePR}
+	}
+
+	prRef, err := client.ResolvePRReference(prArgs)
+	if err != nil {
+		return fmt.Errorf("could not determine PR: %w\nPlease specify a PR with --pr or run from a branch with an associated PR", err)
+	}
+
+	issueOwner, issueRepo := prRef.Owner, prRef.Repo
+	if escalateRepo != "" {
+		parts := strings.Split(escalateRepo, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid repository: %s (expected owner/repo)", escalateRepo)
+		}
+		issueOwner, issueRepo = parts[0], parts[1]
+	}
+
+	item, err := findPRItem(client, prRef, commentID)
+	if err != nil {
+		return err
+	}
+	if item == nil || item.Review != nil {
+		return fmt.Errorf("comment with ID %d not found in PR %d", commentID, prRef.Number)
+	}
+
+	pr, err := client.GetPullRequest(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	body, author := "", ""
+	if item.ReviewComment != nil {
+		body, author = item.ReviewComment.Body, item.ReviewComment.User.Login
+	} else {
+		body, author = item.IssueComment.Body, item.IssueComment.User.Login
+	}
+
+	title := escalateTitle
+	if title == "" {
+		title = todoSummary(body)
+	}
+
+	issue, err := client.CreateIssue(issueOwner, issueRepo, title, escalationIssueBody(pr, item, author, body), escalateLabels)
+	if err != nil {
+		return err
+	}
+	journalAction(prRef, journal.Entry{Action: "issue", CommentID: commentID, URL: issue.HTMLURL})
+
+	issueRef := fmt.Sprintf("%s/%s#%d", issueOwner, issueRepo, issue.Number)
+	var replyURL string
+	if !escalateNoReply {
+		replyBody := fmt.Sprintf("Deferred to a follow-up: %s", issueRef)
+		if item.ReviewComment != nil {
+			reply, err := client.ReplyToReviewComment(prRef.Owner, prRef.Repo, prRef.Number, commentID, withSignature(replyBody))
+			if err != nil {
+				return fmt.Errorf("issue %s created, but %w", issue.HTMLURL, err)
+			}
+			journalAction(prRef, journal.Entry{Action: "reply", CommentID: reply.ID, URL: reply.HTMLURL})
+			replyURL = reply.HTMLURL
+		} else {
+			replyBody = fmt.Sprintf("> %s\n\n%s", todoSummary(body), replyBody)
+			reply, err := client.CreateIssueComment(prRef.Owner, prRef.Repo, prRef.Number, withSignature(replyBody))
+			if err != nil {
+				return fmt.Errorf("issue %s created, but %w", issue.HTMLURL, err)
+			}
+			journalAction(prRef, journal.Entry{Action: "issue_comment", CommentID: reply.ID, URL: reply.HTMLURL})
+			replyURL = reply.HTMLURL
+		}
+	}
+
+	if escalateJsonOutput {
+		return writeJSON(struct {
+			CommentID int64         `json:"comment_id"`
+			Issue     *github.Issue `json:"issue"`
+			ReplyURL  string        `json:"reply_url,omitempty"`
+		}{commentID, issue, replyURL})
+	}
+
+	fmt.Printf("Created issue %s: %s\n", issueRef, issue.HTMLURL)
+	if replyURL != "" {
+		fmt.Printf("Replied:       %s\n", replyURL)
+	}
+	return nil
+}
+
+func escalationIssueBody(pr *github.PullRequest, item *prItem, author, body string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Deferred from review of %s (#%d).\n\n", pr.HTMLURL, pr.Number)
+	fmt.Fprintf(&b, "@%s wrote in %s:\n\n", author, item.HTMLURL())
+	for _, line := range strings.Split(strings.TrimSpace(body), "\n") {
+		fmt.Fprintf(&b, "> %s\n", line)
+	}
+
+	if c := item.ReviewComment; c != nil {
+		fmt.Fprintf(&b, "\nLocation: `%s`\n", c.Location())
+		if c.DiffHunk != "" {
+			fmt.Fprintf(&b, "\n```diff\n%s\n```\n", c.DiffHunk)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// evolutionContext is how many unchanged lines surround each change in the
+// --evolution diff.
+const evolutionContext = 3
+
+// commentEvolution compares the commented region of a file at the commit the
+// comment was made on with the same file at the PR head.
+type commentEvolution struct {
+	CommentID      int64  `json:"comment_id"`
+	Path           string `json:"path"`
+	StartLine      int    `json:"start_line,omitempty"`
+	EndLine        int    `json:"end_line,omitempty"`
+	OriginalCommit string `json:"original_commit"`
+	HeadCommit     string `json:"head_commit"`
+	FileDeleted    bool   `json:"file_deleted"`
+	Changed        bool   `json:"changed"`
+	Diff           string `json:"diff"`
+}
+
+func runViewEvolution(client *github.Client, prRef *github.PRReference, commentID int64) error {
+	comments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+	var comment *github.ReviewComment
+	for i := range comments {
+		if comments[i].ID == commentID {
+			comment = &comments[i]
+			break
+		}
+	}
+	if comment == nil {
+		return fmt.Errorf("review comment with ID %d not found in PR %d", commentID, prRef.Number)
+	}
+	if comment.Side == "LEFT" {
+		return fmt.Errorf("comment %d is on a removed line; --evolution only follows lines on the new side of the diff", commentID)
+	}
+
+	pr, err := client.GetPullRequest(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	evo := &commentEvolution{
+		CommentID:      comment.ID,
+		Path:           comment.Path,
+		OriginalCommit: comment.OriginalCommitID,
+		HeadCommit:     pr.Head.SHA,
+	}
+	if !comment.IsFileLevel() && comment.OriginalLine != nil {
+		evo.EndLine = *comment.OriginalLine
+		evo.StartLine = evo.EndLine
+		if comment.OriginalStartLine != nil {
+			evo.StartLine = *comment.OriginalStartLine
+		}
+	}
+
+	files := newFileVersions(client, prRef)
+	evo.Diff, evo.FileDeleted, err = files.regionChange(*comment, pr.Head.SHA)
+	if err != nil {
+		return err
+	}
+	evo.Changed = evo.Diff != ""
+
+	if viewJsonOutput {
+		return writeJSON(evo)
+	}
+
+	printEvolution(evo)
+	return nil
+}
+
+// fileVersions fetches file contents at given commits, caching each one so
+// several comments on the same file cost one request per commit.
+type fileVersions struct {
+	client *github.Client
+	prRef  *github.PRReference
+	cache  map[string][]string
+}
+
+func newFileVersions(client *github.Client, prRef *github.PRReference) *fileVersions {
+	return &fileVersions{client: client, prRef: prRef, cache: make(map[string][]string)}
+}
+
+// get returns the lines of path at ref. A file missing at ref is nil with
+// exists false.
+func (f *fileVersions) get(path, ref string) (lines []string, exists bool, err error) {
+	key := ref + ":" + path
+	if lines, ok := f.cache[key]; ok {
+		return lines, lines != nil, nil
+	}
+	data, err := f.client.GetFileContent(f.prRef.Owner, f.prRef.Repo, path, ref)
+	if err != nil && !github.IsNotFound(err) {
+		return nil, false, err
+	}
+	if err == nil {
+		lines = splitLines(string(data))
+		if lines == nil {
+			lines = []string{}
+		}
+	}
+	f.cache[key] = lines
+	return lines, lines != nil, nil
+}
+
+// regionChange diffs the lines a review comment points at between the commit
+// it was made on and head. It returns "" when they are unchanged; file-level
+// comments cover the whole file.
+func (f *fileVersions) regionChange(c github.ReviewComment, head string) (diff string, deleted bool, err error) {
+	before, exists, err := f.get(c.Path, c.OriginalCommitID)
+	if err != nil {
+		return "", false, err
+	}
+	if !exists {
+		return "", false, fmt.Errorf("%s not found at commit %s", c.Path, shortCommit(c.OriginalCommitID))
+	}
+	after, exists, err := f.get(c.Path, head)
+	if err != nil {
+		return "", false, err
+	}
+
+	var start, end int
+	if !c.IsFileLevel() && c.OriginalLine != nil {
+		end = *c.OriginalLine
+		start = end
+		if c.OriginalStartLine != nil {
+			start = *c.OriginalStartLine
+		}
+	}
+	return regionDiff(before, after, start, end), !exists, nil
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// regionDiff returns the unified diff hunks between before and after that
+// touch lines start..end of before (1-based), or every hunk when start is 0.
+// It returns "" when the region is unchanged.
+func regionDiff(before, after []string, start, end int) string {
+	matcher := difflib.NewMatcher(before, after)
+	var b strings.Builder
+	for _, group := range matcher.GetGroupedOpCodes(evolutionContext) {
+		if start > 0 && !hunkTouches(group, start, end) {
+			continue
+		}
+		first, last := group[0], group[len(group)-1]
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", first.I1+1, last.I2-first.I1, first.J1+1, last.J2-first.J1)
+		for _, op := range group {
+			switch op.Tag {
+			case 'e':
+				for _, line := range before[op.I1:op.I2] {
+					b.WriteString(" " + line + "\n")
+				}
+			case 'r', 'd':
+				for _, line := range before[op.I1:op.I2] {
+					b.WriteString("-" + line + "\n")
+				}
+				if op.Tag == 'd' {
+					continue
+				}
+				fallthrough
+			case 'i':
+				for _, line := range after[op.J1:op.J2] {
+					b.WriteString("+" + line + "\n")
+				}
+			}
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// hunkTouches reports whether any change in the hunk removes or replaces a
+// line in start..end, or inserts lines inside the region or at its edges.
+func hunkTouches(group []difflib.OpCode, start, end int) bool {
+	// Opcodes are 0-based and half-open; the region is 1-based and inclusive.
+	lo, hi := start-1, end
+	for _, op := range group {
+		switch {
+		case op.Tag == 'e':
+		case op.I1 == op.I2:
+			if op.I1 >= lo && op.I1 <= hi {
+				return true
+			}
+		case op.I1 < hi && op.I2 > lo:
+			return true
+		}
+	}
+	return false
+}
+
+func printEvolution(e *commentEvolution) {
+	fmt.Printf("Evolution of comment %d on %s\n", e.CommentID, e.Path)
+	fmt.Println(strings.Repeat("─", 60))
+	region := "whole file"
+	if e.StartLine > 0 {
+		region = fmt.Sprintf("lines %d-%d", e.StartLine, e.EndLine)
+		if e.StartLine == e.EndLine {
+			region = fmt.Sprintf("line %d", e.EndLine)
+		}
+	}
+	fmt.Printf("Region:    %s\n", region)
+	fmt.Printf("Then:      %s (commented commit)\n", shortCommit(e.OriginalCommit))
+	fmt.Printf("Now:       %s (PR head)\n", shortCommit(e.HeadCommit))
+	fmt.Println(strings.Repeat("─", 60))
+
+	switch {
+	case e.FileDeleted:
+		fmt.Println("The file no longer exists at the PR head.")
+	case !e.Changed:
+		fmt.Println("The commented lines are unchanged at the PR head.")
+	case colorEnabled(viewNoColor):
+		fmt.Println(highlightDiffHunk(e.Diff, e.Path))
+	default:
+		fmt.Println(e.Diff)
+	}
+}
@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportDir      string
+	exportNoRedact bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [pr-reference]",
+	Short: "Archive the full PR conversation as JSON and Markdown files",
+	Long: `Write the complete review conversation of a pull request to disk for
+archiving: the PR's metadata and description, every review, every review
+thread with its diff hunk and replies (resolved and hidden ones included),
+and the general PR comments.
+
+Each run writes a new pair of files named after the export time, so earlier
+archives are never overwritten:
+
+  <dir>/<owner>/<repo>/pr-<number>/<timestamp>.json
+  <dir>/<owner>/<repo>/pr-<number>/<timestamp>.md
+
+Nothing is written unless every review and comment, with its resolved and
+hidden state, could be fetched.
+
+The JSON file carries "format_version" like every other JSON output.
+Credentials pasted into comments are masked as in 'summary' unless
+--no-redact is given.
+
+If no PR reference is given, finds the PR for the current branch.
+
+PR reference can be:
+  - Full URL: https://github.com/owner/repo/pull/123
+  - Short form: owner/repo/123
+  - Just number: 123 (when in a repo context)
+  - Omitted: uses current branch's PR
+
+Examples:
+  gh pr-comments export --dir ./archive
+  gh pr-comments export owner/repo/123 --dir /srv/review-archive`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runExport,
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&exportDir, "dir", ".", "Directory to write the archive under")
+	exportCmd.Flags().BoolVar(&exportNoRedact, "no-redact", false, "Keep credentials found in comments")
+	rootCmd.AddCommand(exportCmd)
+}
+
+// prArchive is the complete conversation of a pull request at one point in
+// time.
+type prArchive struct {
+	ExportedAt    time.Time             `json:"exported_at"`
+	Repository    string                `json:"repository"`
+	PullRequest   *github.PullRequest   `json:"pull_request"`
+	Reviews       []github.Review       `json:"reviews"`
+	Threads       []*threadView         `json:"threads"`
+	IssueComments []github.IssueComment `json:"issue_comments"`
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	redactor, err := outputRedactor(exportNoRedact)
+	if err != nil {
+		return err
+	}
+
+	if err := requireLiveData("export"); err != nil {
+		return err
+	}
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+	client.RequireCompleteStatus()
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
+	if err != nil {
+		return err
+	}
+
+	pr, err := client.GetPullRequest(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+	reviews, err := client.GetReviews(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+	comments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+	issueComments, err := client.GetIssueComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+	if len(comments) < pr.ReviewComments || len(issueComments) < pr.Comments {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("archive would be incomplete: fetched %d of %d review comments and %d of %d general comments; try again", len(comments), pr.ReviewComments, len(issueComments), pr.Comments)
+	}
+
+	archive := &prArchive{
+		ExportedAt:    time.Now().UTC().Truncate(time.Second),
+		Repository:    prRef.Owner + "/" + prRef.Repo,
+		PullRequest:   pr,
+		Reviews:       reviews,
+		Threads:       archiveThreads(comments),
+		IssueComments: issueComments,
+	}
+	if archive.Reviews == nil {
+		archive.Reviews = []github.Review{}
+	}
+	if archive.IssueComments == nil {
+		archive.IssueComments = []github.IssueComment{}
+	}
+
+	dir := filepath.Join(exportDir, prRef.Owner, prRef.Repo, fmt.Sprintf("pr-%d", prRef.Number))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create archive directory: %w", err)
+	}
+	base := filepath.Join(dir, archive.ExportedAt.Format("20060102T150405Z"))
+
+	var data bytes.Buffer
+	redacted, err := encodeRedactedJSON(&data, redactor, archive)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(base+".json", data.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("write archive: %w", err)
+	}
+	if err := os.WriteFile(base+".md", []byte(redactor.String(archiveMarkdown(archive))), 0o644); err != nil {
+		return fmt.Errorf("write archive: %w", err)
+	}
+	warnRedacted(redacted)
+
+	fmt.Printf("Exported PR #%d (%d review(s), %d thread(s), %d comment(s)) to:\n", pr.Number, len(reviews), len(archive.Threads), len(issueComments))
+	fmt.Printf("  %s.json\n  %s.md\n", base, base)
+	return nil
+}
+
+// archiveThreads groups review comments into threads ordered by file and
+// line, each with its replies oldest first.
+func archiveThreads(comments []github.ReviewComment) []*threadView {
+	var roots []github.ReviewComment
+	for _, c := range comments {
+		if c.InReplyToID == 0 {
+			roots = append(roots, c)
+		}
+	}
+	sort.SliceStable(roots, func(i, j int) bool {
+		if roots[i].Path != roots[j].Path {
+			return roots[i].Path < roots[j].Path
+		}
+		if li, lj := roots[i].CurrentLine(), roots[j].CurrentLine(); li != lj {
+			return li < lj
+		}
+		return roots[i].CreatedAt.Before(roots[j].CreatedAt)
+	})
+
+	threads := []*threadView{}
+	for _, root := range roots {
+		threads = append(threads, buildThreadView(comments, root.ID))
+	}
+	return threads
+}
+
+// archiveMarkdown renders an archive as a self-contained Markdown document.
+func archiveMarkdown(a *prArchive) string {
+	pr := a.PullRequest
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s#%d: %s\n\n", a.Repository, pr.Number, pr.Title)
+	fmt.Fprintf(&b, "- URL: %s\n", pr.HTMLURL)
+	fmt.Fprintf(&b, "- Author: @%s\n", pr.User.Login)
+	fmt.Fprintf(&b, "- State: %s\n", pr.State)
+	fmt.Fprintf(&b, "- Base: %s (%s)\n", pr.Base.Ref, pr.Base.SHA)
+	fmt.Fprintf(&b, "- Head: %s (%s)\n", pr.Head.Ref, pr.Head.SHA)
+	if labels := pr.LabelNames(); len(labels) > 0 {
+		fmt.Fprintf(&b, "- Labels: %s\n", strings.Join(labels, ", "))
+	}
+	fmt.Fprintf(&b, "- Exported: %s (format version %d)\n", a.ExportedAt.Format(time.RFC3339), jsonFormatVersion)
+
+	b.WriteString("\n## Description\n\n")
+	writeArchiveBody(&b, pr.Body)
+
+	b.WriteString("\n## Reviews\n")
+	if len(a.Reviews) == 0 {
+		b.WriteString("\nNo reviews.\n")
+	}
+	for _, r := range a.Reviews {
+		fmt.Fprintf(&b, "\n### @%s: %s (%s)\n\n", r.User.Login, r.State, r.SubmittedAt.Format("2006-01-02 15:04"))
+		fmt.Fprintf(&b, "%s\n\n", r.HTMLURL)
+		writeArchiveBody(&b, r.Body)
+	}
+
+	b.WriteString("\n## Review threads\n")
+	if len(a.Threads) == 0 {
+		b.WriteString("\nNo review threads.\n")
+	}
+	for _, t := range a.Threads {
+		state := "unresolved"
+		if t.Resolved {
+			state = "resolved"
+		}
+		if t.Outdated {
+			state += ", outdated"
+		}
+		fmt.Fprintf(&b, "\n### %s (%s)\n\n", t.Location, state)
+		if hunk := t.Comments[0].DiffHunk; hunk != "" {
+			fmt.Fprintf(&b, "```diff\n%s\n```\n", strings.TrimRight(hunk, "\n"))
+		}
+		for _, c := range t.Comments {
+			writeArchiveComment(&b, c.User.Login, c.CreatedAt, c.HTMLURL, c.MinimizedReason, c.IsMinimized, c.Body)
+		}
+	}
+
+	b.WriteString("\n## Conversation\n")
+	if len(a.IssueComments) == 0 {
+		b.WriteString("\nNo general comments.\n")
+	}
+	for _, c := range a.IssueComments {
+		writeArchiveComment(&b, c.User.Login, c.CreatedAt, c.HTMLURL, c.MinimizedReason, c.IsMinimized, c.Body)
+	}
+	return b.String()
+}
+
+func writeArchiveComment(b *strings.Builder, login string, created time.Time, url, hiddenReason string, hidden bool, body string) {
+	fmt.Fprintf(b, "\n**@%s** · %s · %s", login, created.Format("2006-01-02 15:04"), url)
+	if hidden {
+		b.WriteString(" (hidden")
+		if hiddenReason != "" {
+			b.WriteString(": " + strings.ToLower(hiddenReason))
+		}
+		b.WriteString(")")
+	}
+	b.WriteString("\n\n")
+	writeArchiveBody(b, body)
+}
+
+func writeArchiveBody(b *strings.Builder, body string) {
+	if body = strings.TrimSpace(body); body == "" {
+		b.WriteString("_No text._\n")
+		return
+	}
+	b.WriteString(body + "\n")
+}
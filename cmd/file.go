@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fileJsonOutput bool
+	fileResolved   string
+)
+
+var fileCmd = &cobra.Command{
+	Use:   "file <path> [pr-reference]",
+	Short: "Show every review thread on one file, ordered by line",
+	Long: `Show the review conversation on one file: every thread on it ordered
+by line, each with its resolution state and its full reply chain, so a
+file's feedback can be worked through top to bottom.
+
+The path is relative to the repository root, or to the current directory
+when run inside the repository. File-level comments come first. Resolved
+threads are included; use --resolved=false to show only open ones.
+
+If no PR reference is given, finds the PR for the current branch.
+
+PR reference can be:
+  - Full URL: https://github.com/owner/repo/pull/123
+  - Short form: owner/repo/123
+  - Just number: 123 (when in a repo context)
+  - Omitted: uses current branch's PR
+
+Examples:
+  gh pr-comments file internal/github/client.go
+  gh pr-comments file client.go owner/repo/123
+  gh pr-comments file cmd/root.go --resolved=false
+  gh pr-comments file cmd/root.go --json`,
+	Args:        cobra.RangeArgs(1, 2),
+	Annotations: prArgAt(1),
+	RunE:        runFile,
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return completeCommentedFiles(cmd, nil, toComplete)
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	},
+}
+
+func init() {
+	fileCmd.Flags().BoolVar(&fileJsonOutput, "json", false, "Output in JSON format")
+	fileCmd.Flags().StringVar(&fileResolved, "resolved", "", "Filter by resolved status (true/false)")
+	fileCmd.RegisterFlagCompletionFunc("resolved", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"true\tShow only resolved threads", "false\tShow only unresolved threads"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	rootCmd.AddCommand(fileCmd)
+}
+
+func runFile(cmd *cobra.Command, args []string) error {
+	if fileResolved != "" && fileResolved != "true" && fileResolved != "false" {
+		return fmt.Errorf("invalid --resolved value: %s (valid: true, false)", fileResolved)
+	}
+
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(args[1:]))
+	if err != nil {
+		return err
+	}
+
+	comments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	path := repoRelativePath(args[0], comments)
+	threads := fileThreads(comments, path, fileResolved)
+
+	if fileJsonOutput {
+		if threads == nil {
+			threads = []*threadView{}
+		}
+		return writeJSON(threads)
+	}
+
+	if len(threads) == 0 {
+		fmt.Printf("No review threads on %s\n", path)
+		return nil
+	}
+	printFileThreads(path, threads)
+	return nil
+}
+
+// repoRelativePath maps the path given on the command line to the path
+// review comments use. A path that no comment uses as-is is tried relative
+// to the current directory inside the repository.
+func repoRelativePath(arg string, comments []github.ReviewComment) string {
+	path := filepath.ToSlash(filepath.Clean(arg))
+	for _, c := range comments {
+		if c.Path == path {
+			return path
+		}
+	}
+	root, err := github.GetRepoRoot()
+	if err != nil {
+		return path
+	}
+	abs, err := filepath.Abs(arg)
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// fileThreads returns the threads on path ordered by line, file-level
+// threads first, filtered by resolved ("true", "false", or "" for all).
+func fileThreads(comments []github.ReviewComment, path, resolved string) []*threadView {
+	var roots []github.ReviewComment
+	for _, c := range comments {
+		if c.Path != path || c.InReplyToID != 0 {
+			continue
+		}
+		if (resolved == "true" && !c.IsResolved) || (resolved == "false" && c.IsResolved) {
+			continue
+		}
+		roots = append(roots, c)
+	}
+	sort.SliceStable(roots, func(i, j int) bool {
+		if li, lj := roots[i].CurrentLine(), roots[j].CurrentLine(); li != lj {
+			return li < lj
+		}
+		return roots[i].CreatedAt.Before(roots[j].CreatedAt)
+	})
+
+	var threads []*threadView
+	for _, root := range roots {
+		threads = append(threads, buildThreadView(comments, root.ID))
+	}
+	return threads
+}
+
+func printFileThreads(path string, threads []*threadView) {
+	unresolved := 0
+	for _, t := range threads {
+		if !t.Resolved {
+			unresolved++
+		}
+	}
+	fmt.Printf("%s: %d thread(s), %d unresolved\n", path, len(threads), unresolved)
+
+	for _, t := range threads {
+		root := t.Comments[0]
+		location := "file"
+		if lines := root.LineRange(); lines != "" {
+			location = "L" + lines
+		}
+		state := "unresolved"
+		if t.Resolved {
+			state = "resolved"
+		}
+		if t.Outdated {
+			state += ", outdated"
+		}
+
+		fmt.Println()
+		fmt.Println(strings.Repeat("─", 60))
+		fmt.Printf("%s · %s · %d\n", location, state, t.RootID)
+		fmt.Println(strings.Repeat("─", 60))
+		for i, c := range t.Comments {
+			indent := "  "
+			if i > 0 {
+				indent = "    "
+				fmt.Println()
+			}
+			fmt.Printf("%s%s · %s · %d\n", indent, c.User.Login, c.CreatedAt.Format("2006-01-02 15:04"), c.ID)
+			for _, line := range strings.Split(strings.TrimSpace(c.Body), "\n") {
+				fmt.Println(strings.TrimRight(indent+line, " "))
+			}
+		}
+	}
+}
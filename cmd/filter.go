@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/marker"
+	"github.com/STRRL/gh-pr-comments/internal/severity"
+)
+
+// commentFilter holds the filter flags shared by commands that narrow down
+// review comments.
+type commentFilter struct {
+	ReviewID int64
+	Outdated string
+	Resolved string
+	All      bool
+	Subject  string
+	Author   string
+	File     string
+	Hidden   string
+
+	// Marker keeps comments whose bot marker contains it.
+	Marker string
+
+	// Commit keeps review comments made on commits whose SHA starts with it.
+	Commit string
+
+	// Since drops comments created before it when set.
+	Since time.Time
+
+	MinSeverity severity.Level
+}
+
+// Active reports whether any filter beyond the default resolved handling is set.
+func (f *commentFilter) Active() bool {
+	return f.ReviewID != 0 || f.Outdated != "" || f.Resolved != "" || f.Subject != "" || f.Author != "" || f.File != "" || f.Hidden != "" || f.Marker != "" || f.Commit != "" || !f.Since.IsZero() || f.MinSeverity != severity.Unknown
+}
+
+func (f *commentFilter) filterReviewComments(comments []github.ReviewComment) []github.ReviewComment {
+	var result []github.ReviewComment
+	for _, c := range comments {
+		if f.matchReviewComment(c) {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func (f *commentFilter) matchReviewComment(c github.ReviewComment) bool {
+	if f.ReviewID != 0 && c.PullRequestReviewID != f.ReviewID {
+		return false
+	}
+
+	if f.Author != "" && !strings.EqualFold(c.User.Login, f.Author) {
+		return false
+	}
+
+	if f.File != "" && !matchFile(f.File, c.Path) {
+		return false
+	}
+
+	if f.Commit != "" && !strings.HasPrefix(c.OriginalCommitID, f.Commit) {
+		return false
+	}
+
+	if f.Marker != "" && !marker.Match(marker.Of(c.Body), f.Marker) {
+		return false
+	}
+
+	if !f.matchSeverity(c.User.Login, c.Body) {
+		return false
+	}
+
+	if !f.matchHidden(c.IsMinimized) {
+		return false
+	}
+
+	if c.CreatedAt.Before(f.Since) {
+		return false
+	}
+
+	if f.Subject == "file" && !c.IsFileLevel() {
+		return false
+	}
+	if f.Subject == "line" && c.IsFileLevel() {
+		return false
+	}
+
+	if f.Outdated != "" {
+		isOutdated := c.IsOutdated()
+		if f.Outdated == "true" && !isOutdated {
+			return false
+		}
+		if f.Outdated == "false" && isOutdated {
+			return false
+		}
+	}
+
+	if !f.All {
+		if f.Resolved != "" {
+			if f.Resolved == "true" && !c.IsResolved {
+				return false
+			}
+			if f.Resolved == "false" && c.IsResolved {
+				return false
+			}
+		} else {
+			if c.IsResolved {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// matchIssueComment applies the filters that make sense for general PR
+// comments. File, subject, and commit filters exclude them since they have
+// no file or commit.
+func (f *commentFilter) matchIssueComment(c github.IssueComment) bool {
+	if f.File != "" || f.Subject != "" || f.Commit != "" {
+		return false
+	}
+	if f.Author != "" && !strings.EqualFold(c.User.Login, f.Author) {
+		return false
+	}
+	if f.Marker != "" && !marker.Match(marker.Of(c.Body), f.Marker) {
+		return false
+	}
+	if !f.matchHidden(c.IsMinimized) {
+		return false
+	}
+	if c.CreatedAt.Before(f.Since) {
+		return false
+	}
+	return f.matchSeverity(c.User.Login, c.Body)
+}
+
+// matchHidden applies the true/false Hidden filter to a comment's minimized
+// state.
+func (f *commentFilter) matchHidden(minimized bool) bool {
+	switch f.Hidden {
+	case "true":
+		return minimized
+	case "false":
+		return !minimized
+	}
+	return true
+}
+
+// matchSeverity drops comments below MinSeverity, including comments whose
+// severity cannot be determined.
+func (f *commentFilter) matchSeverity(author, body string) bool {
+	if f.MinSeverity == severity.Unknown {
+		return true
+	}
+	return severity.Classify(author, body).Severity >= f.MinSeverity
+}
+
+// latestPerMarker drops the earlier copies of comments an author reposted
+// under the same marker, keeping only the newest one, which is what a bot
+// that replaces its sticky comment leaves visible on GitHub. Comments
+// without a marker are kept.
+func latestPerMarker(comments []github.IssueComment) []github.IssueComment {
+	latest := make(map[string]github.IssueComment)
+	for _, c := range comments {
+		m := marker.Of(c.Body)
+		if m == "" {
+			continue
+		}
+		key := strings.ToLower(c.User.Login) + "\x00" + m
+		if prev, ok := latest[key]; !ok || c.CreatedAt.After(prev.CreatedAt) {
+			latest[key] = c
+		}
+	}
+
+	var result []github.IssueComment
+	for _, c := range comments {
+		m := marker.Of(c.Body)
+		if m == "" || latest[strings.ToLower(c.User.Login)+"\x00"+m].ID == c.ID {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// matchFile accepts an exact path, a directory prefix, or a glob pattern.
+func matchFile(pattern, file string) bool {
+	if pattern == file {
+		return true
+	}
+	if strings.HasPrefix(file, strings.TrimSuffix(pattern, "/")+"/") {
+		return true
+	}
+	matched, _ := path.Match(pattern, file)
+	return matched
+}
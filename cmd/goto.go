@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/kballard/go-shellquote"
+	"github.com/spf13/cobra"
+)
+
+var (
+	gotoPR     string
+	gotoEditor string
+)
+
+var gotoCmd = &cobra.Command{
+	Use:   "goto <comment-id>",
+	Short: "Open the commented file and line in your editor",
+	Long: `Open the file and line a review comment points at in the local checkout.
+
+The editor is taken from --editor, then $GH_PR_COMMENTS_EDITOR, $VISUAL, and
+$EDITOR. The command may contain {file} and {line} placeholders, for example:
+
+  code -g {file}:{line}
+  vim +{line} {file}
+
+Without placeholders, VS Code-like editors get "-g file:line" and all other
+editors get "+line file".
+
+Examples:
+  gh pr-comments goto 2621968472
+  gh pr-comments goto 2621968472 --editor "code -g {file}:{line}"`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runGoto,
+	ValidArgsFunction: completeReviewCommentIDs,
+}
+
+func init() {
+	gotoCmd.Flags().StringVar(&gotoPR, "pr", "", "PR reference (e.g., owner/repo/123 or just 123)")
+	gotoCmd.RegisterFlagCompletionFunc("pr", completePRs)
+	gotoCmd.Flags().StringVar(&gotoEditor, "editor", "", "Editor command (supports {file} and {line} placeholders)")
+	rootCmd.AddCommand(gotoCmd)
+}
+
+func runGoto(cmd *cobra.Command, args []string) error {
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	commentID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid comment ID: %s", args[0])
+	}
+
+	var prArgs []string
+	if gotoPR != "" {
+		prArgs = []string{gotoPR}
+	}
+
+	prRef, err := client.ResolvePRReference(prArgs)
+	if err != nil {
+		return fmt.Errorf("could not determine PR: %w\nPlease specify a PR with --pr or run from a branch with an associated PR", err)
+	}
+
+	comment, err := findReviewCommentByID(client, prRef, commentID)
+	if err != nil {
+		return err
+	}
+
+	root, err := github.GetRepoRoot()
+	if err != nil {
+		return err
+	}
+
+	file := filepath.Join(root, filepath.FromSlash(comment.Path))
+	if _, err := os.Stat(file); err != nil {
+		return fmt.Errorf("file %s not found in local checkout: %w", comment.Path, err)
+	}
+
+	editorArgs, err := editorCommand(file, comment.CurrentLine())
+	if err != nil {
+		return err
+	}
+
+	c := exec.Command(editorArgs[0], editorArgs[1:]...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	return c.Run()
+}
+
+func findReviewCommentByID(client *github.Client, prRef *github.PRReference, commentID int64) (*github.ReviewComment, error) {
+	comments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+	for i := range comments {
+		if comments[i].ID == commentID {
+			return &comments[i], nil
+		}
+	}
+	return nil, fmt.Errorf("review comment with ID %d not found in PR %d", commentID, prRef.Number)
+}
+
+func editorCommand(file string, line int) ([]string, error) {
+	editor := gotoEditor
+	for _, env := range []string{"GH_PR_COMMENTS_EDITOR", "VISUAL", "EDITOR"} {
+		if editor == "" {
+			editor = os.Getenv(env)
+		}
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	if line < 1 {
+		line = 1
+	}
+
+	parts, err := shellquote.Split(editor)
+	if err != nil || len(parts) == 0 {
+		return nil, fmt.Errorf("invalid editor command: %q", editor)
+	}
+
+	lineStr := strconv.Itoa(line)
+	if strings.Contains(editor, "{file}") {
+		for i, p := range parts {
+			p = strings.ReplaceAll(p, "{file}", file)
+			parts[i] = strings.ReplaceAll(p, "{line}", lineStr)
+		}
+		return parts, nil
+	}
+
+	switch filepath.Base(parts[0]) {
+	case "code", "code-insiders", "codium", "cursor", "windsurf":
+		return append(parts, "-g", file+":"+lineStr), nil
+	case "subl", "zed":
+		return append(parts, file+":"+lineStr), nil
+	default:
+		return append(parts, "+"+lineStr, file), nil
+	}
+}
@@ -1,22 +1,27 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 
 	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/journal"
+	"github.com/STRRL/gh-pr-comments/internal/marker"
 	"github.com/spf13/cobra"
 )
 
 var (
-	hideReason     string
-	hideAuthor     string
-	hidePR         string
-	hideJsonOutput bool
-	hideDryRun     bool
+	hideReason      string
+	hideAuthor      string
+	hideMarker      string
+	hideType        string
+	hideReviewID    int64
+	hideFile        string
+	hidePR          string
+	hideJsonOutput  bool
+	hideDryRun      bool
+	hideInteractive bool
 )
 
 var hideCmd = &cobra.Command{
@@ -27,6 +32,15 @@ var hideCmd = &cobra.Command{
 
 When a comment ID is provided, hides that specific comment.
 When no ID is provided, uses filters to select comments for batch hiding.
+The filters combine: a comment is hidden only if it matches all of them.
+Comments that are already hidden are skipped.
+
+Batch filters:
+  --author     Comment author
+  --marker     Bot marker (hidden <!-- --> text, substring match)
+  --type       review (inline comments) or issue (general PR comments)
+  --review-id  Review the comment belongs to (review comments only)
+  --file       Path, directory, or glob (review comments only)
 
 Reasons (--reason):
   abuse     - Abusive or harmful content
@@ -46,8 +60,20 @@ Examples:
   # Hide all comments by a specific author
   gh pr-comments hide --author "claude[bot]" --reason outdated
 
+  # Hide every comment carrying a bot's marker (see 'list --json')
+  gh pr-comments hide --marker "codecov-comment" --reason outdated
+
+  # Hide one bot's inline comments from a single review under internal/
+  gh pr-comments hide --author "coderabbitai[bot]" --type review --review-id 3581523351 --file internal/
+
+  # Hide a bot's general PR comments but keep its inline ones
+  gh pr-comments hide --author "claude[bot]" --type issue --reason outdated
+
   # Dry run to see what would be hidden
-  gh pr-comments hide --author "bot" --dry-run`,
+  gh pr-comments hide --author "bot" --dry-run
+
+  # Pick comments to hide from a list (optionally narrowed by --author)
+  gh pr-comments hide --interactive --author "claude[bot]"`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runHide,
 }
@@ -57,12 +83,29 @@ func init() {
 		"Reason for hiding (abuse, duplicate, off-topic, outdated, resolved, spam)")
 	hideCmd.Flags().StringVar(&hideAuthor, "author", "",
 		"Filter by comment author for batch operations")
+	hideCmd.Flags().StringVar(&hideMarker, "marker", "",
+		"Filter by bot marker (substring match) for batch operations")
+	hideCmd.Flags().StringVar(&hideType, "type", "",
+		"Filter by comment type (review/issue) for batch operations")
+	hideCmd.Flags().Int64Var(&hideReviewID, "review-id", 0,
+		"Filter by review ID (review comments only) for batch operations")
+	hideCmd.Flags().StringVar(&hideFile, "file", "",
+		"Filter by file path, directory, or glob (review comments only) for batch operations")
 	hideCmd.Flags().StringVar(&hidePR, "pr", "",
 		"PR reference (e.g., owner/repo/123)")
+	hideCmd.RegisterFlagCompletionFunc("pr", completePRs)
+	hideCmd.RegisterFlagCompletionFunc("author", completeAuthors)
+	hideCmd.RegisterFlagCompletionFunc("review-id", completeReviewIDs)
+	hideCmd.RegisterFlagCompletionFunc("file", completeCommentedFiles)
+	hideCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"review\tInline code comments", "issue\tGeneral PR comments"}, cobra.ShellCompDirectiveNoFileComp
+	})
 	hideCmd.Flags().BoolVar(&hideJsonOutput, "json", false,
 		"Output in JSON format")
 	hideCmd.Flags().BoolVar(&hideDryRun, "dry-run", false,
 		"Show what would be hidden without actually doing it")
+	hideCmd.Flags().BoolVarP(&hideInteractive, "interactive", "i", false,
+		"Pick comments to hide from a list")
 
 	rootCmd.AddCommand(hideCmd)
 }
@@ -78,6 +121,9 @@ type hideResult struct {
 }
 
 func runHide(cmd *cobra.Command, args []string) error {
+	if err := requireLiveData("hide"); err != nil {
+		return err
+	}
 	client, err := github.NewClient()
 	if err != nil {
 		return err
@@ -102,8 +148,16 @@ func runHide(cmd *cobra.Command, args []string) error {
 		return hideSingleComment(client, prRef, args[0], classifier)
 	}
 
-	if hideAuthor == "" {
-		return fmt.Errorf("batch hide requires --author filter\nProvide a comment ID for single comment, or use --author for batch operations")
+	switch hideType {
+	case "", "review", "issue":
+	case "review_comment", "issue_comment":
+		hideType = strings.TrimSuffix(hideType, "_comment")
+	default:
+		return fmt.Errorf("invalid type: %s (valid: review, issue)", hideType)
+	}
+
+	if hideAuthor == "" && hideMarker == "" && hideType == "" && hideReviewID == 0 && hideFile == "" && !hideInteractive {
+		return fmt.Errorf("batch hide requires a filter\nProvide a comment ID for single comment, or use --author, --marker, --type, --review-id, or --file for batch operations")
 	}
 
 	return hideBatch(client, prRef, classifier)
@@ -141,52 +195,89 @@ func hideSingleComment(client *github.Client, prRef *github.PRReference, comment
 		result.Error = err.Error()
 	} else {
 		result.Success = true
+		journalAction(prRef, journal.Entry{Action: "hide", NodeID: nodeID, Reason: string(classifier)})
 	}
 
 	return outputResult(result)
 }
 
 func hideBatch(client *github.Client, prRef *github.PRReference, classifier github.CommentClassifier) error {
-	reviewComments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
-	if err != nil {
-		return err
+	// Already hidden comments are skipped, so their hidden state must be
+	// known: hiding one again would journal a hide that undo then reverts.
+	client.RequireCompleteStatus()
+	var reviewComments []github.ReviewComment
+	var err error
+	if hideType != "issue" {
+		reviewComments, err = client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+		if err != nil {
+			return err
+		}
 	}
 
-	issueComments, err := client.GetIssueComments(prRef.Owner, prRef.Repo, prRef.Number)
-	if err != nil {
-		return err
+	// Review and file filters only apply to review comments, so they
+	// exclude general PR comments like --type review does.
+	var issueComments []github.IssueComment
+	if hideType != "review" && hideReviewID == 0 && hideFile == "" {
+		issueComments, err = client.GetIssueComments(prRef.Owner, prRef.Repo, prRef.Number)
+		if err != nil {
+			return err
+		}
 	}
 
 	var targets []hideResult
+	var options []string
 
-	authorFilter := strings.ToLower(hideAuthor)
 	for _, c := range reviewComments {
-		if strings.ToLower(c.User.Login) == authorFilter {
+		if c.IsMinimized || (hideReviewID != 0 && c.PullRequestReviewID != hideReviewID) {
+			continue
+		}
+		if hideFile != "" && !matchFile(hideFile, c.Path) {
+			continue
+		}
+		if hideMatches(c.User.Login, c.Body) {
 			targets = append(targets, hideResult{
 				ID:     c.ID,
 				NodeID: c.NodeID,
 				Type:   "review_comment",
 				Author: c.User.Login,
 			})
+			options = append(options, pickerLabel(c.ID, c.Path, c.User.Login, c.Body))
 		}
 	}
 
 	for _, c := range issueComments {
-		if strings.ToLower(c.User.Login) == authorFilter {
+		if !c.IsMinimized && hideMatches(c.User.Login, c.Body) {
 			targets = append(targets, hideResult{
 				ID:     c.ID,
 				NodeID: c.NodeID,
 				Type:   "issue_comment",
 				Author: c.User.Login,
 			})
+			options = append(options, pickerLabel(c.ID, "", c.User.Login, c.Body))
 		}
 	}
 
+	if hideInteractive && len(targets) > 0 {
+		selected, err := pickMany("Select comments to hide", options)
+		if err != nil {
+			return err
+		}
+		var picked []hideResult
+		for _, i := range selected {
+			picked = append(picked, targets[i])
+		}
+		targets = picked
+	}
+
 	if len(targets) == 0 {
 		if hideJsonOutput {
-			return json.NewEncoder(os.Stdout).Encode([]hideResult{})
+			return writeJSON(nil)
 		}
-		fmt.Printf("No comments found by author '%s'\n", hideAuthor)
+		if hideInteractive {
+			fmt.Println("Nothing selected.")
+			return nil
+		}
+		fmt.Println("No comments match the filters")
 		return nil
 	}
 
@@ -209,6 +300,7 @@ func hideBatch(client *github.Client, prRef *github.PRReference, classifier gith
 			result.Error = opErr.Error()
 		} else {
 			result.Success = true
+			journalAction(prRef, journal.Entry{Action: "hide", NodeID: t.NodeID, Reason: string(classifier)})
 		}
 		results = append(results, result)
 	}
@@ -216,6 +308,18 @@ func hideBatch(client *github.Client, prRef *github.PRReference, classifier gith
 	return outputResults(results)
 }
 
+// hideMatches reports whether a comment passes the author and marker
+// filters.
+func hideMatches(author, body string) bool {
+	if hideAuthor != "" && !strings.EqualFold(author, hideAuthor) {
+		return false
+	}
+	if hideMarker != "" && !marker.Match(marker.Of(body), hideMarker) {
+		return false
+	}
+	return true
+}
+
 func findCommentNodeID(client *github.Client, prRef *github.PRReference, commentID int64) (nodeID, commentType, author string, err error) {
 	reviewComments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
 	if err != nil {
@@ -253,9 +357,7 @@ func getActionDisplayString(action string) string {
 
 func outputResult(result hideResult) error {
 	if hideJsonOutput {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(result)
+		return writeJSON(result)
 	}
 
 	if result.Success {
@@ -268,9 +370,7 @@ func outputResult(result hideResult) error {
 
 func outputResults(results []hideResult) error {
 	if hideJsonOutput {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(results)
+		return writeJSON(results)
 	}
 
 	successCount := 0
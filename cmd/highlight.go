@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/STRRL/gh-pr-comments/internal/emoji"
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/cli/go-gh/v2/pkg/term"
+)
+
+const (
+	ansiReset = "\x1b[0m"
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiCyan  = "\x1b[36m"
+	ansiDim   = "\x1b[2m"
+	ansiBold  = "\x1b[1m"
+)
+
+func colorEnabled(noColor bool) bool {
+	if noColor {
+		return false
+	}
+	return term.FromEnv().IsColorEnabled()
+}
+
+// bodyText prepares a comment body for the terminal, rendering :shortcode:
+// emoji as Unicode unless noEmoji is set.
+func bodyText(body string, noEmoji bool) string {
+	if noEmoji {
+		return body
+	}
+	return emoji.Render(body)
+}
+
+// highlightDiffHunk colors diff markers and syntax-highlights the code of a
+// diff hunk using a lexer picked from the file name. The whole hunk is
+// tokenized at once so multi-line constructs such as block comments keep
+// their context.
+func highlightDiffHunk(hunk, path string) string {
+	lines := strings.Split(hunk, "\n")
+
+	markers := make([]string, len(lines))
+	var code strings.Builder
+	for i, line := range lines {
+		if strings.HasPrefix(line, "@@") {
+			markers[i] = line
+			code.WriteString("\n")
+			continue
+		}
+		if line != "" {
+			markers[i] = line[:1]
+			code.WriteString(line[1:])
+		}
+		code.WriteString("\n")
+	}
+
+	lexer := lexers.Match(path)
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	lexer = chroma.Coalesce(lexer)
+
+	formatter := formatters.Get("terminal256")
+	if term.FromEnv().IsTrueColorSupported() {
+		formatter = formatters.Get("terminal16m")
+	}
+	style := styles.Get("monokai")
+
+	var codeLines [][]chroma.Token
+	if iterator, err := lexer.Tokenise(nil, code.String()); err == nil {
+		codeLines = chroma.SplitTokensIntoLines(iterator.Tokens())
+	}
+
+	var out strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			out.WriteString("\n")
+		}
+		marker := markers[i]
+		switch {
+		case strings.HasPrefix(marker, "@@"):
+			out.WriteString(ansiCyan + marker + ansiReset)
+			continue
+		case marker == "+":
+			out.WriteString(ansiGreen + "+" + ansiReset)
+		case marker == "-":
+			out.WriteString(ansiRed + "-" + ansiReset)
+		default:
+			out.WriteString(marker)
+		}
+
+		if i >= len(codeLines) {
+			if line != "" {
+				out.WriteString(line[1:])
+			}
+			continue
+		}
+		tokens := make([]chroma.Token, len(codeLines[i]))
+		for j, t := range codeLines[i] {
+			t.Value = strings.TrimSuffix(t.Value, "\n")
+			tokens[j] = t
+		}
+		var buf bytes.Buffer
+		if err := formatter.Format(&buf, style, chroma.Literator(tokens...)); err != nil {
+			out.WriteString(line[1:])
+			continue
+		}
+		out.WriteString(buf.String())
+	}
+	return out.String()
+}
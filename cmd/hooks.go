@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+)
+
+// hookMarker identifies hooks written by 'hooks install', so they can be
+// replaced or removed without touching hooks written by anything else.
+const hookMarker = "# Installed by gh pr-comments hooks install."
+
+var (
+	hooksInstallMode  string
+	hooksInstallForce bool
+	hooksPrePushMode  string
+)
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Install git hooks that check review feedback",
+}
+
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install a pre-push hook that flags unresolved review threads",
+	Long: `Write a git pre-push hook that checks the current branch's pull request
+before every push. When the PR still has unresolved review threads, the hook
+lists them and either warns and lets the push go ahead (--mode warn) or
+stops it (--mode block). Branches without a PR are pushed silently.
+
+The mode can be changed later without reinstalling by setting the
+pr-comments.prePush git config to warn, block, or off. 'git push
+--no-verify' skips the hook for one push. If GitHub cannot be reached, the
+hook warns and never blocks.
+
+The hook goes to the repository's hooks directory (core.hooksPath when
+set). An existing pre-push hook not written by this command is left alone
+unless --force is given.
+
+Examples:
+  gh pr-comments hooks install
+  gh pr-comments hooks install --mode block
+  git config pr-comments.prePush off
+  gh pr-comments hooks uninstall`,
+	Args: cobra.NoArgs,
+	RunE: runHooksInstall,
+}
+
+var hooksUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the pre-push hook written by 'hooks install'",
+	Long: `Remove the pre-push hook written by 'hooks install'. A pre-push hook
+written by anything else is left alone.
+
+Examples:
+  gh pr-comments hooks uninstall`,
+	Args: cobra.NoArgs,
+	RunE: runHooksUninstall,
+}
+
+var hooksPrePushCmd = &cobra.Command{
+	Use:    "pre-push",
+	Short:  "Check the current branch's PR for unresolved threads (run by the hook)",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	RunE:   runHooksPrePush,
+}
+
+func init() {
+	hooksInstallCmd.Flags().StringVar(&hooksInstallMode, "mode", "warn", "What to do about unresolved threads (warn/block)")
+	hooksInstallCmd.Flags().BoolVar(&hooksInstallForce, "force", false, "Replace an existing pre-push hook")
+	hooksInstallCmd.RegisterFlagCompletionFunc("mode", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"warn\tList unresolved threads and push anyway", "block\tStop the push"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	hooksPrePushCmd.Flags().StringVar(&hooksPrePushMode, "mode", "warn", "What to do about unresolved threads (warn/block)")
+	hooksCmd.AddCommand(hooksInstallCmd, hooksUninstallCmd, hooksPrePushCmd)
+	rootCmd.AddCommand(hooksCmd)
+}
+
+func prePushHookPath() (string, error) {
+	dir, err := github.GitPath("hooks")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "pre-push"), nil
+}
+
+// ownHook reports whether the hook at path was written by 'hooks install'.
+// A missing hook is reported with exists false.
+func ownHook(path string) (own, exists bool, err error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+	return strings.Contains(string(data), hookMarker), true, nil
+}
+
+func runHooksInstall(cmd *cobra.Command, args []string) error {
+	if hooksInstallMode != "warn" && hooksInstallMode != "block" {
+		return fmt.Errorf("invalid --mode value: %s (valid: warn, block)", hooksInstallMode)
+	}
+	path, err := prePushHookPath()
+	if err != nil {
+		return err
+	}
+	own, exists, err := ownHook(path)
+	if err != nil {
+		return err
+	}
+	if exists && !own && !hooksInstallForce {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%s already exists and was not written by gh pr-comments; use --force to replace it", path)
+	}
+
+	script := fmt.Sprintf("#!/bin/sh\n%s\n# Change the mode with: git config pr-comments.prePush warn|block|off\nexec gh pr-comments hooks pre-push --mode %s\n", hookMarker, hooksInstallMode)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create hooks directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		return fmt.Errorf("write hook: %w", err)
+	}
+	fmt.Printf("Installed pre-push hook (%s mode) at %s\n", hooksInstallMode, path)
+	return nil
+}
+
+func runHooksUninstall(cmd *cobra.Command, args []string) error {
+	path, err := prePushHookPath()
+	if err != nil {
+		return err
+	}
+	own, exists, err := ownHook(path)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		fmt.Println("No pre-push hook installed")
+		return nil
+	}
+	if !own {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%s was not written by gh pr-comments; remove it by hand", path)
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("remove hook: %w", err)
+	}
+	fmt.Printf("Removed pre-push hook %s\n", path)
+	return nil
+}
+
+// runHooksPrePush is what the installed hook runs. It only fails, stopping
+// the push, in block mode when unresolved threads were found; problems
+// reaching GitHub are reported as warnings.
+func runHooksPrePush(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	mode := hooksPrePushMode
+	if output, err := exec.Command("git", "config", "--get", "pr-comments.prePush").Output(); err == nil {
+		mode = strings.ToLower(strings.TrimSpace(string(output)))
+	}
+	if mode == "off" {
+		return nil
+	}
+	if requireLiveData("hooks pre-push") != nil {
+		return nil
+	}
+
+	warn := func(err error) error {
+		fmt.Fprintf(os.Stderr, "gh pr-comments: skipping review check: %v\n", err)
+		return nil
+	}
+	client, err := github.NewClient()
+	if err != nil {
+		return warn(err)
+	}
+	prRef, err := client.ResolvePRReference(withGlobalPR(nil))
+	if err != nil {
+		// Branches without a PR are the common case for a first push.
+		return nil
+	}
+	threads, err := client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return warn(err)
+	}
+
+	var unresolved []github.ReviewThread
+	for _, t := range threads {
+		if !t.IsResolved {
+			unresolved = append(unresolved, t)
+		}
+	}
+	if len(unresolved) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "gh pr-comments: PR #%d has %d unresolved review thread(s):\n", prRef.Number, len(unresolved))
+	const shown = 10
+	for i, t := range unresolved {
+		if i == shown {
+			fmt.Fprintf(os.Stderr, "  ... and %d more (gh pr-comments threads)\n", len(unresolved)-shown)
+			break
+		}
+		line := "  " + t.Location()
+		if len(t.Comments) > 0 {
+			first := t.Comments[0]
+			line += fmt.Sprintf(" %s: %s", first.Author, github.TruncateString(strings.Join(strings.Fields(first.Body), " "), 60))
+		}
+		fmt.Fprintln(os.Stderr, line)
+	}
+	if mode == "block" {
+		fmt.Fprintln(os.Stderr, "Push stopped. Resolve the threads, or push anyway with 'git push --no-verify'.")
+		return fmt.Errorf("unresolved review threads on PR #%d", prRef.Number)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	inboxJsonOutput bool
+	inboxQuery      string
+)
+
+var inboxCmd = &cobra.Command{
+	Use:   "inbox",
+	Short: "List unresolved threads awaiting your reply across repositories",
+	Long: `List unresolved review threads on pull requests you authored, across all
+repositories, where the last comment was made by someone else.
+
+Pull requests are found with the GitHub search API (open PRs authored by you).
+Use --query to narrow the search with additional qualifiers.
+
+Examples:
+  gh pr-comments inbox
+  gh pr-comments inbox --query "org:my-org"
+  gh pr-comments inbox --json`,
+	Args: cobra.NoArgs,
+	RunE: runInbox,
+}
+
+func init() {
+	inboxCmd.Flags().BoolVar(&inboxJsonOutput, "json", false, "Output in JSON format")
+	inboxCmd.Flags().StringVar(&inboxQuery, "query", "", "Additional search qualifiers (e.g., \"org:my-org\")")
+	rootCmd.AddCommand(inboxCmd)
+}
+
+type inboxItem struct {
+	Repo       string `json:"repo"`
+	PR         int    `json:"pr"`
+	PRTitle    string `json:"pr_title"`
+	ThreadID   string `json:"thread_id"`
+	CommentID  int64  `json:"comment_id"`
+	File       string `json:"file"`
+	Line       string `json:"line,omitempty"`
+	LastAuthor string `json:"last_author"`
+	Body       string `json:"body"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+func runInbox(cmd *cobra.Command, args []string) error {
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	me, err := client.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	query := "is:open author:@me"
+	if inboxQuery != "" {
+		query += " " + inboxQuery
+	}
+
+	prs, err := client.SearchPullRequests(query)
+	if err != nil {
+		return err
+	}
+
+	var items []inboxItem
+	for _, pr := range prs {
+		prRef := pr.PRReference()
+		threads, err := client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
+		if err != nil {
+			return fmt.Errorf("get review threads for %s/%s#%d: %w", prRef.Owner, prRef.Repo, prRef.Number, err)
+		}
+
+		for _, t := range threads {
+			last := t.LastComment()
+			if t.IsResolved || last == nil || strings.EqualFold(last.Author, me.Login) {
+				continue
+			}
+			line := ""
+			if t.Line != nil {
+				line = fmt.Sprintf("%d", *t.Line)
+			}
+			items = append(items, inboxItem{
+				Repo:       prRef.Owner + "/" + prRef.Repo,
+				PR:         prRef.Number,
+				PRTitle:    pr.Title,
+				ThreadID:   t.ID,
+				CommentID:  last.ID,
+				File:       t.Path,
+				Line:       line,
+				LastAuthor: last.Author,
+				Body:       last.Body,
+				UpdatedAt:  last.CreatedAt.Format("2006-01-02 15:04"),
+			})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].UpdatedAt < items[j].UpdatedAt
+	})
+
+	if inboxJsonOutput {
+		return writeJSON(items)
+	}
+
+	if len(items) == 0 {
+		fmt.Printf("Inbox zero: no threads awaiting your reply across %d PR(s).\n", len(prs))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REPO\tPR\tID\tFILE\tLINE\tLAST AUTHOR\tUPDATED\tBODY")
+	for _, item := range items {
+		body := github.TruncateString(item.Body, 40)
+		fmt.Fprintf(w, "%s\t#%d\t%d\t%s\t%s\t%s\t%s\t%s\n",
+			item.Repo, item.PR, item.CommentID, item.File, item.Line, item.LastAuthor, item.UpdatedAt, body)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/journal"
+)
+
+// journalAction records a change made on a PR in the local journal read by
+// 'undo' and 'view', tagged with the PR so undo stays scoped to it. The
+// journal is a safety net, so failing to write it only warns.
+func journalAction(prRef *github.PRReference, e journal.Entry) {
+	e.Repo = prRef.Owner + "/" + prRef.Repo
+	e.PR = prRef.Number
+	if err := journal.Record(e); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
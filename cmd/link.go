@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	linkPR       string
+	linkMarkdown bool
+	linkCopy     bool
+)
+
+var linkCmd = &cobra.Command{
+	Use:   "link <comment-id>",
+	Short: "Print or copy a permalink to a comment",
+	Long: `Print a permalink to a review comment, review, or issue comment.
+
+With --markdown, the link is formatted as a markdown citation. Review
+comments are cited by file and line, other items by author.
+
+With --copy, the link is copied to the clipboard instead of printed.
+
+Examples:
+  gh pr-comments link 2621968472
+  gh pr-comments link 2621968472 --markdown
+  gh pr-comments link 2621968472 --markdown --copy`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runLink,
+	ValidArgsFunction: completeCommentIDs,
+}
+
+func init() {
+	linkCmd.Flags().StringVar(&linkPR, "pr", "", "PR reference (e.g., owner/repo/123 or just 123)")
+	linkCmd.RegisterFlagCompletionFunc("pr", completePRs)
+	linkCmd.Flags().BoolVar(&linkMarkdown, "markdown", false, "Format as a markdown citation")
+	linkCmd.Flags().BoolVar(&linkCopy, "copy", false, "Copy to the clipboard instead of printing")
+	rootCmd.AddCommand(linkCmd)
+}
+
+func runLink(cmd *cobra.Command, args []string) error {
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid comment ID: %s", args[0])
+	}
+
+	var prArgs []string
+	if linkPR != "" {
+		prArgs = []string{linkPR}
+	}
+
+	prRef, err := client.ResolvePRReference(prArgs)
+	if err != nil {
+		return fmt.Errorf("could not determine PR: %w\nPlease specify a PR with --pr or run from a branch with an associated PR", err)
+	}
+
+	item, err := findPRItem(client, prRef, id)
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		return fmt.Errorf("item with ID %d not found in PR %d", id, prRef.Number)
+	}
+
+	link := item.HTMLURL()
+	if linkMarkdown {
+		link = markdownCitation(item)
+	}
+
+	if linkCopy {
+		if err := copyToClipboard(link); err != nil {
+			return fmt.Errorf("copy to clipboard: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Copied: %s\n", link)
+		return nil
+	}
+
+	fmt.Println(link)
+	return nil
+}
+
+func markdownCitation(item *prItem) string {
+	switch {
+	case item.ReviewComment != nil:
+		return fmt.Sprintf("[`%s`](%s)", item.ReviewComment.Location(), item.ReviewComment.HTMLURL)
+	case item.Review != nil:
+		return fmt.Sprintf("[review by @%s](%s)", item.Review.User.Login, item.Review.HTMLURL)
+	default:
+		return fmt.Sprintf("[comment by @%s](%s)", item.IssueComment.User.Login, item.IssueComment.HTMLURL)
+	}
+}
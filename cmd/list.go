@@ -1,22 +1,38 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
+	"strings"
 	"text/tabwriter"
 
+	"github.com/STRRL/gh-pr-comments/internal/codeowners"
 	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/marker"
+	"github.com/STRRL/gh-pr-comments/internal/severity"
 	"github.com/spf13/cobra"
 )
 
 var (
-	listJsonOutput  bool
-	listReviewID    int64
-	listOutdated    string
-	listResolved    string
-	listAll         bool
-	listCommentType string
+	listJsonOutput    bool
+	listFormat        string
+	listFilter        commentFilter
+	listCommentType   string
+	listAllPRs        bool
+	listState         string
+	listBlame         bool
+	listOwners        bool
+	listOwnedBy       string
+	listMinSeverity   string
+	listTag           string
+	listReviewState   string
+	listUnanswered    bool
+	listSinceLastPush bool
+	listCommits       bool
+	listProblems      bool
+	listNoEmoji       bool
+	listLatest        bool
 )
 
 var listCmd = &cobra.Command{
@@ -28,6 +44,58 @@ var listCmd = &cobra.Command{
 By default, resolved review comments are hidden. Use --all to show all comments,
 or --resolved=true to show only resolved comments.
 
+Comments from CodeRabbit, GitHub Copilot, and SonarCloud are classified by the
+severity and category stated in their bodies and shown in a SEVERITY column.
+--min-severity keeps only comments at or above a level; comments without a
+recognized severity are dropped.
+
+--review-state keeps only review comments whose thread was opened in a review
+with that state, e.g. CHANGES_REQUESTED to address merge-blocking feedback
+first. Replies follow the thread's first comment; issue comments are dropped.
+
+The HIDDEN column shows whether a comment has been minimized (by 'hide',
+'cleanup', or on GitHub) and why. --hidden=true or --hidden=false filters on it.
+
+--unanswered keeps only review threads started by someone other than the PR
+author in which the author has never replied; issue comments are dropped.
+
+--since-last-push keeps only comments posted after the PR's head last changed
+(its latest commit or force push), i.e. feedback on the current code.
+
+--format agent prints a compact, deterministic plain-text digest meant for
+LLM prompts: one block per comment with its ID, location, state, one-line
+body, replies, and suggested next commands. The layout is a stable contract
+described in the README; breaking changes bump the version in its first line.
+
+--problems (same as --format problems) prints one line per review thread,
+"path:line:1: severity: author: message (id)", for editors to pick up.
+Severity is error for critical or major bot findings, info for resolved
+threads, and warning otherwise; issue comments are skipped. To fill the VS
+Code Problems panel, run it from a task with this problem matcher:
+
+` + problemMatcher + `
+
+--commits adds a COMMIT column with the commit each review comment was made
+on, and a CHANGED column counting the PR commits after it that touched the
+commented file. A comment whose file changed since is likely addressed.
+Commits after a comment are those following its commit in the PR, or made
+after the comment if that commit was force-pushed away. This fetches every
+later commit, so it is slower on long PRs.
+
+--commit keeps only review comments made against one commit of the PR,
+given as a full or abbreviated SHA or any local revision (e.g. HEAD~2).
+Combine it with --commits to see which round of feedback went with which
+push; issue comments are dropped.
+
+Bots that keep a comment up to date tag it with a hidden HTML comment, such
+as <!-- codecov-comment -->. --json reports its text as "marker", and
+--marker keeps comments whose marker contains the given text (ignoring
+case), to single out one bot surface for hide or plan.
+
+--latest-per-marker collapses the general comments an author reposted
+under the same marker to the newest one, as a bot replacing its sticky
+comment leaves it on GitHub.
+
 If no PR reference is given, finds the PR for the current branch.
 
 PR reference can be:
@@ -45,67 +113,426 @@ Examples:
   gh pr-comments list https://github.com/owner/repo/pull/123
   gh pr-comments list owner/repo/123 --review-id=3581523351
   gh pr-comments list 123 --outdated
-  gh pr-comments list 123 --outdated=false`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runList,
+  gh pr-comments list 123 --outdated=false
+  gh pr-comments list --all-prs --state open --type=review_comment
+  gh pr-comments list --blame
+  gh pr-comments list --owners
+  gh pr-comments list --owned-by @org/team
+  gh pr-comments list --subject=file
+  gh pr-comments list --author "coderabbitai[bot]" --file cmd/
+  gh pr-comments list --min-severity major
+  gh pr-comments list --tag will-fix
+  gh pr-comments list --review-state CHANGES_REQUESTED
+  gh pr-comments list --unanswered
+  gh pr-comments list --since-last-push
+  gh pr-comments list --commits
+  gh pr-comments list --all --commit 1a2b3c4
+  gh pr-comments list --format agent
+  gh pr-comments list --problems
+  gh pr-comments list --all --hidden=true
+  gh pr-comments list --all --marker codecov-comment
+  gh pr-comments list --type=issue_comment --latest-per-marker`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runList,
 }
 
 func init() {
 	listCmd.Flags().BoolVar(&listJsonOutput, "json", false, "Output in JSON format")
-	listCmd.Flags().Int64Var(&listReviewID, "review-id", 0, "Filter by review ID (review comments only)")
-	listCmd.Flags().StringVar(&listOutdated, "outdated", "", "Filter by outdated status (true/false, review comments only)")
-	listCmd.Flags().StringVar(&listResolved, "resolved", "", "Filter by resolved status (true/false, review comments only)")
-	listCmd.Flags().BoolVar(&listAll, "all", false, "Show all comments including resolved")
+	listCmd.Flags().StringVar(&listFormat, "format", "table", "Output format (table/json/agent/problems)")
+	listCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"table	Aligned columns", "json	Same as --json", "agent	Compact digest for LLM prompts", "problems	path:line: lines for editor problem matchers"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	listCmd.Flags().Int64Var(&listFilter.ReviewID, "review-id", 0, "Filter by review ID (review comments only)")
+	listCmd.Flags().StringVar(&listFilter.Outdated, "outdated", "", "Filter by outdated status (true/false, review comments only)")
+	listCmd.Flags().StringVar(&listFilter.Resolved, "resolved", "", "Filter by resolved status (true/false, review comments only)")
+	listCmd.Flags().BoolVar(&listFilter.All, "all", false, "Show all comments including resolved")
+	listCmd.Flags().StringVar(&listFilter.Author, "author", "", "Filter by comment author")
+	listCmd.Flags().StringVar(&listFilter.File, "file", "", "Filter by file path, directory, or glob (review comments only)")
+	listCmd.RegisterFlagCompletionFunc("file", completeCommentedFiles)
 	listCmd.Flags().StringVar(&listCommentType, "type", "", "Filter by comment type (review_comment/issue_comment)")
+	listCmd.Flags().BoolVar(&listAllPRs, "all-prs", false, "Aggregate comments across every pull request in the current repo")
+	listCmd.Flags().StringVar(&listState, "state", "open", "PR state used with --all-prs (open/closed/all)")
+	listCmd.Flags().BoolVar(&listBlame, "blame", false, "Annotate review comments with the local commit that last touched the line")
+	listCmd.Flags().BoolVar(&listOwners, "owners", false, "Show CODEOWNERS owners of each commented file")
+	listCmd.Flags().StringVar(&listOwnedBy, "owned-by", "", "Filter by CODEOWNERS owner (e.g., @org/team)")
+	listCmd.Flags().StringVar(&listFilter.Subject, "subject", "", "Filter by comment subject (file/line, review comments only)")
+	listCmd.Flags().StringVar(&listMinSeverity, "min-severity", "", "Only show bot findings at or above this severity (info/trivial/minor/major/critical)")
 
 	listCmd.RegisterFlagCompletionFunc("review-id", completeReviewIDs)
+	listCmd.RegisterFlagCompletionFunc("author", completeAuthors)
 	listCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return []string{"review_comment\tInline code comments", "issue_comment\tGeneral PR comments"}, cobra.ShellCompDirectiveNoFileComp
 	})
 	listCmd.RegisterFlagCompletionFunc("outdated", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return []string{"true\tShow only outdated comments", "false\tShow only non-outdated comments"}, cobra.ShellCompDirectiveNoFileComp
 	})
+	listCmd.RegisterFlagCompletionFunc("state", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"open\tOpen pull requests", "closed\tClosed pull requests", "all\tAll pull requests"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	listCmd.RegisterFlagCompletionFunc("subject", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"file\tFile-level comments", "line\tComments on specific lines"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	listCmd.Flags().StringVar(&listTag, "tag", "", "Filter by local triage label set with 'tag' (will-fix/wont-fix/question)")
+	listCmd.RegisterFlagCompletionFunc("tag", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return tagLabels, cobra.ShellCompDirectiveNoFileComp
+	})
+	listCmd.RegisterFlagCompletionFunc("min-severity", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"info", "trivial", "minor", "major", "critical"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	listCmd.Flags().StringVar(&listReviewState, "review-state", "", "Filter by the state of the review a thread was opened in (APPROVED/CHANGES_REQUESTED/COMMENTED/DISMISSED)")
+	listCmd.Flags().StringVar(&listFilter.Marker, "marker", "", "Filter by bot marker (text of the hidden <!-- --> comment, substring match)")
+	listCmd.Flags().BoolVar(&listLatest, "latest-per-marker", false, "Show only the newest general comment per bot marker, dropping reposted copies")
+	listCmd.Flags().StringVar(&listFilter.Hidden, "hidden", "", "Filter by hidden (minimized) status (true/false)")
+	listCmd.RegisterFlagCompletionFunc("hidden", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"true\tShow only hidden comments", "false\tShow only visible comments"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	listCmd.Flags().BoolVar(&listUnanswered, "unanswered", false, "Only show threads the PR author has never replied to")
+	listCmd.Flags().BoolVar(&listSinceLastPush, "since-last-push", false, "Only show comments posted after the latest commit or force push")
+	listCmd.Flags().BoolVar(&listNoEmoji, "no-emoji", false, "Show :shortcode: emoji as written in the table")
+	listCmd.Flags().BoolVar(&listProblems, "problems", false, "Print path:line: lines for editor problem matchers (same as --format problems)")
+	listCmd.Flags().BoolVar(&listCommits, "commits", false, "Show each comment's commit and whether its file changed in later commits")
+	listCmd.Flags().StringVar(&listFilter.Commit, "commit", "", "Filter by the commit review comments were made on (SHA or local revision)")
+	listCmd.RegisterFlagCompletionFunc("review-state", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return reviewStateCompletions, cobra.ShellCompDirectiveNoFileComp
+	})
 	listCmd.RegisterFlagCompletionFunc("resolved", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return []string{"true\tShow only resolved comments", "false\tShow only unresolved comments"}, cobra.ShellCompDirectiveNoFileComp
 	})
 }
 
+var reviewStateCompletions = []string{
+	"APPROVED\tApproving reviews",
+	"CHANGES_REQUESTED\tReviews requesting changes",
+	"COMMENTED\tComment-only reviews",
+	"DISMISSED\tDismissed reviews",
+}
+
 type unifiedComment struct {
-	Type       string `json:"type"`
-	ID         int64  `json:"id"`
-	Author     string `json:"author"`
-	Body       string `json:"body"`
-	CreatedAt  string `json:"created_at"`
-	File       string `json:"file,omitempty"`
-	Line       string `json:"line,omitempty"`
-	Outdated   string `json:"outdated,omitempty"`
-	Resolved   string `json:"resolved,omitempty"`
-	ReviewID   int64  `json:"review_id,omitempty"`
+	PR           int               `json:"pr,omitempty"`
+	Type         string            `json:"type"`
+	ID           int64             `json:"id"`
+	Author       string            `json:"author"`
+	Body         string            `json:"body"`
+	CreatedAt    string            `json:"created_at"`
+	File         string            `json:"file,omitempty"`
+	Line         string            `json:"line,omitempty"`
+	StartLine    int               `json:"start_line,omitempty"`
+	EndLine      int               `json:"end_line,omitempty"`
+	Subject      string            `json:"subject_type,omitempty"`
+	Severity     severity.Level    `json:"severity,omitempty"`
+	Category     string            `json:"category,omitempty"`
+	Marker       string            `json:"marker,omitempty"`
+	Tag          string            `json:"tag,omitempty"`
+	Outdated     string            `json:"outdated,omitempty"`
+	Resolved     string            `json:"resolved,omitempty"`
+	Hidden       bool              `json:"hidden"`
+	HiddenReason string            `json:"hidden_reason,omitempty"`
+	ReviewID     int64             `json:"review_id,omitempty"`
+	InReplyTo    int64             `json:"in_reply_to_id,omitempty"`
+	ReplyCount   int               `json:"replies_count"`
+	CommitID     string            `json:"original_commit_id,omitempty"`
+	ChangedIn    []string          `json:"file_changed_in,omitempty"`
+	Blame        *github.BlameInfo `json:"blame,omitempty"`
+	Owners       []string          `json:"owners,omitempty"`
+	Replies      []unifiedComment  `json:"replies,omitempty"`
+
+	// currentLine is the line at the PR head, for --format problems.
+	currentLine int
 }
 
 func runList(cmd *cobra.Command, args []string) error {
+	if listProblems {
+		listFormat = "problems"
+	}
+	switch listFormat {
+	case "table", "agent", "problems":
+	case "json":
+		listJsonOutput = true
+	default:
+		return fmt.Errorf("invalid --format value: %s (valid: table, json, agent, problems)", listFormat)
+	}
+	if listMinSeverity != "" {
+		level, err := severity.ParseLevel(listMinSeverity)
+		if err != nil {
+			return err
+		}
+		listFilter.MinSeverity = level
+	}
+	listReviewState = strings.ToUpper(listReviewState)
+	switch listReviewState {
+	case "", "APPROVED", "CHANGES_REQUESTED", "COMMENTED", "DISMISSED":
+	default:
+		return fmt.Errorf("invalid --review-state value: %s (valid: APPROVED, CHANGES_REQUESTED, COMMENTED, DISMISSED)", listReviewState)
+	}
+	switch listState {
+	case "open", "closed", "all":
+	default:
+		return fmt.Errorf("invalid --state value: %s (valid: open, closed, all)", listState)
+	}
+	if listFilter.Commit != "" {
+		sha, err := commitFilterSHA(listFilter.Commit)
+		if err != nil {
+			return err
+		}
+		listFilter.Commit = sha
+	}
+
 	client, err := github.NewClient()
 	if err != nil {
 		return err
 	}
 
-	prRef, err := client.ResolvePRReference(args)
+	if listAllPRs {
+		return runListAllPRs(client, args)
+	}
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
+	if err != nil {
+		return err
+	}
+
+	allComments, err := collectComments(client, prRef)
+	if err != nil {
+		return err
+	}
+
+	if listJsonOutput {
+		return writeJSON(allComments)
+	}
+
+	if listFormat == "agent" {
+		writeAgentDigest(os.Stdout, fmt.Sprintf("pr %s/%s#%d", prRef.Owner, prRef.Repo, prRef.Number), allComments)
+		return nil
+	}
+
+	if listFormat == "problems" {
+		writeProblems(os.Stdout, allComments)
+		return nil
+	}
+
+	if len(allComments) == 0 {
+		fmt.Println("No comments found.")
+		return nil
+	}
+
+	return printCommentTable(allComments, false)
+}
+
+func runListAllPRs(client *github.Client, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("--all-prs cannot be combined with a PR reference")
+	}
+
+	owner, repo, err := client.GetCurrentRepo()
+	if err != nil {
+		return err
+	}
+
+	prs, err := client.ListPullRequests(owner, repo, listState)
 	if err != nil {
 		return err
 	}
 
 	var allComments []unifiedComment
+	for _, pr := range prs {
+		prRef := &github.PRReference{Owner: owner, Repo: repo, Number: pr.Number}
+		comments, err := collectComments(client, prRef)
+		if err != nil {
+			return err
+		}
+		for i := range comments {
+			comments[i].PR = pr.Number
+		}
+		allComments = append(allComments, comments...)
+	}
+
+	if listJsonOutput {
+		return writeJSON(allComments)
+	}
+
+	if listFormat == "agent" {
+		writeAgentDigest(os.Stdout, fmt.Sprintf("repo %s/%s", owner, repo), allComments)
+		return nil
+	}
+
+	if listFormat == "problems" {
+		writeProblems(os.Stdout, allComments)
+		return nil
+	}
+
+	if len(allComments) == 0 {
+		fmt.Printf("No comments found across %d %s pull request(s).\n", len(prs), listState)
+		return nil
+	}
+
+	return printCommentTable(allComments, true)
+}
+
+func printCommentTable(comments []unifiedComment, withPR bool) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	header := []string{"TYPE", "ID", "FILE", "LINE", "OUTDATED", "RESOLVED", "HIDDEN", "REPLIES", "AUTHOR"}
+	if withPR {
+		header = append([]string{"PR"}, header...)
+	}
+	showSeverity, showTag := false, listTag != ""
+	for _, c := range comments {
+		showSeverity = showSeverity || c.Severity != severity.Unknown
+		showTag = showTag || c.Tag != ""
+	}
+	if showSeverity {
+		header = append(header, "SEVERITY")
+	}
+	if showTag {
+		header = append(header, "TAG")
+	}
+	if listOwners || listOwnedBy != "" {
+		header = append(header, "OWNER")
+	}
+	if listBlame {
+		header = append(header, "BLAME")
+	}
+	if listCommits {
+		header = append(header, "COMMIT", "CHANGED")
+	}
+	header = append(header, "BODY")
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	var rows []unifiedComment
+	for _, c := range comments {
+		rows = append(rows, c)
+		for _, reply := range c.Replies {
+			reply.Type = "  reply"
+			reply.PR = c.PR
+			rows = append(rows, reply)
+		}
+	}
+
+	for _, c := range rows {
+		line := c.Line
+		if c.Subject == "file" {
+			line = "(file)"
+		} else if c.StartLine != c.EndLine {
+			line = fmt.Sprintf("%d-%d", c.StartLine, c.EndLine)
+		}
+		replies := ""
+		if c.Type == "review_comment" {
+			replies = fmt.Sprintf("%d", c.ReplyCount)
+		}
+		hidden := "false"
+		if c.Hidden {
+			hidden = "true"
+			if c.HiddenReason != "" {
+				hidden = c.HiddenReason
+			}
+		}
+		row := []string{c.Type, fmt.Sprintf("%d", c.ID), c.File, line, c.Outdated, c.Resolved, hidden, replies, c.Author}
+		if withPR {
+			row = append([]string{fmt.Sprintf("#%d", c.PR)}, row...)
+		}
+		if showSeverity {
+			row = append(row, c.Severity.String())
+		}
+		if showTag {
+			row = append(row, c.Tag)
+		}
+		if listOwners || listOwnedBy != "" {
+			row = append(row, strings.Join(c.Owners, " "))
+		}
+		if listBlame {
+			blame := ""
+			if c.Blame != nil {
+				blame = fmt.Sprintf("%s (%s)", c.Blame.ShortSHA(), c.Blame.Author)
+			}
+			row = append(row, blame)
+		}
+		if listCommits {
+			commit, changed := "", ""
+			if c.Type != "issue_comment" {
+				commit = shortCommit(c.CommitID)
+				changed = "no"
+				if len(c.ChangedIn) > 0 {
+					changed = fmt.Sprintf("yes (%d)", len(c.ChangedIn))
+				}
+			}
+			row = append(row, commit, changed)
+		}
+		row = append(row, github.TruncateString(bodyText(c.Body, listNoEmoji), 40))
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+func collectComments(client *github.Client, prRef *github.PRReference) ([]unifiedComment, error) {
+	var allComments []unifiedComment
+
+	tags, err := loadTags(prRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if listSinceLastPush {
+		events, err := client.GetTimeline(prRef.Owner, prRef.Repo, prRef.Number)
+		if err != nil {
+			return nil, err
+		}
+		listFilter.Since = lastPushTime(events)
+	}
 
 	if listCommentType == "" || listCommentType == "review_comment" {
 		reviewComments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		var rules codeowners.Ruleset
+		if listOwners || listOwnedBy != "" {
+			rules = loadCodeowners(client, prRef.Owner, prRef.Repo)
+		}
+		threadStates, err := threadReviewStates(client, prRef, reviewComments)
+		if err != nil {
+			return nil, err
 		}
-		filtered := filterReviewComments(reviewComments)
+		unanswered, err := unansweredThreads(client, prRef, reviewComments)
+		if err != nil {
+			return nil, err
+		}
+		changedIn, err := fileChangesAfter(client, prRef, reviewComments)
+		if err != nil {
+			return nil, err
+		}
+		replyCounts := make(map[int64]int)
+		for _, c := range reviewComments {
+			if c.InReplyToID != 0 {
+				replyCounts[c.InReplyToID]++
+			}
+		}
+		filtered := listFilter.filterReviewComments(reviewComments)
 		for _, c := range filtered {
+			if listTag != "" && tags[c.ID] != listTag {
+				continue
+			}
+			if listReviewState != "" && threadStates[c.ID] != listReviewState {
+				continue
+			}
+			if listUnanswered && !unanswered[c.ID] {
+				continue
+			}
+			owners := rules.Owners(c.Path)
+			if listOwnedBy != "" && !containsFold(owners, listOwnedBy) {
+				continue
+			}
 			line := ""
-			if c.OriginalLine != nil {
+			var startLine, endLine int
+			subject := "line"
+			if c.IsFileLevel() {
+				subject = "file"
+			} else if c.OriginalLine != nil {
 				line = fmt.Sprintf("%d", *c.OriginalLine)
+				startLine, endLine = *c.OriginalLine, *c.OriginalLine
+				if c.OriginalStartLine != nil {
+					startLine = *c.OriginalStartLine
+				}
 			}
 			outdated := "false"
 			if c.IsOutdated() {
@@ -115,91 +542,280 @@ func runList(cmd *cobra.Command, args []string) error {
 			if c.IsResolved {
 				resolved = "true"
 			}
+			class := severity.Classify(c.User.Login, c.Body)
+			var blame *github.BlameInfo
+			if listBlame && c.CurrentLine() > 0 {
+				blame, _ = github.BlameLine(c.Path, c.CurrentLine())
+			}
 			allComments = append(allComments, unifiedComment{
-				Type:      "review_comment",
-				ID:        c.ID,
-				Author:    c.User.Login,
-				Body:      c.Body,
-				CreatedAt: c.CreatedAt.Format("2006-01-02 15:04"),
-				File:      c.Path,
-				Line:      line,
-				Outdated:  outdated,
-				Resolved:  resolved,
-				ReviewID:  c.PullRequestReviewID,
+				Type:         "review_comment",
+				ID:           c.ID,
+				Author:       c.User.Login,
+				Body:         c.Body,
+				CreatedAt:    c.CreatedAt.Format("2006-01-02 15:04"),
+				File:         c.Path,
+				Line:         line,
+				StartLine:    startLine,
+				EndLine:      endLine,
+				Subject:      subject,
+				Severity:     class.Severity,
+				Category:     class.Category,
+				Marker:       marker.Of(c.Body),
+				Tag:          tags[c.ID],
+				Outdated:     outdated,
+				Resolved:     resolved,
+				Hidden:       c.IsMinimized,
+				HiddenReason: c.MinimizedReason,
+				ReviewID:     c.PullRequestReviewID,
+				InReplyTo:    c.InReplyToID,
+				ReplyCount:   replyCounts[c.ID],
+				CommitID:     c.OriginalCommitID,
+				ChangedIn:    changedIn[c.ID],
+				Blame:        blame,
+				Owners:       owners,
+				currentLine:  c.CurrentLine(),
 			})
 		}
 	}
 
-	if listCommentType == "" || listCommentType == "issue_comment" {
+	allComments = nestUnifiedReplies(allComments)
+
+	if (listCommentType == "" || listCommentType == "issue_comment") && listOwnedBy == "" && listReviewState == "" && !listUnanswered {
 		issueComments, err := client.GetIssueComments(prRef.Owner, prRef.Repo, prRef.Number)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		if listLatest {
+			issueComments = latestPerMarker(issueComments)
 		}
 		for _, c := range issueComments {
+			if !listFilter.matchIssueComment(c) {
+				continue
+			}
+			if listTag != "" && tags[c.ID] != listTag {
+				continue
+			}
+			class := severity.Classify(c.User.Login, c.Body)
 			allComments = append(allComments, unifiedComment{
-				Type:      "issue_comment",
-				ID:        c.ID,
-				Author:    c.User.Login,
-				Body:      c.Body,
-				CreatedAt: c.CreatedAt.Format("2006-01-02 15:04"),
+				Type:         "issue_comment",
+				ID:           c.ID,
+				Author:       c.User.Login,
+				Body:         c.Body,
+				CreatedAt:    c.CreatedAt.Format("2006-01-02 15:04"),
+				Severity:     class.Severity,
+				Category:     class.Category,
+				Marker:       marker.Of(c.Body),
+				Tag:          tags[c.ID],
+				Hidden:       c.IsMinimized,
+				HiddenReason: c.MinimizedReason,
 			})
 		}
 	}
 
-	if listJsonOutput {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(allComments)
+	return allComments, nil
+}
+
+// threadReviewStates maps each review comment to the state of the review its
+// thread was opened in. It returns nil unless --review-state is set.
+func threadReviewStates(client *github.Client, prRef *github.PRReference, comments []github.ReviewComment) (map[int64]string, error) {
+	if listReviewState == "" {
+		return nil, nil
+	}
+	reviews, err := client.GetReviews(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+	reviewState := make(map[int64]string)
+	for _, r := range reviews {
+		reviewState[r.ID] = r.State
 	}
+	rootReview := make(map[int64]int64)
+	for _, c := range comments {
+		if c.InReplyToID == 0 {
+			rootReview[c.ID] = c.PullRequestReviewID
+		}
+	}
+	states := make(map[int64]string)
+	for _, c := range comments {
+		reviewID := c.PullRequestReviewID
+		if root, ok := rootReview[c.InReplyToID]; ok {
+			reviewID = root
+		}
+		states[c.ID] = reviewState[reviewID]
+	}
+	return states, nil
+}
 
-	if len(allComments) == 0 {
-		fmt.Println("No comments found.")
-		return nil
+// unansweredThreads reports, for each review comment, whether its thread was
+// started by someone other than the PR author and has no reply from the
+// author. It returns nil unless --unanswered is set.
+func unansweredThreads(client *github.Client, prRef *github.PRReference, comments []github.ReviewComment) (map[int64]bool, error) {
+	if !listUnanswered {
+		return nil, nil
 	}
+	pr, err := client.GetPullRequest(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+	author := pr.User.Login
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "TYPE\tID\tFILE\tLINE\tOUTDATED\tRESOLVED\tAUTHOR\tBODY")
-	for _, c := range allComments {
-		body := github.TruncateString(c.Body, 40)
-		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
-			c.Type, c.ID, c.File, c.Line, c.Outdated, c.Resolved, c.Author, body)
+	answered := make(map[int64]bool)
+	for _, c := range comments {
+		root := c.InReplyToID
+		if root == 0 {
+			root = c.ID
+		}
+		if strings.EqualFold(c.User.Login, author) {
+			answered[root] = true
+		}
 	}
-	return w.Flush()
-}
 
-func filterReviewComments(comments []github.ReviewComment) []github.ReviewComment {
-	var result []github.ReviewComment
+	result := make(map[int64]bool)
 	for _, c := range comments {
-		if listReviewID != 0 && c.PullRequestReviewID != listReviewID {
-			continue
+		root := c.InReplyToID
+		if root == 0 {
+			root = c.ID
 		}
+		result[c.ID] = !answered[root]
+	}
+	return result, nil
+}
 
-		if listOutdated != "" {
-			isOutdated := c.IsOutdated()
-			if listOutdated == "true" && !isOutdated {
-				continue
-			}
-			if listOutdated == "false" && isOutdated {
-				continue
+// fileChangesAfter maps each review comment to the PR commits after it that
+// changed the commented file. It returns nil unless --commits is set.
+func fileChangesAfter(client *github.Client, prRef *github.PRReference, comments []github.ReviewComment) (map[int64][]string, error) {
+	if !listCommits || len(comments) == 0 {
+		return nil, nil
+	}
+	commits, err := client.GetPRCommits(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+	position := make(map[string]int)
+	for i, pc := range commits {
+		position[pc.SHA] = i
+	}
+	// firstAfter is the index of the first commit that came after c.
+	firstAfter := func(c github.ReviewComment) int {
+		if i, ok := position[c.OriginalCommitID]; ok {
+			return i + 1
+		}
+		for i, pc := range commits {
+			if pc.Commit.Committer.Date.After(c.CreatedAt) {
+				return i
 			}
 		}
+		return len(commits)
+	}
 
-		if !listAll {
-			if listResolved != "" {
-				if listResolved == "true" && !c.IsResolved {
-					continue
-				}
-				if listResolved == "false" && c.IsResolved {
-					continue
-				}
-			} else {
-				if c.IsResolved {
-					continue
-				}
+	start := len(commits)
+	for _, c := range comments {
+		start = min(start, firstAfter(c))
+	}
+	files := make([]map[string]bool, len(commits))
+	for i := start; i < len(commits); i++ {
+		detail, err := client.GetCommit(prRef.Owner, prRef.Repo, commits[i].SHA)
+		if err != nil {
+			return nil, err
+		}
+		files[i] = make(map[string]bool)
+		for _, f := range detail.Files {
+			files[i][f.Filename] = true
+		}
+	}
+
+	result := make(map[int64][]string)
+	for _, c := range comments {
+		for i := firstAfter(c); i < len(commits); i++ {
+			if files[i][c.Path] {
+				result[c.ID] = append(result[c.ID], commits[i].SHA)
 			}
 		}
+	}
+	return result, nil
+}
+
+// abbreviatedSHAPattern matches full and abbreviated commit SHAs.
+var abbreviatedSHAPattern = regexp.MustCompile(`^[0-9a-fA-F]{4,40}$`)
+
+// commitFilterSHA normalizes a --commit value: an abbreviated SHA is kept as
+// a prefix, and anything else is resolved as a local git revision.
+func commitFilterSHA(rev string) (string, error) {
+	if abbreviatedSHAPattern.MatchString(rev) {
+		return strings.ToLower(rev), nil
+	}
+	sha, err := github.RevParse(rev)
+	if err != nil {
+		return "", fmt.Errorf("invalid --commit value: %s (not a SHA or a local revision)", rev)
+	}
+	return sha, nil
+}
+
+func shortCommit(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// nestUnifiedReplies moves replies into their root comment's Replies. Replies
+// whose root was filtered out stay at the top level.
+func nestUnifiedReplies(comments []unifiedComment) []unifiedComment {
+	present := make(map[int64]bool)
+	for _, c := range comments {
+		if c.InReplyTo == 0 {
+			present[c.ID] = true
+		}
+	}
 
-		result = append(result, c)
+	repliesByRoot := make(map[int64][]unifiedComment)
+	var roots []unifiedComment
+	for _, c := range comments {
+		if c.InReplyTo != 0 && present[c.InReplyTo] {
+			repliesByRoot[c.InReplyTo] = append(repliesByRoot[c.InReplyTo], c)
+			continue
+		}
+		roots = append(roots, c)
+	}
+
+	for i := range roots {
+		roots[i].Replies = repliesByRoot[roots[i].ID]
+	}
+	return roots
+}
+
+// codeownersByRepo holds the CODEOWNERS rules already loaded for each
+// owner/repo, so --all-prs fetches them once per repository.
+var codeownersByRepo = make(map[string]codeowners.Ruleset)
+
+// loadCodeowners returns the CODEOWNERS rules of a repository, or none if it
+// has no CODEOWNERS file. Failing to read one only warns.
+func loadCodeowners(client *github.Client, owner, repo string) codeowners.Ruleset {
+	key := owner + "/" + repo
+	if rules, ok := codeownersByRepo[key]; ok {
+		return rules
+	}
+	var rules codeowners.Ruleset
+	for _, path := range codeowners.Locations {
+		data, err := client.GetFileContent(owner, repo, path, "")
+		if err == nil {
+			rules = codeowners.Parse(string(data))
+			break
+		}
+		if !github.IsNotFound(err) {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			break
+		}
+	}
+	codeownersByRepo[key] = rules
+	return rules
+}
+
+func containsFold(values []string, target string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, target) {
+			return true
+		}
 	}
-	return result
+	return false
 }
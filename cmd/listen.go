@@ -0,0 +1,363 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/journal"
+	"github.com/kballard/go-shellquote"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listenSecret string
+	listenHost   string
+	listenPort   int
+	listenOn     []string
+	listenDryRun bool
+)
+
+var listenCmd = &cobra.Command{
+	Use:   "listen",
+	Short: "Run actions when GitHub sends review webhooks",
+	Long: `Receive GitHub webhooks and run configured actions for new review comments,
+reviews, and PR conversation comments, turning the extension into a small
+review automation daemon.
+
+Each --on takes event=action and can be repeated; every matching action
+runs, in order. Events:
+
+  review_comment   a review comment was created
+  review           a review was submitted
+  issue_comment    a comment was created in a PR's conversation
+
+Actions:
+
+  hide-bots[:reason]  hide the comment if a bot wrote it (default reason: resolved)
+  ack[:reaction]      react to the comment (default: eyes)
+  notify              show a desktop notification, or print one
+  exec:<command>      run a command with the webhook payload on stdin
+
+Commands started by exec get GH_PR_COMMENTS_EVENT, GH_PR_COMMENTS_REPO,
+GH_PR_COMMENTS_PR, GH_PR_COMMENTS_COMMENT_ID, and GH_PR_COMMENTS_AUTHOR.
+
+Deliveries must be signed with --secret (or GH_PR_COMMENTS_WEBHOOK_SECRET),
+the webhook's secret; unsigned or mis-signed requests are rejected. The
+server listens on 127.0.0.1 by default; 'gh webhook forward' can deliver a
+repository's webhooks there without exposing it.
+
+Examples:
+  gh pr-comments listen --secret "$SECRET" --on review_comment=hide-bots
+  gh pr-comments listen --secret "$SECRET" --on review_comment=ack --on review=notify
+  gh pr-comments listen --secret "$SECRET" --on issue_comment='exec:jq .comment.body'
+  gh webhook forward --repo owner/repo --events pull_request_review_comment \
+    --url http://localhost:8080/ --secret "$SECRET"`,
+	Args: cobra.NoArgs,
+	RunE: runListen,
+}
+
+func init() {
+	listenCmd.Flags().StringVar(&listenSecret, "secret", "", "Webhook secret used to verify deliveries (default $GH_PR_COMMENTS_WEBHOOK_SECRET)")
+	listenCmd.Flags().StringVar(&listenHost, "host", "127.0.0.1", "Address to listen on")
+	listenCmd.Flags().IntVar(&listenPort, "port", 8080, "Port to listen on")
+	listenCmd.Flags().StringArrayVar(&listenOn, "on", nil, "event=action to run (repeatable)")
+	listenCmd.Flags().BoolVar(&listenDryRun, "dry-run", false, "Log the actions that would run without running them")
+	rootCmd.AddCommand(listenCmd)
+}
+
+// webhookEvents maps the event names accepted by --on to the GitHub event
+// and action that trigger them.
+var webhookEvents = map[string]struct{ event, action string }{
+	"review_comment": {"pull_request_review_comment", "created"},
+	"review":         {"pull_request_review", "submitted"},
+	"issue_comment":  {"issue_comment", "created"},
+}
+
+// listenRule is one parsed --on value.
+type listenRule struct {
+	event  string
+	action string
+	arg    string
+}
+
+// webhookPayload holds the fields of review and comment deliveries that the
+// actions need.
+type webhookPayload struct {
+	Action     string `json:"action"`
+	Repository struct {
+		Name  string      `json:"name"`
+		Owner github.User `json:"owner"`
+	} `json:"repository"`
+	PullRequest *struct {
+		Number int `json:"number"`
+	} `json:"pull_request"`
+	Issue *struct {
+		Number      int              `json:"number"`
+		PullRequest *json.RawMessage `json:"pull_request"`
+	} `json:"issue"`
+	Comment *struct {
+		ID      int64       `json:"id"`
+		NodeID  string      `json:"node_id"`
+		Body    string      `json:"body"`
+		User    github.User `json:"user"`
+		HTMLURL string      `json:"html_url"`
+	} `json:"comment"`
+	Review *struct {
+		ID      int64       `json:"id"`
+		State   string      `json:"state"`
+		Body    string      `json:"body"`
+		User    github.User `json:"user"`
+		HTMLURL string      `json:"html_url"`
+	} `json:"review"`
+}
+
+// webhookDelivery is a verified delivery queued for processing.
+type webhookDelivery struct {
+	event   string
+	payload webhookPayload
+	raw     []byte
+}
+
+func parseListenRules(values []string) ([]listenRule, error) {
+	var rules []listenRule
+	for _, v := range values {
+		event, action, ok := strings.Cut(v, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --on value: %s (expected event=action)", v)
+		}
+		if _, ok := webhookEvents[event]; !ok {
+			return nil, fmt.Errorf("invalid --on event: %s (valid: review_comment, review, issue_comment)", event)
+		}
+		name, arg, _ := strings.Cut(action, ":")
+		switch name {
+		case "hide-bots":
+			if arg == "" {
+				arg = "resolved"
+			}
+			if _, err := github.ParseClassifier(arg); err != nil {
+				return nil, err
+			}
+		case "ack":
+			if arg == "" {
+				arg = "eyes"
+			}
+			reaction, err := github.ParseReaction(arg)
+			if err != nil {
+				return nil, err
+			}
+			arg = reaction
+		case "notify":
+		case "exec":
+			if strings.TrimSpace(arg) == "" {
+				return nil, fmt.Errorf("invalid --on action: %s (exec needs a command)", action)
+			}
+		default:
+			return nil, fmt.Errorf("invalid --on action: %s (valid: hide-bots, ack, notify, exec:<command>)", action)
+		}
+		if event == "review" && (name == "hide-bots" || name == "ack") {
+			return nil, fmt.Errorf("%s is not supported for review events", name)
+		}
+		rules = append(rules, listenRule{event: event, action: name, arg: arg})
+	}
+	return rules, nil
+}
+
+func runListen(cmd *cobra.Command, args []string) error {
+	if listenSecret == "" {
+		listenSecret = os.Getenv("GH_PR_COMMENTS_WEBHOOK_SECRET")
+	}
+	if listenSecret == "" {
+		return fmt.Errorf("a webhook secret is required (--secret or GH_PR_COMMENTS_WEBHOOK_SECRET)")
+	}
+	if len(listenOn) == 0 {
+		return fmt.Errorf("at least one --on event=action is required")
+	}
+	rules, err := parseListenRules(listenOn)
+	if err != nil {
+		return err
+	}
+
+	if err := requireLiveData("listen"); err != nil {
+		return err
+	}
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	// Deliveries are acknowledged immediately and handled one at a time, so
+	// GitHub never waits on an action and actions never race each other.
+	queue := make(chan webhookDelivery, 64)
+	go func() {
+		for d := range queue {
+			handleDelivery(client, rules, d)
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 25<<20))
+		if err != nil {
+			http.Error(w, "cannot read body", http.StatusBadRequest)
+			return
+		}
+		if !validWebhookSignature(listenSecret, body, r.Header.Get("X-Hub-Signature-256")) {
+			fmt.Fprintf(os.Stderr, "Rejected delivery with a missing or invalid signature from %s\n", r.RemoteAddr)
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		d := webhookDelivery{event: r.Header.Get("X-GitHub-Event"), raw: body}
+		if err := json.Unmarshal(body, &d.payload); err != nil {
+			http.Error(w, "invalid payload", http.StatusBadRequest)
+			return
+		}
+		select {
+		case queue <- d:
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			http.Error(w, "too many pending deliveries", http.StatusServiceUnavailable)
+		}
+	})
+
+	addr := net.JoinHostPort(listenHost, strconv.Itoa(listenPort))
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Listening for webhooks on http://%s (Ctrl-C to stop)\n", ln.Addr())
+	cmd.SilenceUsage = true
+	return http.Serve(ln, mux)
+}
+
+// validWebhookSignature checks GitHub's X-Hub-Signature-256 header, an HMAC
+// of the body keyed with the webhook secret.
+func validWebhookSignature(secret string, body []byte, header string) bool {
+	sig, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+func handleDelivery(client *github.Client, rules []listenRule, d webhookDelivery) {
+	p := d.payload
+	owner, repo := p.Repository.Owner.Login, p.Repository.Name
+
+	var number int
+	var kind, author, body string
+	var commentID int64
+	var nodeID string
+	switch {
+	case p.Comment != nil && p.PullRequest != nil:
+		number, kind = p.PullRequest.Number, "review_comment"
+		commentID, nodeID, author, body = p.Comment.ID, p.Comment.NodeID, p.Comment.User.Login, p.Comment.Body
+	case p.Comment != nil && p.Issue != nil && p.Issue.PullRequest != nil:
+		number, kind = p.Issue.Number, "issue_comment"
+		commentID, nodeID, author, body = p.Comment.ID, p.Comment.NodeID, p.Comment.User.Login, p.Comment.Body
+	case p.Review != nil && p.PullRequest != nil:
+		number, kind = p.PullRequest.Number, "review"
+		commentID, author, body = p.Review.ID, p.Review.User.Login, p.Review.Body
+	default:
+		return
+	}
+
+	for _, rule := range rules {
+		trigger := webhookEvents[rule.event]
+		if rule.event != kind || trigger.event != d.event || trigger.action != p.Action {
+			continue
+		}
+		desc := fmt.Sprintf("%s/%s#%d %s %d by %s: %s", owner, repo, number, kind, commentID, author, rule.action)
+		if listenDryRun {
+			fmt.Printf("[dry-run] %s\n", desc)
+			continue
+		}
+
+		var err error
+		switch rule.action {
+		case "hide-bots":
+			if !p.Comment.User.IsBot() {
+				continue
+			}
+			classifier, _ := github.ParseClassifier(rule.arg)
+			err = client.MinimizeComment(nodeID, classifier)
+		case "ack":
+			err = client.AddReaction(owner, repo, kind, commentID, rule.arg)
+		case "notify":
+			title := fmt.Sprintf("%s/%s#%d: new %s from %s", owner, repo, number, strings.ReplaceAll(kind, "_", " "), author)
+			err = notify(title, github.TruncateString(body, 200))
+		case "exec":
+			err = runWebhookCommand(rule.arg, d, owner+"/"+repo, number, commentID, author)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed: %s: %v\n", desc, err)
+			continue
+		}
+		prRef := &github.PRReference{Owner: owner, Repo: repo, Number: number}
+		switch rule.action {
+		case "hide-bots":
+			classifier, _ := github.ParseClassifier(rule.arg)
+			journalAction(prRef, journal.Entry{Action: "hide", NodeID: nodeID, Reason: string(classifier)})
+		case "ack":
+			journalAction(prRef, journal.Entry{Action: "react", CommentID: commentID, Reason: rule.arg})
+		}
+		fmt.Printf("Done: %s\n", desc)
+	}
+}
+
+// notify shows a desktop notification when the platform has a tool for it
+// and prints the message otherwise.
+func notify(title, message string) error {
+	var c *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", strconv.Quote(message), strconv.Quote(title))
+		c = exec.Command("osascript", "-e", script)
+	default:
+		if _, err := exec.LookPath("notify-send"); err == nil {
+			c = exec.Command("notify-send", title, message)
+		}
+	}
+	if c != nil && c.Run() == nil {
+		return nil
+	}
+	fmt.Printf("%s\n  %s\n", title, strings.ReplaceAll(message, "\n", "\n  "))
+	return nil
+}
+
+func runWebhookCommand(cmdLine string, d webhookDelivery, repo string, number int, commentID int64, author string) error {
+	parts, err := shellquote.Split(cmdLine)
+	if err != nil {
+		return fmt.Errorf("parse command: %w", err)
+	}
+	c := exec.Command(parts[0], parts[1:]...)
+	c.Stdin = bytes.NewReader(d.raw)
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	c.Env = append(os.Environ(),
+		"GH_PR_COMMENTS_EVENT="+d.event,
+		"GH_PR_COMMENTS_REPO="+repo,
+		"GH_PR_COMMENTS_PR="+strconv.Itoa(number),
+		"GH_PR_COMMENTS_COMMENT_ID="+strconv.FormatInt(commentID, 10),
+		"GH_PR_COMMENTS_AUTHOR="+author,
+	)
+	return c.Run()
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"github.com/STRRL/gh-pr-comments/internal/github"
+)
+
+// prItem is any commentable item on a PR, located by its numeric ID.
+type prItem struct {
+	Type          string                `json:"type"`
+	ReviewComment *github.ReviewComment `json:"review_comment,omitempty"`
+	Review        *github.Review        `json:"review,omitempty"`
+	IssueComment  *github.IssueComment  `json:"issue_comment,omitempty"`
+}
+
+func (it *prItem) HTMLURL() string {
+	switch {
+	case it.ReviewComment != nil:
+		return it.ReviewComment.HTMLURL
+	case it.Review != nil:
+		return it.Review.HTMLURL
+	case it.IssueComment != nil:
+		return it.IssueComment.HTMLURL
+	}
+	return ""
+}
+
+func (it *prItem) ID() int64 {
+	switch {
+	case it.ReviewComment != nil:
+		return it.ReviewComment.ID
+	case it.Review != nil:
+		return it.Review.ID
+	case it.IssueComment != nil:
+		return it.IssueComment.ID
+	}
+	return 0
+}
+
+// value returns the underlying API object.
+func (it *prItem) value() any {
+	switch {
+	case it.ReviewComment != nil:
+		return it.ReviewComment
+	case it.Review != nil:
+		return it.Review
+	case it.IssueComment != nil:
+		return it.IssueComment
+	}
+	return nil
+}
+
+// prItemIndex holds every review comment, review, and issue comment of a PR
+// so several IDs can be looked up with a single round of API calls.
+type prItemIndex struct {
+	ReviewComments []github.ReviewComment
+	Reviews        []github.Review
+	IssueComments  []github.IssueComment
+}
+
+func loadPRItems(client *github.Client, prRef *github.PRReference) (*prItemIndex, error) {
+	reviewComments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := client.GetReviews(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+	issueComments, err := client.GetIssueComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+	return &prItemIndex{
+		ReviewComments: reviewComments,
+		Reviews:        reviews,
+		IssueComments:  issueComments,
+	}, nil
+}
+
+// Find searches review comments, reviews, and issue comments in that order.
+// It returns nil when nothing matches.
+func (idx *prItemIndex) Find(id int64) *prItem {
+	for i := range idx.ReviewComments {
+		if idx.ReviewComments[i].ID == id {
+			return &prItem{Type: "review_comment", ReviewComment: &idx.ReviewComments[i]}
+		}
+	}
+	for i := range idx.Reviews {
+		if idx.Reviews[i].ID == id {
+			return &prItem{Type: "review", Review: &idx.Reviews[i]}
+		}
+	}
+	for i := range idx.IssueComments {
+		if idx.IssueComments[i].ID == id {
+			return &prItem{Type: "issue_comment", IssueComment: &idx.IssueComments[i]}
+		}
+	}
+	return nil
+}
+
+// ReviewItems returns the review with the given ID followed by all of its
+// review comments. It returns nil when the review does not exist.
+func (idx *prItemIndex) ReviewItems(reviewID int64) []*prItem {
+	var items []*prItem
+	for i := range idx.Reviews {
+		if idx.Reviews[i].ID == reviewID {
+			items = append(items, &prItem{Type: "review", Review: &idx.Reviews[i]})
+			break
+		}
+	}
+	if items == nil {
+		return nil
+	}
+	for i := range idx.ReviewComments {
+		if idx.ReviewComments[i].PullRequestReviewID == reviewID {
+			items = append(items, &prItem{Type: "review_comment", ReviewComment: &idx.ReviewComments[i]})
+		}
+	}
+	return items
+}
+
+// findPRItem looks up a single item by ID. It returns nil without error when
+// nothing matches.
+func findPRItem(client *github.Client, prRef *github.PRReference, id int64) (*prItem, error) {
+	idx, err := loadPRItems(client, prRef)
+	if err != nil {
+		return nil, err
+	}
+	return idx.Find(id), nil
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+)
+
+// mentionPattern matches an @mention of a user. Team mentions (@org/team)
+// and the domain of an email address are not matched.
+var mentionPattern = regexp.MustCompile(`(^|[^\w@/.-])@([A-Za-z0-9](?:[A-Za-z0-9]|-[A-Za-z0-9]){0,38})\b(/)?`)
+
+// inlineCodePattern matches inline code spans, where mentions do not notify.
+var inlineCodePattern = regexp.MustCompile("`[^`\n]*`")
+
+// bodyMentions returns the logins mentioned in a Markdown body, in order of
+// first appearance. Mentions inside code are ignored.
+func bodyMentions(body string) []string {
+	var mentions []string
+	seen := make(map[string]bool)
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		line = inlineCodePattern.ReplaceAllString(line, "")
+		for _, m := range mentionPattern.FindAllStringSubmatch(line, -1) {
+			if m[3] != "" {
+				continue
+			}
+			if key := strings.ToLower(m[2]); !seen[key] {
+				seen[key] = true
+				mentions = append(mentions, m[2])
+			}
+		}
+	}
+	return mentions
+}
+
+// mentionableUsers returns the logins that can be meaningfully mentioned on
+// a PR, keyed by their lowercase form: the repository's collaborators (its
+// assignable users) and everyone already taking part in the PR.
+func mentionableUsers(client *github.Client, prRef *github.PRReference) (map[string]string, error) {
+	users := make(map[string]string)
+	add := func(login string) {
+		if login != "" {
+			users[strings.ToLower(login)] = login
+		}
+	}
+
+	assignees, err := client.ListAssignees(prRef.Owner, prRef.Repo)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range assignees {
+		add(u.Login)
+	}
+	if pr, err := client.GetPullRequest(prRef.Owner, prRef.Repo, prRef.Number); err == nil {
+		add(pr.User.Login)
+	}
+	if reviewComments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number); err == nil {
+		for _, c := range reviewComments {
+			add(c.User.Login)
+		}
+	}
+	if issueComments, err := client.GetIssueComments(prRef.Owner, prRef.Repo, prRef.Number); err == nil {
+		for _, c := range issueComments {
+			add(c.User.Login)
+		}
+	}
+	return users, nil
+}
+
+// unknownMentions returns the mentions in body that are not in users.
+func unknownMentions(body string, users map[string]string) []string {
+	var unknown []string
+	for _, login := range bodyMentions(body) {
+		if _, ok := users[strings.ToLower(login)]; !ok {
+			unknown = append(unknown, login)
+		}
+	}
+	return unknown
+}
+
+// mentionCandidates returns the known logins starting with prefix, sorted.
+func mentionCandidates(users map[string]string, prefix string) []string {
+	prefix = strings.ToLower(prefix)
+	var candidates []string
+	for key, login := range users {
+		if strings.HasPrefix(key, prefix) {
+			candidates = append(candidates, login)
+		}
+	}
+	sort.Strings(candidates)
+	return candidates
+}
+
+// warnUnknownMentions warns on stderr about mentions in body of users who
+// are neither collaborators nor taking part in the PR; such users may not
+// be notified, or may be the wrong person. Nothing is checked when the
+// collaborators cannot be listed.
+func warnUnknownMentions(client *github.Client, prRef *github.PRReference, body string) {
+	if len(bodyMentions(body)) == 0 {
+		return
+	}
+	users, err := mentionableUsers(client, prRef)
+	if err != nil {
+		return
+	}
+	for _, login := range unknownMentions(body, users) {
+		fmt.Fprintf(os.Stderr, "Warning: @%s is not a collaborator on %s/%s or a participant in PR #%d\n", login, prRef.Owner, prRef.Repo, prRef.Number)
+	}
+}
+
+// completeMentions interactively completes the mentions in body that are
+// not known but start a known login, asking which one was meant. Mentions
+// left unknown are warned about.
+func completeMentions(reader *bufio.Reader, client *github.Client, prRef *github.PRReference, body string) string {
+	if len(bodyMentions(body)) == 0 {
+		return body
+	}
+	users, err := mentionableUsers(client, prRef)
+	if err != nil {
+		return body
+	}
+	for _, login := range unknownMentions(body, users) {
+		candidates := mentionCandidates(users, login)
+		if len(candidates) > 9 {
+			candidates = candidates[:9]
+		}
+		if len(candidates) == 0 {
+			fmt.Printf("Warning: @%s is not a collaborator or a participant in this PR\n", login)
+			continue
+		}
+		fmt.Printf("@%s is not a collaborator or a participant. Did you mean:\n", login)
+		for i, c := range candidates {
+			fmt.Printf("  %d) @%s\n", i+1, c)
+		}
+		fmt.Print("Pick a number, or press Enter to keep it: ")
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			return body
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(input))
+		if err != nil || n < 1 || n > len(candidates) {
+			continue
+		}
+		body = replaceMention(body, login, candidates[n-1])
+	}
+	return body
+}
+
+// replaceMention rewrites every mention of from as a mention of to.
+func replaceMention(body, from, to string) string {
+	return mentionPattern.ReplaceAllStringFunc(body, func(match string) string {
+		m := mentionPattern.FindStringSubmatch(match)
+		if m[3] != "" || !strings.EqualFold(m[2], from) {
+			return match
+		}
+		return m[1] + "@" + to
+	})
+}
+
+// completeBodyMentions completes an @mention at the end of a --body value
+// with the logins of collaborators and PR participants.
+func completeBodyMentions(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	at := strings.LastIndex(toComplete, "@")
+	if at < 0 || strings.ContainsAny(toComplete[at:], " \t\n") {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	client, err := completionClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	prRef, err := client.ResolvePRReference(completionPRArgs(cmd, args))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	users, err := mentionableUsers(client, prRef)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []string
+	for _, login := range mentionCandidates(users, toComplete[at+1:]) {
+		completions = append(completions, toComplete[:at+1]+login)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
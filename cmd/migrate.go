@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateFrom       string
+	migrateTo         string
+	migrateDryRun     bool
+	migrateJsonOutput bool
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate --from <pr-reference> [--to <pr-reference>]",
+	Short: "Repost unresolved review threads from a closed PR onto its replacement",
+	Long: `Carry the open review feedback of a pull request that was closed and
+recreated over to the new PR. Each unresolved thread on --from is posted on
+--to as one comment quoting the original comment and its replies, with
+their authors and permalinks, so the review context is not lost.
+
+A thread is placed on the same lines of the new PR when they are part of
+its diff, otherwise on the file, otherwise as a general PR comment; each
+fallback is reported with the reason GitHub gave. Authors and @mentions in
+the quoted comments are not linked, so nobody is notified again. Hidden
+threads are skipped. Reposted threads are tagged with the original's
+permalink, so running migrate again only carries threads not yet moved.
+
+--to defaults to the PR of the current branch.
+
+Examples:
+  gh pr-comments migrate --from 123 --to 456
+  gh pr-comments migrate --from owner/repo/123 --dry-run
+  gh pr-comments migrate --from 123 --to 456 --json`,
+	Args: cobra.NoArgs,
+	RunE: runMigrate,
+}
+
+func init() {
+	migrateCmd.Flags().StringVar(&migrateFrom, "from", "", "PR to carry unresolved threads from")
+	migrateCmd.Flags().StringVar(&migrateTo, "to", "", "PR to post them on (default: current branch's PR)")
+	migrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "Show which threads would be carried over")
+	migrateCmd.Flags().BoolVar(&migrateJsonOutput, "json", false, "Output in JSON format")
+	_ = migrateCmd.MarkFlagRequired("from")
+	migrateCmd.RegisterFlagCompletionFunc("from", completePRs)
+	migrateCmd.RegisterFlagCompletionFunc("to", completePRs)
+	rootCmd.AddCommand(migrateCmd)
+}
+
+type migrateResult struct {
+	CommentID int64  `json:"comment_id"`
+	Author    string `json:"author"`
+	Location  string `json:"location"`
+	URL       string `json:"url"`
+	Replies   int    `json:"replies"`
+	Placement string `json:"placement,omitempty"`
+	Fallback  string `json:"fallback,omitempty"`
+	NewID     int64  `json:"new_id,omitempty"`
+	NewURL    string `json:"new_url,omitempty"`
+	Action    string `json:"action"`
+	Success   bool   `json:"success"`
+	Error     string `json:"error,omitempty"`
+}
+
+// migratedMarker tags a reposted thread with the original's permalink so it
+// is never carried over twice.
+func migratedMarker(url string) string {
+	return fmt.Sprintf("<!-- gh-pr-comments-migrated: %s -->", url)
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	if err := requireLiveData("migrate"); err != nil {
+		return err
+	}
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+	// Without the resolved and hidden states every thread would look open
+	// and be carried over.
+	client.RequireCompleteStatus()
+
+	fromRef, err := client.ResolvePRReference([]string{migrateFrom})
+	if err != nil {
+		return err
+	}
+	var toArgs []string
+	if migrateTo != "" {
+		toArgs = []string{migrateTo}
+	}
+	toRef, err := client.ResolvePRReference(withGlobalPR(toArgs))
+	if err != nil {
+		return err
+	}
+	if *fromRef == *toRef {
+		return fmt.Errorf("--from and --to are the same PR")
+	}
+
+	from, err := client.GetReviewComments(fromRef.Owner, fromRef.Repo, fromRef.Number)
+	if err != nil {
+		return err
+	}
+	to, err := client.GetPullRequest(toRef.Owner, toRef.Repo, toRef.Number)
+	if err != nil {
+		return err
+	}
+	existing, err := migratedURLs(client, toRef)
+	if err != nil {
+		return err
+	}
+
+	var results []migrateResult
+	failed := 0
+	for _, t := range diffThreads(from) {
+		root := t.root
+		if root.IsResolved || root.IsMinimized {
+			continue
+		}
+		r := migrateResult{CommentID: root.ID, Author: root.User.Login, Location: root.Location(), URL: root.HTMLURL, Replies: len(t.replies)}
+		switch {
+		case existing[root.HTMLURL]:
+			r.Action = "already_migrated"
+			r.Success = true
+		case migrateDryRun:
+			r.Action = "would_migrate"
+			r.Success = true
+		default:
+			r.Action = "migrated"
+			body := migratedBody(fromRef, t)
+			r.Placement, r.Fallback, r.NewID, r.NewURL, err = postMigratedThread(client, toRef, to.Head.SHA, root, body)
+			if r.Fallback != "" && !migrateJsonOutput {
+				fmt.Fprintf(os.Stderr, "Warning: comment %d posted as a %s comment: %s\n", root.ID, r.Placement, r.Fallback)
+			}
+			if err != nil {
+				r.Error = err.Error()
+				failed++
+			} else {
+				r.Success = true
+				journalAction(toRef, journal.Entry{Action: "comment", CommentID: r.NewID, URL: r.NewURL})
+			}
+		}
+		results = append(results, r)
+	}
+
+	if migrateJsonOutput {
+		if results == nil {
+			results = []migrateResult{}
+		}
+		if err := writeJSON(results); err != nil {
+			return err
+		}
+	} else if len(results) == 0 {
+		fmt.Printf("No unresolved threads on PR #%d\n", fromRef.Number)
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tAUTHOR\tLOCATION\tREPLIES\tSTATUS")
+		for _, r := range results {
+			status := r.Action
+			switch {
+			case !r.Success:
+				status = "failed: " + r.Error
+			case r.NewURL != "":
+				status = fmt.Sprintf("%s as %s comment %s", r.Action, r.Placement, r.NewURL)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\n", r.CommentID, r.Author, r.Location, r.Replies, status)
+		}
+		w.Flush()
+		if migrateDryRun {
+			fmt.Printf("\nWould carry %d thread(s) from #%d to #%d\n", len(results), fromRef.Number, toRef.Number)
+		}
+	}
+
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d thread(s) could not be carried over", failed)
+	}
+	return nil
+}
+
+// migratedURLs returns the permalinks of threads already carried over to a
+// PR, read from the markers in its comments.
+func migratedURLs(client *github.Client, prRef *github.PRReference) (map[string]bool, error) {
+	reviewComments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+	issueComments, err := client.GetIssueComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+	bodies := make([]string, 0, len(reviewComments)+len(issueComments))
+	for _, c := range reviewComments {
+		bodies = append(bodies, c.Body)
+	}
+	for _, c := range issueComments {
+		bodies = append(bodies, c.Body)
+	}
+
+	urls := make(map[string]bool)
+	const prefix = "<!-- gh-pr-comments-migrated: "
+	for _, body := range bodies {
+		for _, line := range strings.Split(body, "\n") {
+			line = strings.TrimSpace(line)
+			if strings.HasPrefix(line, prefix) && strings.HasSuffix(line, " -->") {
+				urls[strings.TrimSuffix(strings.TrimPrefix(line, prefix), " -->")] = true
+			}
+		}
+	}
+	return urls, nil
+}
+
+// migratedBody quotes a thread's comments with their authors and
+// permalinks, followed by the marker naming the original thread.
+func migratedBody(fromRef *github.PRReference, t *diffThread) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Carried over from unresolved review feedback on %s/%s#%d (`%s`).\n", fromRef.Owner, fromRef.Repo, fromRef.Number, t.root.Location())
+	for i, c := range append([]github.ReviewComment{t.root}, t.replies...) {
+		verb := "commented"
+		if i > 0 {
+			verb = "replied"
+		}
+		fmt.Fprintf(&b, "\n**%s** [%s](%s) on %s:\n\n", c.User.Login, verb, c.HTMLURL, c.CreatedAt.Format("2006-01-02"))
+		for _, line := range strings.Split(quietMentions(strings.TrimSpace(c.Body)), "\n") {
+			b.WriteString(strings.TrimRight("> "+line, " ") + "\n")
+		}
+	}
+	b.WriteString("\n" + migratedMarker(t.root.HTMLURL))
+	return b.String()
+}
+
+// quietMentionPattern matches a user or team @mention.
+var quietMentionPattern = regexp.MustCompile(`(^|[^\w@/.-])(@[A-Za-z0-9][A-Za-z0-9-]*(?:/[\w.-]+)?)`)
+
+// quietMentions wraps the @mentions in a reposted body in code spans so
+// that carrying a thread over does not notify everyone it mentions again.
+// Mentions already inside code are left alone.
+func quietMentions(body string) string {
+	lines := strings.Split(body, "\n")
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		var b strings.Builder
+		last := 0
+		for _, span := range inlineCodePattern.FindAllStringIndex(line, -1) {
+			b.WriteString(quietMentionPattern.ReplaceAllString(line[last:span[0]], "$1`$2`"))
+			b.WriteString(line[span[0]:span[1]])
+			last = span[1]
+		}
+		b.WriteString(quietMentionPattern.ReplaceAllString(line[last:], "$1`$2`"))
+		lines[i] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// postMigratedThread posts body on the same line of the new PR, falling back
+// to the file and then to a general comment when GitHub rejects the line or
+// the file as outside the new diff. It reports where the comment landed and,
+// when it fell back, why.
+func postMigratedThread(client *github.Client, prRef *github.PRReference, headSHA string, root github.ReviewComment, body string) (placement, fallback string, id int64, url string, err error) {
+	var reasons []string
+	if !root.IsFileLevel() && root.Line != nil {
+		input := github.NewReviewComment{Body: body, CommitID: headSHA, Path: root.Path, Line: *root.Line, Side: root.Side}
+		if root.StartLine != nil && *root.StartLine < *root.Line {
+			input.StartLine = *root.StartLine
+			input.StartSide = root.StartSide
+			if input.StartSide == "" {
+				input.StartSide = root.Side
+			}
+		}
+		created, err := client.CreateReviewComment(prRef.Owner, prRef.Repo, prRef.Number, input)
+		if err == nil {
+			return "line", "", created.ID, created.HTMLURL, nil
+		}
+		if !github.IsUnprocessable(err) {
+			return "", "", 0, "", err
+		}
+		reasons = append(reasons, fmt.Sprintf("line %s: %v", root.Location(), err))
+	}
+	input := github.NewReviewComment{Body: body, CommitID: headSHA, Path: root.Path, SubjectType: "file"}
+	created, err := client.CreateReviewComment(prRef.Owner, prRef.Repo, prRef.Number, input)
+	if err == nil {
+		return "file", strings.Join(reasons, "; "), created.ID, created.HTMLURL, nil
+	}
+	if !github.IsUnprocessable(err) {
+		return "", "", 0, "", err
+	}
+	reasons = append(reasons, fmt.Sprintf("file %s: %v", root.Path, err))
+	issueComment, err := client.CreateIssueComment(prRef.Owner, prRef.Repo, prRef.Number, body)
+	if err != nil {
+		return "", "", 0, "", err
+	}
+	return "general", strings.Join(reasons, "; "), issueComment.ID, issueComment.HTMLURL, nil
+}
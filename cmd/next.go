@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/journal"
+	"github.com/STRRL/gh-pr-comments/internal/state"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+const nextStateFile = "next.json"
+
+var (
+	nextPR         string
+	nextJsonOutput bool
+	nextSkip       bool
+	nextReset      bool
+)
+
+var nextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Walk through unresolved feedback one comment at a time",
+	Long: `Show the oldest unresolved review thread that the PR author has not yet
+answered, with full context.
+
+When run in a terminal, offers inline actions:
+  r - reply to the thread
+  s - resolve the thread
+  k - skip it for now (remembered in a local state file)
+  q - quit
+
+Skipped threads are not shown again until --reset is used, so repeated calls
+walk through all outstanding feedback.
+
+When a reply @mentions someone who is neither a collaborator nor taking part
+in the PR, next offers the matching logins to complete the mention with.
+
+Examples:
+  gh pr-comments next
+  gh pr-comments next --skip
+  gh pr-comments next --reset
+  gh pr-comments next --pr owner/repo/123 --json`,
+	Args: cobra.NoArgs,
+	RunE: runNext,
+}
+
+func init() {
+	nextCmd.Flags().StringVar(&nextPR, "pr", "", "PR reference (e.g., owner/repo/123 or just 123)")
+	nextCmd.RegisterFlagCompletionFunc("pr", completePRs)
+	nextCmd.Flags().BoolVar(&nextJsonOutput, "json", false, "Output in JSON format (no interactive actions)")
+	nextCmd.Flags().BoolVar(&nextSkip, "skip", false, "Skip the current comment and show the one after it")
+	nextCmd.Flags().BoolVar(&nextReset, "reset", false, "Forget previously skipped comments")
+	rootCmd.AddCommand(nextCmd)
+}
+
+type nextState map[string][]int64
+
+type nextItem struct {
+	Thread  github.ReviewThread
+	Comment github.ReviewComment
+}
+
+func runNext(cmd *cobra.Command, args []string) error {
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	var prArgs []string
+	if nextPR != "" {
+		prArgs = []string{nextPR}
+	}
+
+	prRef, err := client.ResolvePRReference(prArgs)
+	if err != nil {
+		return fmt.Errorf("could not determine PR: %w\nPlease specify a PR with --pr or run from a branch with an associated PR", err)
+	}
+
+	st := nextState{}
+	if err := state.Load(nextStateFile, &st); err != nil {
+		return err
+	}
+	key := state.PRKey(prRef.Owner, prRef.Repo, prRef.Number)
+	if nextReset {
+		delete(st, key)
+		if err := state.Save(nextStateFile, st); err != nil {
+			return err
+		}
+	}
+
+	items, err := findNextCandidates(client, prRef, st[key])
+	if err != nil {
+		return err
+	}
+
+	if nextSkip && len(items) > 0 {
+		st[key] = append(st[key], items[0].Comment.ID)
+		if err := state.Save(nextStateFile, st); err != nil {
+			return err
+		}
+		items = items[1:]
+	}
+
+	if len(items) == 0 {
+		if nextJsonOutput {
+			// Only format_version: there is no comment to show.
+			return writeJSON(struct{}{})
+		}
+		fmt.Println("Nothing left: every unresolved thread has been answered or skipped.")
+		return nil
+	}
+
+	item := items[0]
+	if nextJsonOutput {
+		output := struct {
+			ThreadID  string                 `json:"thread_id"`
+			Comment   github.ReviewComment   `json:"comment"`
+			Thread    []github.ThreadComment `json:"thread"`
+			Remaining int                    `json:"remaining"`
+		}{
+			ThreadID:  item.Thread.ID,
+			Comment:   item.Comment,
+			Thread:    item.Thread.Comments,
+			Remaining: len(items) - 1,
+		}
+		return writeJSON(output)
+	}
+
+	printNextItem(item, len(items)-1)
+
+	if !term.IsTerminal(os.Stdin) || !term.IsTerminal(os.Stdout) {
+		return nil
+	}
+
+	return promptNextAction(client, prRef, item, st, key)
+}
+
+func findNextCandidates(client *github.Client, prRef *github.PRReference, skipped []int64) ([]nextItem, error) {
+	pr, err := client.GetPullRequest(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	threads, err := client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, fmt.Errorf("get review threads: %w", err)
+	}
+
+	comments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	commentByID := make(map[int64]github.ReviewComment)
+	for _, c := range comments {
+		commentByID[c.ID] = c
+	}
+
+	skippedSet := make(map[int64]bool)
+	for _, id := range skipped {
+		skippedSet[id] = true
+	}
+
+	var items []nextItem
+	for _, t := range threads {
+		last := t.LastComment()
+		if t.IsResolved || last == nil || strings.EqualFold(last.Author, pr.User.Login) {
+			continue
+		}
+		root, ok := commentByID[t.CommentIDs[0]]
+		if !ok || skippedSet[root.ID] {
+			continue
+		}
+		items = append(items, nextItem{Thread: t, Comment: root})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Comment.CreatedAt.Before(items[j].Comment.CreatedAt)
+	})
+
+	return items, nil
+}
+
+func printNextItem(item nextItem, remaining int) {
+	printReviewCommentDetail(item.Comment)
+
+	if len(item.Thread.Comments) > 1 {
+		fmt.Println(strings.Repeat("─", 60))
+		fmt.Println("Replies:")
+		fmt.Println(strings.Repeat("─", 60))
+		for _, c := range item.Thread.Comments[1:] {
+			fmt.Printf("[%d] %s - %s\n", c.ID, c.Author, c.CreatedAt.Format("2006-01-02 15:04"))
+			fmt.Println(c.Body)
+			fmt.Println()
+		}
+	}
+
+	fmt.Println(strings.Repeat("─", 60))
+	fmt.Printf("%d more unanswered thread(s) after this one\n", remaining)
+}
+
+func promptNextAction(client *github.Client, prRef *github.PRReference, item nextItem, st nextState, key string) error {
+	reader := bufio.NewReader(os.Stdin)
+
+	for {
+		fmt.Print("Action: [r]eply, re[s]olve, s[k]ip, [q]uit: ")
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			return nil
+		}
+
+		switch strings.TrimSpace(strings.ToLower(input)) {
+		case "r", "reply":
+			fmt.Print("Reply: ")
+			body, err := reader.ReadString('\n')
+			if err != nil {
+				return fmt.Errorf("read reply: %w", err)
+			}
+			body = strings.TrimSpace(body)
+			if body == "" {
+				fmt.Println("Empty reply, nothing posted.")
+				continue
+			}
+			body = withSignature(completeMentions(reader, client, prRef, body))
+			reply, err := client.ReplyToReviewComment(prRef.Owner, prRef.Repo, prRef.Number, item.Comment.ID, body)
+			if err != nil {
+				return err
+			}
+			journalAction(prRef, journal.Entry{Action: "reply", CommentID: reply.ID, URL: reply.HTMLURL})
+			fmt.Printf("Replied: %s\n", reply.HTMLURL)
+			return nil
+		case "s", "resolve":
+			if err := client.ResolveThread(item.Thread.ID); err != nil {
+				return err
+			}
+			journalAction(prRef, journal.Entry{Action: "resolve", ThreadID: item.Thread.ID})
+			fmt.Printf("Thread resolved for comment %d\n", item.Comment.ID)
+			return nil
+		case "k", "skip":
+			st[key] = append(st[key], item.Comment.ID)
+			if err := state.Save(nextStateFile, st); err != nil {
+				return err
+			}
+			fmt.Printf("Skipped comment %d\n", item.Comment.ID)
+			return nil
+		case "q", "quit", "":
+			return nil
+		default:
+			fmt.Println("Unknown action.")
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/state"
+	"github.com/spf13/cobra"
+)
+
+const noteStateFile = "notes.json"
+
+var (
+	notePR         string
+	noteBody       string
+	noteClear      bool
+	noteExport     bool
+	noteJsonOutput bool
+	noteNoRedact   bool
+)
+
+var noteCmd = &cobra.Command{
+	Use:   "note [comment-id]",
+	Short: "Keep private notes on comments",
+	Long: `Attach a private note to a comment. Notes are stored in a local state file
+per PR and never sent to GitHub, which makes them handy for drafting replies
+offline. They are shown by 'view'.
+
+With only a comment ID, prints the comment's note. --export prints every
+note on the PR as Markdown (or JSON with --json), with credentials masked
+as in 'summary' unless --no-redact is given.
+
+Examples:
+  gh pr-comments note 2621968472 --body "Fix in the retry PR, mention the flake"
+  gh pr-comments note 2621968472
+  gh pr-comments note 2621968472 --clear
+  gh pr-comments note --export > notes.md`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runNote,
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return completeCommentIDs(cmd, args, toComplete)
+	},
+}
+
+func init() {
+	noteCmd.Flags().StringVar(&notePR, "pr", "", "PR reference (e.g., owner/repo/123 or just 123)")
+	noteCmd.RegisterFlagCompletionFunc("pr", completePRs)
+	noteCmd.Flags().StringVar(&noteBody, "body", "", "Note text")
+	noteCmd.Flags().BoolVar(&noteClear, "clear", false, "Delete the comment's note")
+	noteCmd.Flags().BoolVar(&noteExport, "export", false, "Print all notes on the PR")
+	noteCmd.Flags().BoolVar(&noteJsonOutput, "json", false, "Output in JSON format")
+	noteCmd.Flags().BoolVar(&noteNoRedact, "no-redact", false, "Keep credentials found in exported notes")
+	rootCmd.AddCommand(noteCmd)
+}
+
+type commentNote struct {
+	Body      string    `json:"body"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// commentNotes maps a PR key to the note on each annotated comment.
+type commentNotes map[string]map[int64]commentNote
+
+func runNote(cmd *cobra.Command, args []string) error {
+	if noteExport && len(args) > 0 {
+		return fmt.Errorf("--export cannot be combined with a comment ID")
+	}
+	if !noteExport && len(args) == 0 {
+		return fmt.Errorf("requires a comment ID, or --export")
+	}
+	if noteClear && noteBody != "" {
+		return fmt.Errorf("--clear cannot be combined with --body")
+	}
+
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	var prArgs []string
+	if notePR != "" {
+		prArgs = []string{notePR}
+	}
+
+	prRef, err := client.ResolvePRReference(prArgs)
+	if err != nil {
+		return fmt.Errorf("could not determine PR: %w\nPlease specify a PR with --pr or run from a branch with an associated PR", err)
+	}
+
+	notes := commentNotes{}
+	if err := state.Load(noteStateFile, &notes); err != nil {
+		return err
+	}
+	key := state.PRKey(prRef.Owner, prRef.Repo, prRef.Number)
+
+	if noteExport {
+		return exportNotes(prRef, notes[key])
+	}
+
+	commentID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid comment ID: %s", args[0])
+	}
+
+	switch {
+	case noteClear:
+		delete(notes[key], commentID)
+		if len(notes[key]) == 0 {
+			delete(notes, key)
+		}
+		if err := state.Save(noteStateFile, notes); err != nil {
+			return err
+		}
+		fmt.Printf("Deleted note on comment %d\n", commentID)
+		return nil
+	case noteBody != "":
+		item, err := findPRItem(client, prRef, commentID)
+		if err != nil {
+			return err
+		}
+		if item == nil {
+			return fmt.Errorf("comment with ID %d not found in PR %d", commentID, prRef.Number)
+		}
+		if notes[key] == nil {
+			notes[key] = make(map[int64]commentNote)
+		}
+		notes[key][commentID] = commentNote{Body: noteBody, UpdatedAt: time.Now()}
+		if err := state.Save(noteStateFile, notes); err != nil {
+			return err
+		}
+		fmt.Printf("Saved note on comment %d\n", commentID)
+		return nil
+	}
+
+	note, ok := notes[key][commentID]
+	if noteJsonOutput {
+		if !ok {
+			// Only format_version: the comment has no note.
+			return writeJSON(struct{}{})
+		}
+		return writeJSON(note)
+	}
+	if !ok {
+		fmt.Printf("Comment %d has no note\n", commentID)
+		return nil
+	}
+	fmt.Println(note.Body)
+	return nil
+}
+
+func exportNotes(prRef *github.PRReference, notes map[int64]commentNote) error {
+	ids := make([]int64, 0, len(notes))
+	for id := range notes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	redactor, err := outputRedactor(noteNoRedact)
+	if err != nil {
+		return err
+	}
+
+	if noteJsonOutput {
+		type exportedNote struct {
+			ID int64 `json:"id"`
+			commentNote
+		}
+		exported := make([]exportedNote, 0, len(ids))
+		for _, id := range ids {
+			exported = append(exported, exportedNote{ID: id, commentNote: notes[id]})
+		}
+		return writeRedactedJSON(redactor, exported)
+	}
+
+	if len(ids) == 0 {
+		fmt.Println("No notes found.")
+		return nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Notes for %s/%s#%d\n", prRef.Owner, prRef.Repo, prRef.Number)
+	for _, id := range ids {
+		fmt.Fprintf(&b, "\n## Comment %d\n\n%s\n", id, strings.TrimSpace(notes[id].Body))
+	}
+	fmt.Print(redactText(redactor, b.String()))
+	return nil
+}
+
+// loadNotes returns the notes on one PR.
+func loadNotes(prRef *github.PRReference) (map[int64]commentNote, error) {
+	notes := commentNotes{}
+	if err := state.Load(noteStateFile, &notes); err != nil {
+		return nil, err
+	}
+	return notes[state.PRKey(prRef.Owner, prRef.Repo, prRef.Number)], nil
+}
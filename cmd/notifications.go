@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	notificationsReadOnly   bool
+	notificationsJsonOutput bool
+)
+
+var notificationsCmd = &cobra.Command{
+	Use:   "notifications",
+	Short: "Keep GitHub notifications in sync with PRs processed here",
+}
+
+var notificationsDoneCmd = &cobra.Command{
+	Use:   "done [pr-reference]",
+	Short: "Mark a PR's notifications as done",
+	Long: `Mark the notification thread for a pull request as done, removing it from
+your GitHub inbox. Use --read-only to only mark it as read.
+
+If no PR reference is given, finds the PR for the current branch.
+
+PR reference can be:
+  - Full URL: https://github.com/owner/repo/pull/123
+  - Short form: owner/repo/123
+  - Just number: 123 (when in a repo context)
+  - Omitted: uses current branch's PR
+
+Examples:
+  gh pr-comments notifications done
+  gh pr-comments notifications done owner/repo/123
+  gh pr-comments notifications done --read-only`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runNotificationsDone,
+}
+
+func init() {
+	notificationsDoneCmd.Flags().BoolVar(&notificationsReadOnly, "read-only", false, "Mark as read but keep in the inbox")
+	notificationsDoneCmd.Flags().BoolVar(&notificationsJsonOutput, "json", false, "Output in JSON format")
+	notificationsCmd.AddCommand(notificationsDoneCmd)
+	rootCmd.AddCommand(notificationsCmd)
+}
+
+type notificationResult struct {
+	ThreadID string `json:"thread_id"`
+	Title    string `json:"title"`
+	Action   string `json:"action"`
+	Success  bool   `json:"success"`
+	Error    string `json:"error,omitempty"`
+}
+
+func runNotificationsDone(cmd *cobra.Command, args []string) error {
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
+	if err != nil {
+		return err
+	}
+
+	notifications, err := client.ListRepoNotifications(prRef.Owner, prRef.Repo)
+	if err != nil {
+		return err
+	}
+
+	action := "done"
+	if notificationsReadOnly {
+		action = "read"
+	}
+
+	var results []notificationResult
+	for _, n := range notifications {
+		if !n.IsForPullRequest(prRef.Owner, prRef.Repo, prRef.Number) {
+			continue
+		}
+		var opErr error
+		if notificationsReadOnly {
+			opErr = client.MarkNotificationRead(n.ID)
+		} else {
+			opErr = client.MarkNotificationDone(n.ID)
+		}
+		result := notificationResult{
+			ThreadID: n.ID,
+			Title:    n.Subject.Title,
+			Action:   action,
+			Success:  opErr == nil,
+		}
+		if opErr != nil {
+			result.Error = opErr.Error()
+		}
+		results = append(results, result)
+	}
+
+	if notificationsJsonOutput {
+		if results == nil {
+			results = []notificationResult{}
+		}
+		return writeJSON(results)
+	}
+
+	if len(results) == 0 {
+		fmt.Printf("No notifications found for PR #%d.\n", prRef.Number)
+		return nil
+	}
+
+	for _, r := range results {
+		if r.Success {
+			fmt.Printf("Marked %s: %s\n", r.Action, r.Title)
+		} else {
+			fmt.Fprintf(os.Stderr, "Failed to mark notification %s: %s\n", r.ThreadID, r.Error)
+		}
+	}
+	return nil
+}
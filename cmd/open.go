@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/cli/go-gh/v2/pkg/browser"
+	"github.com/spf13/cobra"
+)
+
+var (
+	openPR    string
+	openPrint bool
+	openFiles bool
+)
+
+var openCmd = &cobra.Command{
+	Use:   "open [id|pr-reference]",
+	Short: "Open a comment or pull request in the browser",
+	Long: `Open a review comment, review, issue comment, or pull request in the
+default web browser.
+
+A numeric argument is first looked up as a comment or review ID on the PR
+(current branch's PR or --pr). If nothing matches, it is treated as a PR
+number. With no argument, the current branch's PR is opened.
+
+Use --files to open review comments in the PR's "Files changed" tab instead
+of the conversation view, and --print to output the URL without opening it.
+
+Examples:
+  gh pr-comments open
+  gh pr-comments open 2621968472
+  gh pr-comments open 2621968472 --files
+  gh pr-comments open owner/repo/123 --print`,
+	Args:              cobra.MaximumNArgs(1),
+	RunE:              runOpen,
+	ValidArgsFunction: completeCommentIDs,
+}
+
+func init() {
+	openCmd.Flags().StringVar(&openPR, "pr", "", "PR reference (e.g., owner/repo/123 or just 123)")
+	openCmd.RegisterFlagCompletionFunc("pr", completePRs)
+	openCmd.Flags().BoolVar(&openPrint, "print", false, "Print the URL instead of opening it")
+	openCmd.Flags().BoolVar(&openFiles, "files", false, "Open review comments in the Files changed tab")
+	rootCmd.AddCommand(openCmd)
+}
+
+func runOpen(cmd *cobra.Command, args []string) error {
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	target, err := resolveOpenURL(client, args)
+	if err != nil {
+		return err
+	}
+
+	if openPrint {
+		fmt.Println(target)
+		return nil
+	}
+
+	return browser.New("", os.Stdout, os.Stderr).Browse(target)
+}
+
+func resolveOpenURL(client *github.Client, args []string) (string, error) {
+	var prArgs []string
+	if openPR != "" {
+		prArgs = []string{openPR}
+	}
+
+	if len(args) > 0 {
+		if id, err := strconv.ParseInt(args[0], 10, 64); err == nil {
+			itemURL, err := findItemURL(client, prArgs, id)
+			if err != nil {
+				return "", err
+			}
+			if itemURL != "" {
+				return itemURL, nil
+			}
+		}
+		prArgs = args
+	}
+
+	prRef, err := client.ResolvePRReference(prArgs)
+	if err != nil {
+		return "", err
+	}
+	suffix := ""
+	if openFiles {
+		suffix = "/files"
+	}
+	return pullRequestURL(client, prRef, suffix)
+}
+
+// findItemURL returns an empty URL when the PR cannot be determined or the ID
+// does not belong to it, so the caller can fall back to a PR number.
+func findItemURL(client *github.Client, prArgs []string, id int64) (string, error) {
+	prRef, err := client.ResolvePRReference(prArgs)
+	if err != nil {
+		return "", nil
+	}
+	item, err := findPRItem(client, prRef, id)
+	if err != nil || item == nil {
+		return "", err
+	}
+	if openFiles && item.ReviewComment != nil {
+		return pullRequestURL(client, prRef, fmt.Sprintf("/files#r%d", id))
+	}
+	return item.HTMLURL(), nil
+}
+
+func pullRequestURL(client *github.Client, prRef *github.PRReference, suffix string) (string, error) {
+	pr, err := client.GetPullRequest(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return "", err
+	}
+	return pr.HTMLURL + suffix, nil
+}
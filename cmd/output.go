@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+)
+
+// jsonFormatVersion is reported as format_version in every JSON output. It
+// is bumped when a field is renamed, removed, or changes meaning; adding
+// fields does not bump it.
+const jsonFormatVersion = 1
+
+// writeJSON prints v as indented JSON. Objects get a "format_version" key in
+// front of their own; arrays are wrapped in an object under "items". Keys
+// follow struct field order, and map keys are sorted, so the same data always
+// encodes to the same bytes.
+func writeJSON(v interface{}) error {
+	return encodeJSON(os.Stdout, v)
+}
+
+// encodeJSON is writeJSON for an arbitrary writer, such as an HTTP response.
+func encodeJSON(w io.Writer, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if v == nil || (rv.Kind() == reflect.Slice && rv.IsNil()) {
+		v = []struct{}{}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode JSON: %w", err)
+	}
+
+	header := fmt.Sprintf(`{"format_version":%d`, jsonFormatVersion)
+	var compact bytes.Buffer
+	compact.WriteString(header)
+	if data[0] == '{' {
+		if !bytes.Equal(data, []byte("{}")) {
+			compact.WriteByte(',')
+		}
+		compact.Write(data[1:])
+	} else {
+		compact.WriteString(`,"items":`)
+		compact.Write(data)
+		compact.WriteByte('}')
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, compact.Bytes(), "", "  "); err != nil {
+		return fmt.Errorf("encode JSON: %w", err)
+	}
+	out.WriteByte('\n')
+	_, err = w.Write(out.Bytes())
+	return err
+}
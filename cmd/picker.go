@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/cli/go-gh/v2/pkg/prompter"
+	"github.com/cli/go-gh/v2/pkg/term"
+)
+
+func pickMany(prompt string, options []string) ([]int, error) {
+	if len(options) == 0 {
+		return nil, nil
+	}
+	if !term.IsTerminal(os.Stdin) || !term.IsTerminal(os.Stdout) {
+		return nil, fmt.Errorf("--interactive requires a terminal")
+	}
+	p := prompter.New(os.Stdin, os.Stdout, os.Stderr)
+	return p.MultiSelect(prompt, nil, options)
+}
+
+func pickerLabel(id int64, location, author, body string) string {
+	label := fmt.Sprintf("%d  %s", id, author)
+	if location != "" {
+		label = fmt.Sprintf("%d  %s  %s", id, location, author)
+	}
+	return label + ": " + github.TruncateString(body, 60)
+}
+
+// choosePR prompts for one of several PRs found for the current branch or
+// commit.
+func choosePR(what string, prs []github.PRSearchResult) (int, error) {
+	options := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		options = append(options, fmt.Sprintf("#%d [%s → %s] %s", pr.Number, pr.Status(), pr.Base.Ref, github.TruncateString(pr.Title, 60)))
+	}
+	p := prompter.New(os.Stdin, os.Stderr, os.Stderr)
+	return p.Select(fmt.Sprintf("Several pull requests found for %s", what), "", options)
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	planPR     string
+	planOutput string
+	planAction string
+	planReason string
+	planBody   string
+	planFilter commentFilter
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Write a reviewable manifest of batch actions",
+	Long: `Select review comments with filters and write one planned action per
+comment to a YAML manifest. The manifest can be edited by hand (drop lines,
+change actions, write reply bodies) and is executed with 'apply'.
+
+Actions:
+  resolve - resolve the comment's thread
+  hide    - minimize the comment with --reason
+  reply   - reply to the comment with --body (or fill in each body by hand)
+
+Each entry carries a 'context' line (location, author, summary) for the
+reader; 'apply' ignores it.
+
+Examples:
+  gh pr-comments plan --author "coderabbitai[bot]" --outdated=true -o plan.yaml
+  gh pr-comments plan --action hide --reason outdated --file internal/ -o plan.yaml
+  gh pr-comments plan --action reply --body "Fixed, thanks!" --review-id 3581523351
+  gh pr-comments apply plan.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runPlan,
+}
+
+func init() {
+	planCmd.Flags().StringVar(&planPR, "pr", "", "PR reference (e.g., owner/repo/123 or just 123)")
+	planCmd.RegisterFlagCompletionFunc("pr", completePRs)
+	planCmd.Flags().StringVarP(&planOutput, "output", "o", "", "Write the plan to this file instead of stdout")
+	planCmd.Flags().StringVar(&planAction, "action", "resolve", "Action to plan for each comment (resolve/hide/reply)")
+	planCmd.Flags().StringVar(&planReason, "reason", "resolved", "Reason for hide actions")
+	planCmd.Flags().StringVar(&planBody, "body", "", "Body for reply actions")
+	planCmd.Flags().Int64Var(&planFilter.ReviewID, "review-id", 0, "Filter by review ID")
+	planCmd.Flags().StringVar(&planFilter.Outdated, "outdated", "", "Filter by outdated status (true/false)")
+	planCmd.Flags().StringVar(&planFilter.Author, "author", "", "Filter by comment author")
+	planCmd.Flags().StringVar(&planFilter.Marker, "marker", "", "Filter by bot marker (substring match)")
+	planCmd.Flags().StringVar(&planFilter.File, "file", "", "Filter by file path, directory, or glob")
+	planCmd.RegisterFlagCompletionFunc("file", completeCommentedFiles)
+	planCmd.RegisterFlagCompletionFunc("action", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"resolve\tResolve the thread", "hide\tMinimize the comment", "reply\tReply to the comment"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	rootCmd.AddCommand(planCmd)
+}
+
+// batchPlan is the manifest written by 'plan' and executed by 'apply'.
+type batchPlan struct {
+	PR          string          `yaml:"pr"`
+	GeneratedAt time.Time       `yaml:"generated_at"`
+	Actions     []plannedAction `yaml:"actions"`
+}
+
+type plannedAction struct {
+	Action    string `yaml:"action"`
+	CommentID int64  `yaml:"comment_id"`
+	Reason    string `yaml:"reason,omitempty"`
+	Body      string `yaml:"body,omitempty"`
+	Context   string `yaml:"context,omitempty"`
+}
+
+func (a plannedAction) validate() error {
+	switch a.Action {
+	case "resolve":
+	case "hide":
+		if _, err := github.ParseClassifier(a.Reason); err != nil {
+			return err
+		}
+	case "reply":
+		if a.Body == "" {
+			return fmt.Errorf("reply to %d has no body", a.CommentID)
+		}
+	default:
+		return fmt.Errorf("invalid action: %s (valid: resolve, hide, reply)", a.Action)
+	}
+	return nil
+}
+
+func runPlan(cmd *cobra.Command, args []string) error {
+	template := plannedAction{Action: planAction}
+	switch planAction {
+	case "hide":
+		template.Reason = planReason
+	case "reply":
+		// An empty body is allowed here so replies can be written in the
+		// manifest by hand; apply rejects any that are still empty.
+		template.Body = planBody
+	}
+	if err := (plannedAction{Action: template.Action, Reason: template.Reason, Body: "-"}).validate(); err != nil {
+		return err
+	}
+
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	var prArgs []string
+	if planPR != "" {
+		prArgs = []string{planPR}
+	}
+
+	prRef, err := client.ResolvePRReference(prArgs)
+	if err != nil {
+		return fmt.Errorf("could not determine PR: %w\nPlease specify a PR with --pr or run from a branch with an associated PR", err)
+	}
+
+	comments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	plan := batchPlan{
+		PR:          fmt.Sprintf("%s/%s/%d", prRef.Owner, prRef.Repo, prRef.Number),
+		GeneratedAt: time.Now().UTC().Truncate(time.Second),
+		Actions:     []plannedAction{},
+	}
+	for _, c := range planFilter.filterReviewComments(comments) {
+		if planAction == "resolve" && c.InReplyToID != 0 {
+			// Resolving any comment resolves its whole thread; one entry per
+			// thread keeps the plan short.
+			continue
+		}
+		action := template
+		action.CommentID = c.ID
+		action.Context = fmt.Sprintf("%s by %s: %s", c.Location(), c.User.Login, github.TruncateString(c.Body, 60))
+		plan.Actions = append(plan.Actions, action)
+	}
+
+	data, err := yaml.Marshal(plan)
+	if err != nil {
+		return fmt.Errorf("encode plan: %w", err)
+	}
+
+	if planOutput == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(planOutput, data, 0o644); err != nil {
+		return fmt.Errorf("write plan: %w", err)
+	}
+	fmt.Printf("Wrote %d action(s) to %s\n", len(plan.Actions), planOutput)
+	return nil
+}
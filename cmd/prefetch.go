@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/STRRL/gh-pr-comments/internal/cache"
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+)
+
+// prefetchWorkers bounds how many commit diffs are fetched at once.
+const prefetchWorkers = 8
+
+var prefetchCmd = &cobra.Command{
+	Use:   "prefetch [pr-reference]",
+	Short: "Warm the local cache with a PR's reviews, comments, threads, and diffs",
+	Long: `Fetch everything the other commands read about a pull request, in
+parallel, and store it in the local response cache: the PR itself, reviews,
+review and issue comments, review threads, the timeline, and every commit
+with its diff. Commands run with --offline are then answered locally.
+
+Other commands read from GitHub unless GH_PR_COMMENTS_CACHE_TTL is set, in
+which case cached responses younger than it are served. Any write made
+through this extension drops the cached responses of the PR it changed,
+and --no-cache makes a single command skip the cache. Commands that act on what they read, such as resolve,
+hide, and apply, or must answer for right now, such as ready and wait,
+always read live data and refuse to run with --offline.
+
+If no PR reference is given, finds the PR for the current branch.
+
+PR reference can be:
+  - Full URL: https://github.com/owner/repo/pull/123
+  - Short form: owner/repo/123
+  - Just number: 123 (when in a repo context)
+  - Omitted: uses current branch's PR
+
+Examples:
+  gh pr-comments prefetch
+  gh pr-comments prefetch owner/repo/123
+  gh pr-comments prefetch && gh pr-comments list --offline
+  GH_PR_COMMENTS_CACHE_TTL=30m gh pr-comments prefetch`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runPrefetch,
+}
+
+func init() {
+	rootCmd.AddCommand(prefetchCmd)
+}
+
+func runPrefetch(cmd *cobra.Command, args []string) error {
+	ttl, err := cache.TTL()
+	if err != nil {
+		return err
+	}
+
+	// Always refresh: the point is to replace whatever is cached.
+	github.NoCache = true
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
+	if err != nil {
+		return err
+	}
+	owner, repo, number := prRef.Owner, prRef.Repo, prRef.Number
+
+	start := time.Now()
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		failures []error
+		counts   = make(map[string]int)
+	)
+	fetch := func(what string, f func() (int, error)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n, err := f()
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures = append(failures, fmt.Errorf("%s: %w", what, err))
+				return
+			}
+			counts[what] += n
+		}()
+	}
+
+	fetch("pull request", func() (int, error) {
+		_, err := client.GetPullRequest(owner, repo, number)
+		return 1, err
+	})
+	fetch("reviews", func() (int, error) {
+		reviews, err := client.GetReviews(owner, repo, number)
+		return len(reviews), err
+	})
+	fetch("review comments", func() (int, error) {
+		comments, err := client.GetReviewComments(owner, repo, number)
+		return len(comments), err
+	})
+	fetch("issue comments", func() (int, error) {
+		comments, err := client.GetIssueComments(owner, repo, number)
+		return len(comments), err
+	})
+	fetch("threads", func() (int, error) {
+		threads, err := client.GetReviewThreads(owner, repo, number)
+		return len(threads), err
+	})
+	fetch("timeline events", func() (int, error) {
+		events, err := client.GetTimeline(owner, repo, number)
+		return len(events), err
+	})
+	fetch("commits", func() (int, error) {
+		commits, err := client.GetPRCommits(owner, repo, number)
+		if err != nil {
+			return 0, err
+		}
+		sem := make(chan struct{}, prefetchWorkers)
+		for _, c := range commits {
+			sem <- struct{}{}
+			fetch("commit diffs", func() (int, error) {
+				defer func() { <-sem }()
+				_, err := client.GetCommit(owner, repo, c.SHA)
+				return 1, err
+			})
+		}
+		return len(commits), nil
+	})
+	wg.Wait()
+
+	for _, err := range failures {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	served := "for --offline"
+	if ttl > 0 {
+		served = "cached for " + ttl.String()
+	}
+	fmt.Printf("Prefetched PR #%d in %s (%s):\n", number, time.Since(start).Round(100*time.Millisecond), served)
+	for _, what := range []string{"pull request", "reviews", "review comments", "issue comments", "threads", "timeline events", "commits", "commit diffs"} {
+		fmt.Printf("  %-16s %d\n", what, counts[what])
+	}
+	if len(failures) > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d fetch(es) failed", len(failures))
+	}
+	return nil
+}
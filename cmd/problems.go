@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/STRRL/gh-pr-comments/internal/severity"
+)
+
+// problemMatcher is a VS Code problem matcher for "--format problems"
+// output, for the "problemMatcher" field of a task in .vscode/tasks.json.
+const problemMatcher = `{
+  "owner": "gh-pr-comments",
+  "source": "review",
+  "fileLocation": ["relative", "${workspaceFolder}"],
+  "pattern": {
+    "regexp": "^(.+?):(\\d+):(\\d+): (error|warning|info): (.*)$",
+    "file": 1,
+    "line": 2,
+    "column": 3,
+    "severity": 4,
+    "message": 5
+  }
+}`
+
+// writeProblems writes review threads in the "--format problems" layout, one
+// line per thread:
+//
+//	path:line:column: severity: message
+//
+// Paths are relative to the repository root and columns are always 1.
+// File-level threads point at line 1. Severity is error for critical or
+// major bot findings, info for resolved threads, and warning otherwise.
+// Issue comments have no location and are skipped.
+func writeProblems(w io.Writer, comments []unifiedComment) {
+	var threads []unifiedComment
+	for _, c := range comments {
+		if c.Type == "review_comment" {
+			threads = append(threads, c)
+		}
+	}
+	sort.SliceStable(threads, func(i, j int) bool {
+		a, b := threads[i], threads[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.currentLine < b.currentLine
+	})
+
+	for _, c := range threads {
+		line := max(c.currentLine, 1)
+		level := "warning"
+		switch {
+		case c.Resolved == "true":
+			level = "info"
+		case c.Severity >= severity.Major:
+			level = "error"
+		}
+		message := fmt.Sprintf("%s: %s (%d", c.Author, strings.Join(strings.Fields(c.Body), " "), c.ID)
+		switch n := len(c.Replies); {
+		case n == 1:
+			message += ", 1 reply"
+		case n > 1:
+			message += fmt.Sprintf(", %d replies", n)
+		}
+		message += ")"
+		if c.PR != 0 {
+			message = fmt.Sprintf("#%d %s", c.PR, message)
+		}
+		fmt.Fprintf(w, "%s:%d:1: %s: %s\n", c.File, line, level, message)
+	}
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	queueJsonOutput bool
+	queueQuery      string
+)
+
+var queueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "List PRs awaiting your review with your open threads",
+	Long: `List open pull requests where your review is requested, along with how
+many unresolved threads you started on each.
+
+PRs with threads you opened that are still unresolved are good candidates
+for a follow-up pass.
+
+Examples:
+  gh pr-comments queue
+  gh pr-comments queue --query "repo:owner/repo"
+  gh pr-comments queue --json`,
+	Args: cobra.NoArgs,
+	RunE: runQueue,
+}
+
+func init() {
+	queueCmd.Flags().BoolVar(&queueJsonOutput, "json", false, "Output in JSON format")
+	queueCmd.Flags().StringVar(&queueQuery, "query", "", "Additional search qualifiers (e.g., \"org:my-org\")")
+	rootCmd.AddCommand(queueCmd)
+}
+
+type queueItem struct {
+	Repo              string `json:"repo"`
+	PR                int    `json:"pr"`
+	Title             string `json:"title"`
+	Author            string `json:"author"`
+	URL               string `json:"url"`
+	MyThreads         int    `json:"my_threads"`
+	MyUnresolved      int    `json:"my_unresolved_threads"`
+	UnresolvedThreads int    `json:"unresolved_threads"`
+	UpdatedAt         string `json:"updated_at"`
+}
+
+func runQueue(cmd *cobra.Command, args []string) error {
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	me, err := client.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	query := "is:open review-requested:@me"
+	if queueQuery != "" {
+		query += " " + queueQuery
+	}
+
+	prs, err := client.SearchPullRequests(query)
+	if err != nil {
+		return err
+	}
+
+	var items []queueItem
+	for _, pr := range prs {
+		prRef := pr.PRReference()
+		threads, err := client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
+		if err != nil {
+			return fmt.Errorf("get review threads for %s/%s#%d: %w", prRef.Owner, prRef.Repo, prRef.Number, err)
+		}
+
+		item := queueItem{
+			Repo:      prRef.Owner + "/" + prRef.Repo,
+			PR:        prRef.Number,
+			Title:     pr.Title,
+			Author:    pr.User.Login,
+			URL:       pr.HTMLURL,
+			UpdatedAt: pr.UpdatedAt.Format("2006-01-02 15:04"),
+		}
+		for _, t := range threads {
+			if !t.IsResolved {
+				item.UnresolvedThreads++
+			}
+			if len(t.Comments) == 0 || !strings.EqualFold(t.Comments[0].Author, me.Login) {
+				continue
+			}
+			item.MyThreads++
+			if !t.IsResolved {
+				item.MyUnresolved++
+			}
+		}
+		items = append(items, item)
+	}
+
+	if queueJsonOutput {
+		return writeJSON(items)
+	}
+
+	if len(items) == 0 {
+		fmt.Println("No pull requests are waiting for your review.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REPO\tPR\tAUTHOR\tMY OPEN THREADS\tALL OPEN THREADS\tUPDATED\tTITLE")
+	for _, item := range items {
+		title := github.TruncateString(item.Title, 50)
+		fmt.Fprintf(w, "%s\t#%d\t%s\t%d/%d\t%d\t%s\t%s\n",
+			item.Repo, item.PR, item.Author, item.MyUnresolved, item.MyThreads, item.UnresolvedThreads, item.UpdatedAt, title)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	readyJsonOutput   bool
+	readyMinApprovals int
+)
+
+var readyCmd = &cobra.Command{
+	Use:   "ready [pr-reference]",
+	Short: "Check whether a PR is ready to merge",
+	Long: `Combine CI checks, review states, and review threads into a single verdict.
+
+A PR is ready when:
+  - it is not a draft
+  - every check run and commit status on the head commit has passed
+    (neutral and skipped count as passed)
+  - no reviewer's latest verdict is CHANGES_REQUESTED and at least
+    --min-approvals reviewers have approved
+  - no review thread is unresolved
+
+The command exits with status 0 when the PR is ready and 1 otherwise, so it
+can gate merge scripts directly. Checks still running make the PR not ready.
+
+If no PR reference is given, finds the PR for the current branch.
+
+PR reference can be:
+  - Full URL: https://github.com/owner/repo/pull/123
+  - Short form: owner/repo/123
+  - Just number: 123 (when in a repo context)
+  - Omitted: uses current branch's PR
+
+Examples:
+  gh pr-comments ready
+  gh pr-comments ready owner/repo/123 --min-approvals 2
+  gh pr-comments ready --json && gh pr merge --squash`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runReady,
+}
+
+func init() {
+	readyCmd.Flags().BoolVar(&readyJsonOutput, "json", false, "Output in JSON format")
+	readyCmd.Flags().IntVar(&readyMinApprovals, "min-approvals", 1, "Number of approving reviewers required")
+	rootCmd.AddCommand(readyCmd)
+}
+
+// readyCheck is one part of the verdict; Status is pass, fail, or pending.
+type readyCheck struct {
+	Name    string   `json:"name"`
+	Status  string   `json:"status"`
+	Detail  string   `json:"detail"`
+	Failing []string `json:"failing,omitempty"`
+}
+
+type readyOutput struct {
+	Number int          `json:"number"`
+	Title  string       `json:"title"`
+	Ready  bool         `json:"ready"`
+	Checks []readyCheck `json:"checks"`
+}
+
+func runReady(cmd *cobra.Command, args []string) error {
+	if err := requireLiveData("ready"); err != nil {
+		return err
+	}
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
+	if err != nil {
+		return err
+	}
+
+	pr, err := client.GetPullRequest(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	runs, err := client.GetCheckRuns(prRef.Owner, prRef.Repo, pr.Head.SHA)
+	if err != nil {
+		return err
+	}
+	statuses, err := client.GetCommitStatuses(prRef.Owner, prRef.Repo, pr.Head.SHA)
+	if err != nil {
+		return err
+	}
+
+	reviews, err := client.GetReviews(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	threads, err := client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return fmt.Errorf("get review threads: %w", err)
+	}
+
+	output := readyOutput{
+		Number: pr.Number,
+		Title:  pr.Title,
+		Checks: []readyCheck{
+			draftCheck(pr),
+			ciCheck(runs, statuses),
+			reviewCheck(latestReviewStates(reviews), readyMinApprovals),
+			threadCheck(threads),
+		},
+	}
+	output.Ready = true
+	for _, c := range output.Checks {
+		if c.Status != "pass" {
+			output.Ready = false
+		}
+	}
+
+	if readyJsonOutput {
+		if err := writeJSON(output); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("PR #%d: %s\n", output.Number, output.Title)
+		fmt.Println(strings.Repeat("─", 60))
+		for _, c := range output.Checks {
+			fmt.Printf("%-10s %-8s %s\n", "["+c.Status+"]", c.Name, c.Detail)
+			for _, name := range c.Failing {
+				fmt.Printf("           - %s\n", name)
+			}
+		}
+		fmt.Println(strings.Repeat("─", 60))
+		if output.Ready {
+			fmt.Println("Verdict: READY")
+		} else {
+			fmt.Println("Verdict: NOT READY")
+		}
+	}
+
+	if !output.Ready {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("PR #%d is not ready to merge", output.Number)
+	}
+	return nil
+}
+
+func draftCheck(pr *github.PullRequest) readyCheck {
+	if pr.Draft {
+		return readyCheck{Name: "draft", Status: "fail", Detail: "PR is a draft"}
+	}
+	return readyCheck{Name: "draft", Status: "pass", Detail: "ready for review"}
+}
+
+// ciCheck folds check runs and commit statuses into one result. Any failure
+// fails the check; otherwise anything unfinished leaves it pending.
+func ciCheck(runs []github.CheckRun, statuses []github.CommitStatus) readyCheck {
+	var passed int
+	var failing, pending []string
+	for _, r := range runs {
+		switch {
+		case r.Status != "completed":
+			pending = append(pending, r.Name)
+		case r.Conclusion == "success" || r.Conclusion == "neutral" || r.Conclusion == "skipped":
+			passed++
+		default:
+			failing = append(failing, fmt.Sprintf("%s (%s)", r.Name, r.Conclusion))
+		}
+	}
+	for _, s := range statuses {
+		switch s.State {
+		case "success":
+			passed++
+		case "pending":
+			pending = append(pending, s.Context)
+		default:
+			failing = append(failing, fmt.Sprintf("%s (%s)", s.Context, s.State))
+		}
+	}
+
+	check := readyCheck{Name: "checks"}
+	switch {
+	case len(failing) > 0:
+		check.Status = "fail"
+		check.Detail = fmt.Sprintf("%d failed, %d pending, %d passed", len(failing), len(pending), passed)
+		check.Failing = failing
+	case len(pending) > 0:
+		check.Status = "pending"
+		check.Detail = fmt.Sprintf("%d pending, %d passed", len(pending), passed)
+		check.Failing = pending
+	case passed == 0:
+		check.Status = "pass"
+		check.Detail = "no checks reported"
+	default:
+		check.Status = "pass"
+		check.Detail = fmt.Sprintf("%d passed", passed)
+	}
+	return check
+}
+
+// reviewCheck requires minApprovals approving reviewers and no outstanding
+// change requests, based on each reviewer's latest verdict.
+func reviewCheck(states map[string]string, minApprovals int) readyCheck {
+	var approvers, blockers []string
+	for login, state := range states {
+		switch state {
+		case "APPROVED":
+			approvers = append(approvers, login)
+		case "CHANGES_REQUESTED":
+			blockers = append(blockers, login)
+		}
+	}
+	sort.Strings(approvers)
+	sort.Strings(blockers)
+
+	check := readyCheck{Name: "reviews"}
+	switch {
+	case len(blockers) > 0:
+		check.Status = "fail"
+		check.Detail = "changes requested by " + strings.Join(blockers, ", ")
+	case len(approvers) < minApprovals:
+		check.Status = "fail"
+		check.Detail = fmt.Sprintf("%d of %d required approval(s)", len(approvers), minApprovals)
+	default:
+		check.Status = "pass"
+		check.Detail = fmt.Sprintf("%d approval(s)", len(approvers))
+		if len(approvers) > 0 {
+			check.Detail += ": " + strings.Join(approvers, ", ")
+		}
+	}
+	return check
+}
+
+func threadCheck(threads []github.ReviewThread) readyCheck {
+	var unresolved []string
+	for _, t := range threads {
+		if !t.IsResolved {
+			unresolved = append(unresolved, t.Location())
+		}
+	}
+	if len(unresolved) > 0 {
+		return readyCheck{
+			Name:    "threads",
+			Status:  "fail",
+			Detail:  fmt.Sprintf("%d unresolved thread(s)", len(unresolved)),
+			Failing: unresolved,
+		}
+	}
+	return readyCheck{Name: "threads", Status: "pass", Detail: fmt.Sprintf("all %d thread(s) resolved", len(threads))}
+}
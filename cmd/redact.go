@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/redact"
+)
+
+// outputRedactor returns the redactor for report and export output: the
+// built-in token patterns plus every pr-comments.redact git config value.
+// It returns nil, which redacts nothing, when disabled.
+func outputRedactor(disabled bool) (*redact.Redactor, error) {
+	if disabled {
+		return nil, nil
+	}
+	return redact.New(github.GitConfigAll("pr-comments.redact"))
+}
+
+// redactText redacts s and notes on stderr how many secrets were masked.
+func redactText(r *redact.Redactor, s string) string {
+	redacted := r.String(s)
+	warnRedacted(strings.Count(redacted, redact.Placeholder) - strings.Count(s, redact.Placeholder))
+	return redacted
+}
+
+// writeRedactedJSON is writeJSON with secrets in string values masked.
+func writeRedactedJSON(r *redact.Redactor, v interface{}) error {
+	n, err := encodeRedactedJSON(os.Stdout, r, v)
+	warnRedacted(n)
+	return err
+}
+
+// encodeRedactedJSON is encodeJSON with secrets in string values masked. It
+// returns how many secrets were masked.
+func encodeRedactedJSON(w io.Writer, r *redact.Redactor, v interface{}) (int, error) {
+	var buf bytes.Buffer
+	if err := encodeJSON(&buf, v); err != nil {
+		return 0, err
+	}
+	redacted := r.JSON(buf.Bytes())
+	n := bytes.Count(redacted, []byte(redact.Placeholder)) - bytes.Count(buf.Bytes(), []byte(redact.Placeholder))
+	_, err := w.Write(redacted)
+	return n, err
+}
+
+func warnRedacted(n int) {
+	if n > 0 {
+		fmt.Fprintf(os.Stderr, "Redacted %d possible secret(s); use --no-redact to keep them\n", n)
+	}
+}
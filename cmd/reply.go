@@ -1,21 +1,31 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/journal"
+	"github.com/STRRL/gh-pr-comments/internal/suggestion"
 	"github.com/spf13/cobra"
 )
 
 var (
-	replyBody       string
-	replyPR         string
-	replyJsonOutput bool
+	replyBody        string
+	replyPR          string
+	replyJsonOutput  bool
+	replyIssue       bool
+	replyNoSignature bool
+	replySuggestFrom string
+	replyAttach      []string
+	replyPushAttach  bool
 )
 
 var replyCmd = &cobra.Command{
@@ -27,7 +37,33 @@ The reply will be added as a threaded response to the specified review comment.
 The comment-id can be found from the 'list', 'view', or 'tree' command output.
 
 Note: Only review comments (inline code comments) support threaded replies.
-Issue comments (general PR comments) do not support threading.
+Issue comments (general PR comments) do not support threading. With --issue,
+the comment-id names an issue comment instead, and the reply is emulated: a
+new general comment is posted that quotes the original and links to it.
+
+--suggest-from path:start-end reads those lines (1-based, inclusive) of a
+file in the working tree and posts them as a suggestion block, which the
+reviewer can commit from GitHub. Text from --body, if any, goes above it.
+Use path:line for a single line.
+
+--attach uploads a file, such as a screenshot of the fix, and embeds it in
+the reply: images are shown inline, other files are linked. GitHub's upload
+flow for comment attachments is not available to API tokens, so files are
+committed to the ` + github.AttachmentsBranch + ` branch of the
+PR's head repository (your fork, for a PR from a fork) and served from
+there; anyone who can read that repository can see them, and the commits
+stay in its history. Because this pushes a branch, it must be enabled with
+--push-attachments or the pr-comments.pushAttachments git config. Repeat
+--attach for several files.
+
+A signature, such as a team tag or "sent via gh-pr-comments", is appended
+to every reply when configured with the pr-comments.signature git config
+or the GH_PR_COMMENTS_SIGNATURE environment variable (which wins).
+--no-signature leaves it off for one reply.
+
+@mentions of users who are neither collaborators on the repository nor
+taking part in the PR are warned about, as they are likely typos. Shell
+completion of --body completes a trailing @mention.
 
 Examples:
   # Reply using --body flag
@@ -39,6 +75,19 @@ Examples:
   # Specify PR explicitly
   gh pr-comments reply 2621968472 --pr owner/repo/99 --body "Fixed"
 
+  # Propose the local version of lines 40-52 as a suggestion
+  gh pr-comments reply 2621968472 --suggest-from internal/github/client.go:40-52 --body "How about this?"
+
+  # Show what it looks like now
+  gh pr-comments reply 2621968472 --body "Updated layout:" --attach screenshot.png --push-attachments
+
+  # Reply to a general PR comment by quoting it
+  gh pr-comments reply --issue 3581523351 --body "Good point, done."
+
+  # Configure a footer for every reply, or skip it once
+  git config --global pr-comments.signature "— sent via gh-pr-comments"
+  gh pr-comments reply 2621968472 --body "Done" --no-signature
+
   # Reply with JSON output
   gh pr-comments reply 2621968472 --body "Done" --json`,
 	Args:              cobra.ExactArgs(1),
@@ -49,7 +98,14 @@ Examples:
 func init() {
 	replyCmd.Flags().StringVar(&replyBody, "body", "", "Reply message body (reads from stdin if not provided)")
 	replyCmd.Flags().StringVar(&replyPR, "pr", "", "PR reference (e.g., owner/repo/123 or just 123)")
+	replyCmd.RegisterFlagCompletionFunc("pr", completePRs)
+	replyCmd.RegisterFlagCompletionFunc("body", completeBodyMentions)
 	replyCmd.Flags().BoolVar(&replyJsonOutput, "json", false, "Output in JSON format")
+	replyCmd.Flags().BoolVar(&replyIssue, "issue", false, "Reply to an issue comment by posting a comment that quotes it")
+	replyCmd.Flags().StringVar(&replySuggestFrom, "suggest-from", "", "Post lines of a local file as a suggestion (path:start-end)")
+	replyCmd.Flags().StringArrayVar(&replyAttach, "attach", nil, "Upload a file and embed it in the reply (repeatable)")
+	replyCmd.Flags().BoolVar(&replyPushAttach, "push-attachments", false, "Allow --attach to push files to a branch of the PR's head repository")
+	replyCmd.Flags().BoolVar(&replyNoSignature, "no-signature", false, "Do not append the configured signature")
 	rootCmd.AddCommand(replyCmd)
 }
 
@@ -65,10 +121,36 @@ func runReply(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid comment ID: %s", commentIDStr)
 	}
 
-	body, err := getReplyBody()
+	if replySuggestFrom != "" && replyIssue {
+		return fmt.Errorf("--suggest-from cannot be used with --issue; suggestions only work in review threads")
+	}
+
+	attachments, err := readAttachments(replyAttach)
 	if err != nil {
 		return err
 	}
+	if len(attachments) > 0 && !replyPushAttach && !github.GitConfigBool("pr-comments.pushAttachments") {
+		return fmt.Errorf("--attach pushes files to the %s branch of the PR's head repository\nPass --push-attachments, or run 'git config pr-comments.pushAttachments true', to allow it", github.AttachmentsBranch)
+	}
+
+	var body string
+	if replySuggestFrom != "" || len(attachments) > 0 {
+		// The suggestion or attachment is the reply; --body adds text
+		// above it.
+		body = strings.TrimSpace(replyBody)
+		if replySuggestFrom != "" {
+			block, err := suggestionFromFile(replySuggestFrom)
+			if err != nil {
+				return err
+			}
+			body = joinParagraphs(body, block)
+		}
+	} else {
+		body, err = readBody(replyBody, "reply body")
+		if err != nil {
+			return err
+		}
+	}
 
 	var prArgs []string
 	if replyPR != "" {
@@ -80,32 +162,235 @@ func runReply(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("could not determine PR: %w\nPlease specify a PR with --pr or run from a branch with an associated PR", err)
 	}
 
-	found, err := findReviewComment(client, prRef, commentID)
+	// Check the target first so nothing is uploaded for a reply that
+	// cannot be posted.
+	var original *github.IssueComment
+	if replyIssue {
+		original, err = findIssueComment(client, prRef, commentID)
+		if err != nil {
+			return err
+		}
+	} else {
+		found, err := findReviewComment(client, prRef, commentID)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("review comment with ID %d not found in PR %d\nNote: Only review comments support threaded replies; use --issue for general PR comments", commentID, prRef.Number)
+		}
+	}
+
+	if len(attachments) > 0 {
+		owner, repo, err := attachmentRepo(client, prRef)
+		if err != nil {
+			return err
+		}
+		for _, a := range attachments {
+			url, err := client.UploadAttachment(owner, repo, a.name, a.data)
+			if err != nil {
+				return err
+			}
+			body = joinParagraphs(body, a.markdown(url))
+		}
+	}
+	if !replyNoSignature {
+		body = withSignature(body)
+	}
+	warnUnknownMentions(client, prRef, body)
+
+	if replyIssue {
+		return replyToIssueComment(client, prRef, original, body)
+	}
+
+	reply, err := client.ReplyToReviewComment(prRef.Owner, prRef.Repo, prRef.Number, commentID, body)
 	if err != nil {
 		return err
 	}
-	if !found {
-		return fmt.Errorf("review comment with ID %d not found in PR %d\nNote: Only review comments support threaded replies", commentID, prRef.Number)
+	journalAction(prRef, journal.Entry{Action: "reply", CommentID: reply.ID, URL: reply.HTMLURL})
+
+	if replyJsonOutput {
+		return writeJSON(reply)
+	}
+
+	printReplySuccess(reply.ID, reply.User.Login, reply.CreatedAt, reply.HTMLURL, body)
+	return nil
+}
+
+// findIssueComment returns the general PR comment with the given ID.
+func findIssueComment(client *github.Client, prRef *github.PRReference, commentID int64) (*github.IssueComment, error) {
+	comments, err := client.GetIssueComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
 	}
+	for i := range comments {
+		if comments[i].ID == commentID {
+			return &comments[i], nil
+		}
+	}
+	return nil, fmt.Errorf("issue comment with ID %d not found in PR %d", commentID, prRef.Number)
+}
 
-	reply, err := client.ReplyToReviewComment(prRef.Owner, prRef.Repo, prRef.Number, commentID, body)
+// replyToIssueComment emulates a threaded reply to an issue comment, which
+// the API does not support, by posting a comment that quotes it.
+func replyToIssueComment(client *github.Client, prRef *github.PRReference, original *github.IssueComment, body string) error {
+	reply, err := client.CreateIssueComment(prRef.Owner, prRef.Repo, prRef.Number, quoteReply(original, body))
 	if err != nil {
 		return err
 	}
+	journalAction(prRef, journal.Entry{Action: "issue_comment", CommentID: reply.ID, URL: reply.HTMLURL})
 
 	if replyJsonOutput {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(reply)
+		return writeJSON(reply)
 	}
 
-	printReplySuccess(reply, body)
+	printReplySuccess(reply.ID, reply.User.Login, reply.CreatedAt, reply.HTMLURL, body)
 	return nil
 }
 
-func getReplyBody() (string, error) {
-	if replyBody != "" {
-		return replyBody, nil
+// quotedReplyLines is how many lines of the original comment an emulated
+// reply quotes.
+const quotedReplyLines = 6
+
+// quoteReply builds the body of an emulated reply: a link to the original
+// comment, the start of its text as a quote, and the reply. Quotes in the
+// original, such as those of an earlier emulated reply, are left out.
+func quoteReply(original *github.IssueComment, body string) string {
+	var quoted []string
+	for _, line := range strings.Split(strings.TrimSpace(original.Body), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, ">") || (trimmed == "" && len(quoted) == 0) {
+			continue
+		}
+		if len(quoted) == quotedReplyLines {
+			quoted = append(quoted, "…")
+			break
+		}
+		quoted = append(quoted, line)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "> @%s [wrote](%s):\n", original.User.Login, original.HTMLURL)
+	for _, line := range quoted {
+		b.WriteString(strings.TrimRight("> "+line, " ") + "\n")
+	}
+	b.WriteString("\n" + body)
+	return b.String()
+}
+
+// suggestionFromFile reads the lines named by spec, "path:start-end" or
+// "path:line", from the working tree and wraps them in a suggestion block.
+func suggestionFromFile(spec string) (string, error) {
+	i := strings.LastIndex(spec, ":")
+	if i <= 0 {
+		return "", fmt.Errorf("invalid --suggest-from value: %s (expected path:start-end)", spec)
+	}
+	path, lines := spec[:i], spec[i+1:]
+	startStr, endStr, isRange := strings.Cut(lines, "-")
+	if !isRange {
+		endStr = startStr
+	}
+	start, err1 := strconv.Atoi(startStr)
+	end, err2 := strconv.Atoi(endStr)
+	if err1 != nil || err2 != nil || start < 1 || end < start {
+		return "", fmt.Errorf("invalid line range in --suggest-from: %s", lines)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read suggestion: %w", err)
+	}
+	fileLines := strings.Split(strings.TrimSuffix(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n"), "\n")
+	if end > len(fileLines) {
+		return "", fmt.Errorf("%s has %d line(s); cannot suggest lines %d-%d", path, len(fileLines), start, end)
+	}
+	return suggestion.Format(strings.Join(fileLines[start-1:end], "\n")), nil
+}
+
+// maxAttachmentSize is GitHub's limit for files attached to comments.
+const maxAttachmentSize = 25 << 20
+
+// imageExtensions are the attachments embedded as images rather than linked.
+var imageExtensions = []string{".png", ".jpg", ".jpeg", ".gif", ".svg", ".webp"}
+
+type replyAttachment struct {
+	name string
+	data []byte
+}
+
+// markdown embeds the attachment served at url.
+func (a replyAttachment) markdown(url string) string {
+	name := filepath.Base(a.name)
+	if slices.Contains(imageExtensions, strings.ToLower(filepath.Ext(name))) {
+		return fmt.Sprintf("![%s](%s)", name, url)
+	}
+	return fmt.Sprintf("[%s](%s)", name, url)
+}
+
+// attachmentRepo returns the repository attachments are pushed to: the PR's
+// head repository, which its author can write to even for a PR from a fork.
+func attachmentRepo(client *github.Client, prRef *github.PRReference) (owner, repo string, err error) {
+	pr, err := client.GetPullRequest(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return "", "", err
+	}
+	if pr.Head.Repo == nil {
+		return "", "", fmt.Errorf("the head repository of PR #%d was deleted; cannot attach files", prRef.Number)
+	}
+	return pr.Head.Repo.Owner.Login, pr.Head.Repo.Name, nil
+}
+
+// readAttachments reads the files to attach, before anything is posted.
+func readAttachments(paths []string) ([]replyAttachment, error) {
+	var attachments []replyAttachment
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("read attachment: %w", err)
+		}
+		if len(data) > maxAttachmentSize {
+			return nil, fmt.Errorf("%s is larger than 25 MB", p)
+		}
+		attachments = append(attachments, replyAttachment{name: p, data: data})
+	}
+	return attachments, nil
+}
+
+// joinParagraphs joins the non-empty parts with blank lines.
+func joinParagraphs(parts ...string) string {
+	var kept []string
+	for _, p := range parts {
+		if p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, "\n\n")
+}
+
+// replySignature returns the configured reply footer, or "" when none is
+// set.
+func replySignature() string {
+	if sig := os.Getenv("GH_PR_COMMENTS_SIGNATURE"); sig != "" {
+		return strings.TrimSpace(sig)
+	}
+	output, err := exec.Command("git", "config", "--get", "pr-comments.signature").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// withSignature appends the configured signature to a reply body, unless
+// the body already ends with it.
+func withSignature(body string) string {
+	sig := replySignature()
+	if sig == "" || strings.HasSuffix(strings.TrimSpace(body), sig) {
+		return body
+	}
+	return strings.TrimRight(body, "\n ") + "\n\n" + sig
+}
+
+func readBody(flagBody, what string) (string, error) {
+	if flagBody != "" {
+		return flagBody, nil
 	}
 
 	stat, err := os.Stdin.Stat()
@@ -124,7 +409,7 @@ func getReplyBody() (string, error) {
 		}
 	}
 
-	return "", fmt.Errorf("reply body required: use --body flag or pipe content via stdin")
+	return "", fmt.Errorf("%s required: use --body flag or pipe content via stdin", what)
 }
 
 func findReviewComment(client *github.Client, prRef *github.PRReference, commentID int64) (bool, error) {
@@ -142,13 +427,13 @@ func findReviewComment(client *github.Client, prRef *github.PRReference, comment
 	return false, nil
 }
 
-func printReplySuccess(reply *github.ReviewComment, body string) {
+func printReplySuccess(id int64, author string, created time.Time, url, body string) {
 	fmt.Println("Reply created successfully!")
 	fmt.Println(strings.Repeat("─", 60))
-	fmt.Printf("ID:      %d\n", reply.ID)
-	fmt.Printf("Author:  %s\n", reply.User.Login)
-	fmt.Printf("Created: %s\n", reply.CreatedAt.Format("2006-01-02 15:04:05"))
-	fmt.Printf("URL:     %s\n", reply.HTMLURL)
+	fmt.Printf("ID:      %d\n", id)
+	fmt.Printf("Author:  %s\n", author)
+	fmt.Printf("Created: %s\n", created.Format("2006-01-02 15:04:05"))
+	fmt.Printf("URL:     %s\n", url)
 	fmt.Println(strings.Repeat("─", 60))
 	fmt.Println()
 	fmt.Println(body)
@@ -1,13 +1,15 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
 
 	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/journal"
+	"github.com/STRRL/gh-pr-comments/internal/state"
+	"github.com/STRRL/gh-pr-comments/internal/trailer"
 	"github.com/spf13/cobra"
 )
 
@@ -19,12 +21,17 @@ type CleanupInfo struct {
 }
 
 var (
-	resolvePR         string
-	resolveJsonOutput bool
+	resolvePR          string
+	resolveJsonOutput  bool
+	resolveInteractive bool
+	resolveThreadIDs   []string
+	resolveFromCommits bool
 )
 
+const resolveCommitsStateFile = "resolve-commits.json"
+
 var resolveCmd = &cobra.Command{
-	Use:               "resolve <comment-id> [comment-id...]",
+	Use:               "resolve [comment-id...]",
 	Short:             "Resolve review threads",
 	ValidArgsFunction: completeReviewCommentIDs,
 	Long:              `Mark review comment threads as resolved.
@@ -33,9 +40,20 @@ The comment-id(s) can be found from the 'list', 'view', or 'tree' command output
 Each comment belongs to a review thread, and this command resolves the
 entire thread containing the specified comment.
 
+Threads that are already resolved are reported as skipped (already_resolved)
+without calling the API, so repeated runs are safe.
+
 After resolving, this command automatically minimizes (hides) any reviews where
 all inline comments are now resolved. This helps reduce noise in the PR timeline.
 
+With --from-commits, the PR's commits are scanned for trailers of the form
+
+  Resolves-Comment: 2621968472
+
+(or the comment's permalink, as printed by 'trailer') and the referenced
+threads are resolved. Commits already scanned by a previous
+--from-commits run are remembered in a local state file and skipped.
+
 Examples:
   # Resolve a single thread
   gh pr-comments resolve 2621968472
@@ -46,28 +64,58 @@ Examples:
   # Specify PR explicitly
   gh pr-comments resolve 2621968472 --pr owner/repo/99
 
+  # Resolve by thread ID (from the 'threads' command)
+  gh pr-comments resolve --thread PRRT_kwDOABCD1234
+
+  # Pick threads to resolve from a list
+  gh pr-comments resolve --interactive
+
+  # Resolve threads named by "Resolves-Comment: <id>" trailers in commits
+  # pushed since the last --from-commits run
+  gh pr-comments resolve --from-commits
+
   # Get JSON output
   gh pr-comments resolve 2621968472 --json`,
-	Args: cobra.MinimumNArgs(1),
+	Args: func(cmd *cobra.Command, args []string) error {
+		if resolveInteractive || resolveFromCommits || len(resolveThreadIDs) > 0 {
+			return nil
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
 	RunE: runResolve,
 }
 
 func init() {
 	resolveCmd.Flags().StringVar(&resolvePR, "pr", "", "PR reference (e.g., owner/repo/123 or just 123)")
+	resolveCmd.RegisterFlagCompletionFunc("pr", completePRs)
 	resolveCmd.Flags().BoolVar(&resolveJsonOutput, "json", false, "Output in JSON format")
+	resolveCmd.Flags().BoolVarP(&resolveInteractive, "interactive", "i", false, "Pick unresolved comments to resolve from a list")
+	resolveCmd.Flags().StringSliceVar(&resolveThreadIDs, "thread", nil, "Review thread ID to resolve (repeatable)")
+	resolveCmd.RegisterFlagCompletionFunc("thread", completeThreadIDs)
+	resolveCmd.Flags().BoolVar(&resolveFromCommits, "from-commits", false, "Resolve threads referenced by Resolves-Comment trailers in new commits")
 	rootCmd.AddCommand(resolveCmd)
 }
 
 type ResolveResult struct {
-	CommentID int64  `json:"comment_id"`
+	CommentID int64  `json:"comment_id,omitempty"`
 	ThreadID  string `json:"thread_id"`
 	Action    string `json:"action"`
 	Success   bool   `json:"success"`
 	Skipped   bool   `json:"skipped,omitempty"`
+	Reason    string `json:"reason,omitempty"`
 	Error     string `json:"error,omitempty"`
 }
 
+// Reasons a resolve was skipped without calling the API.
+const (
+	skipSameThread      = "same_thread"
+	skipAlreadyResolved = "already_resolved"
+)
+
 func runResolve(cmd *cobra.Command, args []string) error {
+	if err := requireLiveData("resolve"); err != nil {
+		return err
+	}
 	client, err := github.NewClient()
 	if err != nil {
 		return err
@@ -92,13 +140,53 @@ func runResolve(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("could not determine PR: %w\nPlease specify a PR with --pr or run from a branch with an associated PR", err)
 	}
 
+	if resolveInteractive {
+		picked, err := pickCommentsToResolve(client, prRef)
+		if err != nil {
+			return err
+		}
+		commentIDs = append(commentIDs, picked...)
+		if len(commentIDs) == 0 && len(resolveThreadIDs) == 0 {
+			fmt.Println("Nothing selected.")
+			return nil
+		}
+	}
+
+	var newCommits []trailerCommit
+	if resolveFromCommits {
+		newCommits, err = commentsFromNewCommits(client, prRef)
+		if err != nil {
+			return err
+		}
+		var referenced []int64
+		for _, c := range newCommits {
+			referenced = append(referenced, c.commentIDs...)
+		}
+		if len(referenced) == 0 && len(commentIDs) == 0 && len(resolveThreadIDs) == 0 {
+			// Commits without trailers never need another look.
+			if err := markCommitsScanned(prRef, newCommits, nil); err != nil {
+				return err
+			}
+			if resolveJsonOutput {
+				return writeJSON(map[string]interface{}{"results": []ResolveResult{}})
+			}
+			fmt.Printf("No %s trailers in new commits.\n", trailer.ResolvesComment)
+			return nil
+		}
+		commentIDs = append(commentIDs, referenced...)
+	}
+
 	threads, err := client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
 	if err != nil {
 		return fmt.Errorf("get review threads: %w", err)
 	}
 
 	commentToThread := make(map[int64]string)
+	knownThreads := make(map[string]bool)
+	resolvedThreads := make(map[string]bool)
 	for _, t := range threads {
+		knownThreads[t.ID] = true
+		resolvedThreads[t.ID] = t.IsResolved
 		for _, cid := range t.CommentIDs {
 			commentToThread[cid] = t.ID
 		}
@@ -109,6 +197,47 @@ func runResolve(cmd *cobra.Command, args []string) error {
 	var results []ResolveResult
 	processedThreads := make(map[string]bool)
 
+	for _, threadID := range resolveThreadIDs {
+		if !knownThreads[threadID] {
+			results = append(results, ResolveResult{
+				ThreadID: threadID,
+				Action:   action,
+				Success:  false,
+				Error:    "thread not found in this pull request",
+			})
+			continue
+		}
+		if processedThreads[threadID] {
+			continue
+		}
+		processedThreads[threadID] = true
+
+		if resolvedThreads[threadID] {
+			results = append(results, ResolveResult{
+				ThreadID: threadID,
+				Action:   action,
+				Success:  true,
+				Skipped:  true,
+				Reason:   skipAlreadyResolved,
+			})
+			continue
+		}
+
+		err := client.ResolveThread(threadID)
+		if err == nil {
+			journalAction(prRef, journal.Entry{Action: "resolve", ThreadID: threadID})
+		}
+		result := ResolveResult{
+			ThreadID: threadID,
+			Action:   action,
+			Success:  err == nil,
+		}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+
 	for _, commentID := range commentIDs {
 		threadID, ok := commentToThread[commentID]
 		if !ok {
@@ -128,12 +257,28 @@ func runResolve(cmd *cobra.Command, args []string) error {
 				Action:    action,
 				Success:   true,
 				Skipped:   true,
+				Reason:    skipSameThread,
 			})
 			continue
 		}
 		processedThreads[threadID] = true
 
+		if resolvedThreads[threadID] {
+			results = append(results, ResolveResult{
+				CommentID: commentID,
+				ThreadID:  threadID,
+				Action:    action,
+				Success:   true,
+				Skipped:   true,
+				Reason:    skipAlreadyResolved,
+			})
+			continue
+		}
+
 		err := client.ResolveThread(threadID)
+		if err == nil {
+			journalAction(prRef, journal.Entry{Action: "resolve", ThreadID: threadID})
+		}
 
 		result := ResolveResult{
 			CommentID: commentID,
@@ -147,6 +292,12 @@ func runResolve(cmd *cobra.Command, args []string) error {
 		results = append(results, result)
 	}
 
+	if resolveFromCommits {
+		if err := markCommitsScanned(prRef, newCommits, results); err != nil {
+			return err
+		}
+	}
+
 	cleanupResults := performAutoCleanup(client, prRef)
 
 	if resolveJsonOutput {
@@ -157,16 +308,120 @@ func runResolve(cmd *cobra.Command, args []string) error {
 			Results: results,
 			Cleanup: cleanupResults,
 		}
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(output)
+		return writeJSON(output)
 	}
 
 	printResolveResults(results, action, cleanupResults)
 	return nil
 }
 
+// trailerCommit is a PR commit with the comment IDs its Resolves-Comment
+// trailers name.
+type trailerCommit struct {
+	sha        string
+	commentIDs []int64
+}
+
+// commentsFromNewCommits returns the PR commits not seen by an earlier run,
+// with the comment IDs their Resolves-Comment trailers name. They are only
+// recorded as seen by markCommitsScanned, once their threads are resolved.
+func commentsFromNewCommits(client *github.Client, prRef *github.PRReference) ([]trailerCommit, error) {
+	commits, err := client.GetPRCommits(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string][]string{}
+	if err := state.Load(resolveCommitsStateFile, &seen); err != nil {
+		return nil, err
+	}
+	key := state.PRKey(prRef.Owner, prRef.Repo, prRef.Number)
+	scanned := make(map[string]bool)
+	for _, sha := range seen[key] {
+		scanned[sha] = true
+	}
+
+	var newCommits []trailerCommit
+	for _, c := range commits {
+		if !scanned[c.SHA] {
+			newCommits = append(newCommits, trailerCommit{sha: c.SHA, commentIDs: trailer.ResolvedComments(c.Commit.Message)})
+		}
+	}
+	return newCommits, nil
+}
+
+// markCommitsScanned records as seen the commits whose referenced threads
+// are now resolved, so a later run retries only the commits with a thread
+// that failed to resolve. Comments that are in no review thread will never
+// resolve and do not hold their commit back.
+func markCommitsScanned(prRef *github.PRReference, commits []trailerCommit, results []ResolveResult) error {
+	failedThreads := make(map[string]bool)
+	threadOf := make(map[int64]string)
+	for _, r := range results {
+		if r.ThreadID == "" {
+			continue
+		}
+		if !r.Success {
+			failedThreads[r.ThreadID] = true
+		}
+		if r.CommentID != 0 {
+			threadOf[r.CommentID] = r.ThreadID
+		}
+	}
+
+	seen := map[string][]string{}
+	if err := state.Load(resolveCommitsStateFile, &seen); err != nil {
+		return err
+	}
+	key := state.PRKey(prRef.Owner, prRef.Repo, prRef.Number)
+commits:
+	for _, c := range commits {
+		for _, id := range c.commentIDs {
+			if failedThreads[threadOf[id]] {
+				continue commits
+			}
+		}
+		seen[key] = append(seen[key], c.sha)
+	}
+	return state.Save(resolveCommitsStateFile, seen)
+}
+
+func pickCommentsToResolve(client *github.Client, prRef *github.PRReference) ([]int64, error) {
+	comments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []github.ReviewComment
+	var options []string
+	for _, c := range comments {
+		if c.IsResolved {
+			continue
+		}
+		candidates = append(candidates, c)
+		options = append(options, pickerLabel(c.ID, c.Location(), c.User.Login, c.Body))
+	}
+
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	selected, err := pickMany("Select comments whose threads should be resolved", options)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int64
+	for _, i := range selected {
+		ids = append(ids, candidates[i].ID)
+	}
+	return ids, nil
+}
+
 func performAutoCleanup(client *github.Client, prRef *github.PRReference) []CleanupInfo {
+	// Hiding a review whose hidden state is unknown could hide it twice and
+	// journal a hide that undo would wrongly revert.
+	client.RequireCompleteStatus()
 	reviews, err := client.GetReviews(prRef.Owner, prRef.Repo, prRef.Number)
 	if err != nil {
 		return nil
@@ -186,7 +441,7 @@ func performAutoCleanup(client *github.Client, prRef *github.PRReference) []Clea
 
 	for _, r := range reviews {
 		comments := commentsByReview[r.ID]
-		if len(comments) == 0 {
+		if len(comments) == 0 || r.IsMinimized {
 			continue
 		}
 
@@ -218,6 +473,7 @@ func performAutoCleanup(client *github.Client, prRef *github.PRReference) []Clea
 			info.Error = err.Error()
 		} else {
 			info.Minimized = true
+			journalAction(prRef, journal.Entry{Action: "hide", NodeID: r.NodeID, Reason: string(github.ClassifierResolved)})
 		}
 
 		cleanupResults = append(cleanupResults, info)
@@ -229,19 +485,35 @@ func performAutoCleanup(client *github.Client, prRef *github.PRReference) []Clea
 func printResolveResults(results []ResolveResult, action string, cleanupResults []CleanupInfo) {
 	successCount := 0
 	skippedCount := 0
+	alreadyCount := 0
 	failCount := 0
 
 	for _, r := range results {
-		if r.Skipped {
+		if r.Skipped && r.Reason == skipAlreadyResolved {
+			alreadyCount++
+			if r.CommentID == 0 {
+				fmt.Printf("Skipped thread %s: %s\n", r.ThreadID, r.Reason)
+			} else {
+				fmt.Printf("Skipped comment %d: %s\n", r.CommentID, r.Reason)
+			}
+		} else if r.Skipped {
 			skippedCount++
 			fmt.Printf("Skipped comment %d (thread already processed)\n", r.CommentID)
 		} else if r.Success {
 			successCount++
-			fmt.Printf("Thread %s for comment %d\n", action, r.CommentID)
+			if r.CommentID == 0 {
+				fmt.Printf("Thread %s %s\n", r.ThreadID, action)
+			} else {
+				fmt.Printf("Thread %s for comment %d\n", action, r.CommentID)
+			}
 		} else {
 			failCount++
-			fmt.Fprintf(os.Stderr, "Failed to resolve thread for comment %d: %s\n",
-				r.CommentID, r.Error)
+			if r.CommentID == 0 {
+				fmt.Fprintf(os.Stderr, "Failed to resolve thread %s: %s\n", r.ThreadID, r.Error)
+			} else {
+				fmt.Fprintf(os.Stderr, "Failed to resolve thread for comment %d: %s\n",
+					r.CommentID, r.Error)
+			}
 		}
 	}
 
@@ -252,6 +524,9 @@ func printResolveResults(results []ResolveResult, action string, cleanupResults
 	if skippedCount > 0 {
 		fmt.Printf("Skipped: %d comment(s) (same thread)\n", skippedCount)
 	}
+	if alreadyCount > 0 {
+		fmt.Printf("Skipped: %d thread(s) (already resolved)\n", alreadyCount)
+	}
 	if failCount > 0 {
 		fmt.Printf("Failed: %d thread(s)\n", failCount)
 	}
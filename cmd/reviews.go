@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"text/tabwriter"
@@ -10,12 +9,22 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var reviewsJsonOutput bool
+var (
+	reviewsJsonOutput bool
+	reviewsLatest     bool
+)
 
 var reviewsCmd = &cobra.Command{
 	Use:   "reviews [pr-reference]",
 	Short: "List all reviews on a pull request",
-	Long: `List all reviews on a pull request with their states.
+	Long: `List all reviews on a pull request with their states and how many of
+their inline comments are resolved (e.g. 5/7). Reviews without inline
+comments show "-".
+
+With --latest, each reviewer is collapsed to the review that decides their
+current state: their most recent approval, change request, or dismissal, or
+their most recent comment if they have given no verdict. The RESOLVED column
+then counts inline comments across all of that reviewer's reviews.
 
 If no PR reference is given, finds the PR for the current branch.
 
@@ -29,13 +38,30 @@ Examples:
   gh pr-comments reviews
   gh pr-comments reviews https://github.com/owner/repo/pull/123
   gh pr-comments reviews owner/repo/123
-  gh pr-comments reviews 123`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runReviews,
+  gh pr-comments reviews 123
+  gh pr-comments reviews --latest`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runReviews,
 }
 
 func init() {
 	reviewsCmd.Flags().BoolVar(&reviewsJsonOutput, "json", false, "Output in JSON format")
+	reviewsCmd.Flags().BoolVar(&reviewsLatest, "latest", false, "Show only each reviewer's latest review state")
+}
+
+// reviewRow is a review with the resolution progress of its inline comments.
+type reviewRow struct {
+	github.Review
+	TotalComments    int `json:"total_comments"`
+	ResolvedComments int `json:"resolved_comments"`
+}
+
+func (r reviewRow) Progress() string {
+	if r.TotalComments == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%d", r.ResolvedComments, r.TotalComments)
 }
 
 func runReviews(cmd *cobra.Command, args []string) error {
@@ -44,7 +70,7 @@ func runReviews(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	prRef, err := client.ResolvePRReference(args)
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
 	if err != nil {
 		return err
 	}
@@ -54,26 +80,70 @@ func runReviews(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	reviewComments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	var rows []reviewRow
+	for _, c := range identifyCleanupCandidates(reviews, reviewComments) {
+		rows = append(rows, reviewRow{
+			Review:           c.Review,
+			TotalComments:    c.TotalCount,
+			ResolvedComments: c.ResolvedCount,
+		})
+	}
+
+	if reviewsLatest {
+		rows = latestReviewRows(rows)
+	}
+
 	if reviewsJsonOutput {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(reviews)
+		if rows == nil {
+			rows = []reviewRow{}
+		}
+		return writeJSON(rows)
 	}
 
-	if len(reviews) == 0 {
+	if len(rows) == 0 {
 		fmt.Println("No reviews found.")
 		return nil
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "ID\tSTATE\tAUTHOR\tSUBMITTED\tBODY")
-	for _, r := range reviews {
+	fmt.Fprintln(w, "ID\tSTATE\tAUTHOR\tSUBMITTED\tRESOLVED\tBODY")
+	for _, r := range rows {
 		submitted := ""
 		if !r.SubmittedAt.IsZero() {
 			submitted = r.SubmittedAt.Format("2006-01-02 15:04")
 		}
 		body := github.TruncateString(r.Body, 50)
-		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", r.ID, r.State, r.User.Login, submitted, body)
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", r.ID, r.State, r.User.Login, submitted, r.Progress(), body)
 	}
 	return w.Flush()
 }
+
+// latestReviewRows collapses rows to one per reviewer, keeping the review
+// picked by latestReviews and summing comment counts over all of the
+// reviewer's reviews.
+func latestReviewRows(rows []reviewRow) []reviewRow {
+	total := make(map[string]int)
+	resolved := make(map[string]int)
+	byID := make(map[int64]reviewRow)
+	reviews := make([]github.Review, 0, len(rows))
+	for _, r := range rows {
+		total[r.User.Login] += r.TotalComments
+		resolved[r.User.Login] += r.ResolvedComments
+		byID[r.ID] = r
+		reviews = append(reviews, r.Review)
+	}
+
+	var latest []reviewRow
+	for _, r := range latestReviews(reviews) {
+		row := byID[r.ID]
+		row.TotalComments = total[r.User.Login]
+		row.ResolvedComments = resolved[r.User.Login]
+		latest = append(latest, row)
+	}
+	return latest
+}
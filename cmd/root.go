@@ -1,11 +1,27 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"strings"
 
+	"github.com/STRRL/gh-pr-comments/internal/cache"
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/cli/go-gh/v2/pkg/term"
 	"github.com/spf13/cobra"
 )
 
+var (
+	rootPR      string
+	rootPRState string
+	rootPRBase  string
+	rootNoCache bool
+	rootOffline bool
+	rootAccount string
+	rootCwd     string
+)
+
 var rootCmd = &cobra.Command{
 	Use:   "gh-pr-comments",
 	Short: "Structured access to PR reviews and review comments",
@@ -59,17 +75,111 @@ Unlike the standard gh CLI, this extension provides:
 
   # Output as JSON
   gh pr-comments list --json
-  gh pr-comments tree --json`,
+  gh pr-comments tree --json
+
+  # Show a comment from a link copied from GitHub
+  gh pr-comments https://github.com/owner/repo/pull/123#discussion_r2621968472`,
+	Args:                       cobra.MaximumNArgs(1),
+	SuggestionsMinimumDistance: 2,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return cmd.Help()
+		}
+		cmd.SilenceUsage = true
+		if _, _, ok := github.ParseCommentURL(args[0]); !ok {
+			msg := fmt.Sprintf("unknown command %q for %q", args[0], cmd.CommandPath())
+			if suggestions := cmd.SuggestionsFor(args[0]); len(suggestions) > 0 {
+				msg += "\n\nDid you mean this?\n\t" + strings.Join(suggestions, "\n\t")
+			}
+			return errors.New(msg)
+		}
+		return runView(cmd, args)
+	},
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	if storedAt, ok := cache.ServedStale(); ok {
+		fmt.Fprintf(os.Stderr, "\nStale: shown from the local cache (oldest response from %s, %s), not live from GitHub.\n",
+			storedAt.Local().Format("2006-01-02 15:04"), cacheAge(storedAt))
+	}
+	if err != nil {
 		os.Exit(1)
 	}
 }
 
+// withGlobalPR returns the positional PR reference if one was given, and
+// otherwise the global --pr flag.
+func withGlobalPR(args []string) []string {
+	if len(args) == 0 && rootPR != "" {
+		return []string{rootPR}
+	}
+	return args
+}
+
 func init() {
+	rootCmd.PersistentFlags().StringVar(&rootPR, "pr", "", "PR reference (e.g., owner/repo/123 or just 123)")
+	rootCmd.RegisterFlagCompletionFunc("pr", completePRs)
+	rootCmd.PersistentFlags().StringVar(&rootPRState, "pr-state", "", "When detecting the branch's PR, only consider PRs in this state (open/closed/merged)")
+	rootCmd.PersistentFlags().StringVar(&rootPRBase, "pr-base", "", "When detecting the branch's PR, only consider PRs into this base branch")
+	rootCmd.PersistentFlags().BoolVar(&rootNoCache, "no-cache", false, "Fetch everything from GitHub instead of the local response cache")
+	rootCmd.PersistentFlags().BoolVar(&rootOffline, "offline", false, "Read only from the local response cache, however old, and never contact GitHub")
+	rootCmd.MarkFlagsMutuallyExclusive("no-cache", "offline")
+	rootCmd.PersistentFlags().StringVar(&rootAccount, "account", "", "gh account to act as: login, host, or login@host (default $GH_PR_COMMENTS_ACCOUNT or git config pr-comments.account)")
+	rootCmd.RegisterFlagCompletionFunc("pr-state", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"open\tOpen PRs", "closed\tClosed without merging", "merged\tMerged PRs"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	rootCmd.PersistentFlags().StringVarP(&rootCwd, "cwd", "C", "", "Run as if started in this directory (like git -C)")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := applyRootFlags(); err != nil {
+			return err
+		}
+		// Only prompt when someone can answer, and never while completing.
+		if cmd.Name() != cobra.ShellCompRequestCmd && term.IsTerminal(os.Stdin) && term.IsTerminal(os.Stderr) {
+			github.BranchPRs.Choose = choosePR
+		}
+		return nil
+	}
 	rootCmd.AddCommand(reviewsCmd)
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(treeCmd)
 }
+
+// applyRootFlags applies the global flags. Cobra does not run
+// PersistentPreRunE for shell completion, so completion functions call it
+// through completionClient.
+func applyRootFlags() error {
+	// Everything that looks at the local repository (git, remote and
+	// branch detection, relative file arguments) then sees this
+	// directory, exactly as with git -C.
+	if rootCwd != "" {
+		if err := os.Chdir(rootCwd); err != nil {
+			return fmt.Errorf("invalid --cwd: %w", err)
+		}
+	}
+	switch rootPRState {
+	case "", "open", "closed", "merged":
+	default:
+		return fmt.Errorf("invalid --pr-state value: %s (valid: open, closed, merged)", rootPRState)
+	}
+	github.BranchPRs = github.BranchPROptions{State: rootPRState, Base: rootPRBase}
+	github.NoCache = rootNoCache
+	github.Offline = rootOffline
+	github.Account = rootAccount
+	if github.Account == "" {
+		github.Account = github.ConfiguredAccount()
+	}
+	return nil
+}
+
+// requireLiveData makes a command read from GitHub directly instead of the
+// response cache. Commands that act on what they read, or whose answer must
+// reflect GitHub right now, call it before creating their client; they
+// cannot run with --offline.
+func requireLiveData(command string) error {
+	if github.Offline {
+		return fmt.Errorf("%s needs live data from GitHub and cannot run with --offline", command)
+	}
+	github.NoCache = true
+	return nil
+}
@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var roundsJsonOutput bool
+
+var roundsCmd = &cobra.Command{
+	Use:   "rounds [pr-reference]",
+	Short: "Group reviews and comments into rounds delimited by pushes",
+	Long: `Show the back-and-forth of a pull request as review rounds. A round
+starts when new commits are pushed (or the branch is force-pushed) after
+feedback, and collects the reviews, review threads, replies, and general
+comments that followed until the next push.
+
+For each round the RESOLVED column shows how many of the threads opened in
+that round are resolved now, so you can see which rounds of feedback are
+done and which still have work left.
+
+Pushes are taken from the commits and force pushes in the PR timeline;
+several commits pushed with no feedback in between count as one push.
+
+If no PR reference is given, finds the PR for the current branch.
+
+PR reference can be:
+  - Full URL: https://github.com/owner/repo/pull/123
+  - Short form: owner/repo/123
+  - Just number: 123 (when in a repo context)
+  - Omitted: uses current branch's PR
+
+Examples:
+  gh pr-comments rounds
+  gh pr-comments rounds owner/repo/123
+  gh pr-comments rounds --json`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runRounds,
+}
+
+func init() {
+	roundsCmd.Flags().BoolVar(&roundsJsonOutput, "json", false, "Output in JSON format")
+	rootCmd.AddCommand(roundsCmd)
+}
+
+// reviewRound is one push and the feedback that followed it.
+type reviewRound struct {
+	Number           int       `json:"number"`
+	StartedAt        time.Time `json:"started_at"`
+	HeadSHA          string    `json:"head_sha,omitempty"`
+	Commits          int       `json:"commits"`
+	ForcePushed      bool      `json:"force_pushed"`
+	Reviews          int       `json:"reviews"`
+	Approved         int       `json:"approved"`
+	ChangesRequested int       `json:"changes_requested"`
+	Threads          int       `json:"threads"`
+	Resolved         int       `json:"resolved"`
+	Replies          int       `json:"replies"`
+	Comments         int       `json:"comments"`
+
+	hasFeedback bool
+}
+
+func runRounds(cmd *cobra.Command, args []string) error {
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
+	if err != nil {
+		return err
+	}
+
+	events, err := client.GetTimeline(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+	reviews, err := client.GetReviews(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+	threads, err := client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return fmt.Errorf("get review threads: %w", err)
+	}
+
+	rounds := buildRounds(events, reviews, threads)
+
+	if roundsJsonOutput {
+		if rounds == nil {
+			rounds = []*reviewRound{}
+		}
+		return writeJSON(rounds)
+	}
+
+	if len(rounds) == 0 {
+		fmt.Println("No pushes found")
+		return nil
+	}
+	printRounds(rounds)
+	return nil
+}
+
+// buildRounds splits the timeline at pushes that follow feedback and counts
+// the reviews, threads, replies, and comments that fall into each round.
+func buildRounds(events []github.TimelineEvent, reviews []github.Review, threads []github.ReviewThread) []*reviewRound {
+	var rounds []*reviewRound
+	var cur *reviewRound
+	var lastFeedback time.Time
+	for _, e := range events {
+		switch e.Kind {
+		case "commit", "force_push":
+			if cur == nil || cur.hasFeedback {
+				// Commit dates can predate feedback they were pushed after.
+				start := e.CreatedAt
+				if start.Before(lastFeedback) {
+					start = lastFeedback
+				}
+				cur = &reviewRound{Number: len(rounds) + 1, StartedAt: start}
+				rounds = append(rounds, cur)
+			}
+			if e.Kind == "commit" {
+				cur.Commits++
+			} else {
+				cur.ForcePushed = true
+			}
+			cur.HeadSHA = e.SHA
+		case "review", "comment":
+			if cur != nil {
+				cur.hasFeedback = true
+			}
+			if e.CreatedAt.After(lastFeedback) {
+				lastFeedback = e.CreatedAt
+			}
+			if e.Kind == "comment" {
+				if r := roundAt(rounds, e.CreatedAt); r != nil {
+					r.Comments++
+				}
+			}
+		}
+	}
+
+	for _, review := range reviews {
+		if review.State == "PENDING" {
+			continue
+		}
+		r := roundAt(rounds, review.SubmittedAt)
+		if r == nil {
+			continue
+		}
+		r.Reviews++
+		switch review.State {
+		case "APPROVED":
+			r.Approved++
+		case "CHANGES_REQUESTED":
+			r.ChangesRequested++
+		}
+	}
+
+	for _, t := range threads {
+		if len(t.Comments) == 0 {
+			continue
+		}
+		if r := roundAt(rounds, t.Comments[0].CreatedAt); r != nil {
+			r.Threads++
+			if t.IsResolved {
+				r.Resolved++
+			}
+		}
+		for _, c := range t.Comments[1:] {
+			if r := roundAt(rounds, c.CreatedAt); r != nil {
+				r.Replies++
+			}
+		}
+	}
+	return rounds
+}
+
+// roundAt returns the round in progress at t. Activity at the moment a round
+// starts belongs to the round before, and activity before the first push
+// counts toward the first round.
+func roundAt(rounds []*reviewRound, t time.Time) *reviewRound {
+	if len(rounds) == 0 {
+		return nil
+	}
+	i := sort.Search(len(rounds), func(i int) bool { return !rounds[i].StartedAt.Before(t) })
+	if i == 0 {
+		return rounds[0]
+	}
+	return rounds[i-1]
+}
+
+func printRounds(rounds []*reviewRound) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ROUND\tSTARTED\tPUSH\tREVIEWS\tTHREADS\tRESOLVED\tREPLIES\tCOMMENTS")
+	var threads, resolved int
+	for _, r := range rounds {
+		push := fmt.Sprintf("%s (%d commit(s))", shortCommit(r.HeadSHA), r.Commits)
+		if r.ForcePushed {
+			push = fmt.Sprintf("%s (force push, %d commit(s))", shortCommit(r.HeadSHA), r.Commits)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%d\t%s\t%d\t%d\n",
+			r.Number, r.StartedAt.Format("2006-01-02 15:04"), push, roundReviews(r),
+			r.Threads, progressBar(r.Resolved, r.Threads), r.Replies, r.Comments)
+		threads += r.Threads
+		resolved += r.Resolved
+	}
+	w.Flush()
+	fmt.Printf("\nTotal: %d round(s), %d of %d thread(s) resolved\n", len(rounds), resolved, threads)
+}
+
+func roundReviews(r *reviewRound) string {
+	if r.Reviews == 0 {
+		return "0"
+	}
+	var parts []string
+	if r.Approved > 0 {
+		parts = append(parts, fmt.Sprintf("%d approved", r.Approved))
+	}
+	if r.ChangesRequested > 0 {
+		parts = append(parts, fmt.Sprintf("%d changes requested", r.ChangesRequested))
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("%d", r.Reviews)
+	}
+	return fmt.Sprintf("%d (%s)", r.Reviews, strings.Join(parts, ", "))
+}
+
+// progressBar renders done of total as a 10-cell bar followed by the counts.
+func progressBar(done, total int) string {
+	if total == 0 {
+		return "-"
+	}
+	const width = 10
+	filled := done * width / total
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + fmt.Sprintf(" %d/%d", done, total)
+}
@@ -0,0 +1,305 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveHost string
+	servePort int
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve comments and threads as a local JSON HTTP API",
+	Long: `Run an HTTP server that exposes the same data and actions as the CLI, so
+editor plugins and dashboards can use them without shelling out.
+
+A PR is addressed as /prs/{number} (in the repository of the current
+directory) or /prs/{owner}/{repo}/{number}:
+
+  GET  /prs/{pr}/comments                  like 'list --json'; ?all=true includes resolved
+  GET  /prs/{pr}/threads                   like 'threads --json'; ?all=true or ?resolved=true|false
+  POST /prs/{pr}/comments/{id}/resolve     resolve the comment's thread, like 'resolve --json'
+  POST /prs/{pr}/comments/{id}/replies     reply with {"body": "..."}, like 'reply --json'
+
+Responses are the CLI's JSON output. Errors are {"error": "..."} with a 4xx
+or 5xx status. POST requests must have Content-Type: application/json.
+
+Every request must carry the token printed at startup as
+"Authorization: Bearer <token>". Set GH_PR_COMMENTS_SERVE_TOKEN to use a
+fixed token instead of a random one. Requests whose Host header is not
+localhost, 127.0.0.1, [::1], or the --host address are refused, so web
+pages cannot reach the server through DNS rebinding.
+
+Mutations run as your gh user. The server listens on 127.0.0.1 unless
+--host says otherwise; only expose it where everyone who can reach it may
+act as you. Reads always fetch live data, bypassing the response cache.
+
+Examples:
+  gh pr-comments serve --port 8080
+  curl -H "Authorization: Bearer $TOKEN" localhost:8080/prs/123/comments
+  curl -H "Authorization: Bearer $TOKEN" localhost:8080/prs/owner/repo/123/threads?all=true
+  curl -X POST -H "Authorization: Bearer $TOKEN" -H 'Content-Type: application/json' \
+    -d '{"body":"Done"}' localhost:8080/prs/123/comments/2621968472/replies`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveHost, "host", "127.0.0.1", "Address to listen on")
+	serveCmd.Flags().IntVar(&servePort, "port", 8080, "Port to listen on")
+	rootCmd.AddCommand(serveCmd)
+}
+
+// apiServer handles one request at a time: the command helpers it reuses
+// read package-level flag variables.
+type apiServer struct {
+	mu     sync.Mutex
+	client *github.Client
+	token  string
+}
+
+// apiError is an error with the HTTP status it should be reported with.
+type apiError struct {
+	status int
+	err    error
+}
+
+func (e *apiError) Error() string { return e.err.Error() }
+
+func badRequest(format string, a ...interface{}) error {
+	return &apiError{http.StatusBadRequest, fmt.Errorf(format, a...)}
+}
+
+// serveToken returns the token requests must present: the configured one,
+// or a random one for this run.
+func serveToken() (string, error) {
+	if token := os.Getenv("GH_PR_COMMENTS_SERVE_TOKEN"); token != "" {
+		return token, nil
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	if err := requireLiveData("serve"); err != nil {
+		return err
+	}
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+	token, err := serveToken()
+	if err != nil {
+		return err
+	}
+	s := &apiServer{client: client, token: token}
+
+	mux := http.NewServeMux()
+	for _, prefix := range []string{"/prs/{number}", "/prs/{owner}/{repo}/{number}"} {
+		mux.HandleFunc("GET "+prefix+"/comments", s.handle(s.comments))
+		mux.HandleFunc("GET "+prefix+"/threads", s.handle(s.threads))
+		mux.HandleFunc("POST "+prefix+"/comments/{id}/resolve", s.handle(s.resolve))
+		mux.HandleFunc("POST "+prefix+"/comments/{id}/replies", s.handle(s.reply))
+	}
+
+	addr := net.JoinHostPort(serveHost, strconv.Itoa(servePort))
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Serving on http://%s (Ctrl-C to stop)\n", ln.Addr())
+	fmt.Fprintf(os.Stderr, "Token: %s\n", token)
+	cmd.SilenceUsage = true
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	return server.Serve(ln)
+}
+
+// allowedHost reports whether a request's Host header names this machine's
+// loopback interface or the address the server was asked to listen on. A
+// page loaded from another name that resolves here is refused.
+func allowedHost(hostport string) bool {
+	host, _, err := net.SplitHostPort(hostport)
+	if err != nil {
+		host = strings.TrimSuffix(strings.TrimPrefix(hostport, "["), "]")
+	}
+	switch strings.ToLower(host) {
+	case "localhost", "127.0.0.1", "::1":
+		return true
+	}
+	return host == serveHost && net.ParseIP(host) != nil
+}
+
+// authorized reports whether a request carries the server's token.
+func (s *apiServer) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+// handle adapts a handler that returns its output layout name and value,
+// resolving the PR from the path and encoding the result or error.
+func (s *apiServer) handle(h func(r *http.Request, prRef *github.PRReference) (interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		v, err := s.serve(r, h)
+		w.Header().Set("Content-Type", "application/json")
+		if err != nil {
+			status := http.StatusInternalServerError
+			var apiErr *apiError
+			switch {
+			case errors.As(err, &apiErr):
+				status = apiErr.status
+			case github.IsNotFound(err):
+				status = http.StatusNotFound
+			}
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			fmt.Fprintf(os.Stderr, "%s %s: %d %v\n", r.Method, r.URL.Path, status, err)
+			return
+		}
+		if err := encodeJSON(w, v); err != nil {
+			fmt.Fprintf(os.Stderr, "%s %s: %v\n", r.Method, r.URL.Path, err)
+		}
+	}
+}
+
+func (s *apiServer) serve(r *http.Request, h func(*http.Request, *github.PRReference) (interface{}, error)) (interface{}, error) {
+	if !allowedHost(r.Host) {
+		return nil, &apiError{http.StatusForbidden, fmt.Errorf("host not allowed: %s", r.Host)}
+	}
+	if !s.authorized(r) {
+		return nil, &apiError{http.StatusUnauthorized, errors.New("missing or invalid token")}
+	}
+	if r.Method == http.MethodPost {
+		// A JSON content type cannot be sent cross-site without a CORS
+		// preflight, which this server never approves.
+		if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt != "application/json" {
+			return nil, &apiError{http.StatusUnsupportedMediaType, errors.New("POST requests must be application/json")}
+		}
+	}
+
+	ref := r.PathValue("number")
+	if _, err := strconv.Atoi(ref); err != nil {
+		return nil, badRequest("invalid PR number: %s", ref)
+	}
+	if owner := r.PathValue("owner"); owner != "" {
+		ref = owner + "/" + r.PathValue("repo") + "/" + ref
+	}
+	prRef, err := s.client.ResolvePRReference([]string{ref})
+	if err != nil {
+		return nil, badRequest("%v", err)
+	}
+	return h(r, prRef)
+}
+
+func (s *apiServer) comments(r *http.Request, prRef *github.PRReference) (interface{}, error) {
+	saved := listFilter
+	defer func() { listFilter = saved }()
+	listFilter = commentFilter{All: r.URL.Query().Get("all") == "true"}
+
+	comments, err := collectComments(s.client, prRef)
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (s *apiServer) threads(r *http.Request, prRef *github.PRReference) (interface{}, error) {
+	resolved := r.URL.Query().Get("resolved")
+	switch resolved {
+	case "", "true", "false":
+	default:
+		return nil, badRequest("invalid resolved value: %s (valid: true, false)", resolved)
+	}
+	threads, err := s.client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, fmt.Errorf("get review threads: %w", err)
+	}
+	return buildThreadRows(threads, r.URL.Query().Get("all") == "true", resolved), nil
+}
+
+func (s *apiServer) resolve(r *http.Request, prRef *github.PRReference) (interface{}, error) {
+	commentID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return nil, badRequest("invalid comment ID: %s", r.PathValue("id"))
+	}
+	threads, err := s.client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, fmt.Errorf("get review threads: %w", err)
+	}
+
+	result := ResolveResult{CommentID: commentID, Action: "resolved"}
+	for _, t := range threads {
+		for _, id := range t.CommentIDs {
+			if id != commentID {
+				continue
+			}
+			result.ThreadID = t.ID
+			if t.IsResolved {
+				result.Success, result.Skipped, result.Reason = true, true, skipAlreadyResolved
+			} else if err := s.client.ResolveThread(t.ID); err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Success = true
+				journalAction(prRef, journal.Entry{Action: "resolve", ThreadID: t.ID})
+			}
+		}
+	}
+	if result.ThreadID == "" {
+		return nil, &apiError{http.StatusNotFound, fmt.Errorf("comment %d not found in any review thread", commentID)}
+	}
+	return map[string]interface{}{"results": []ResolveResult{result}}, nil
+}
+
+func (s *apiServer) reply(r *http.Request, prRef *github.PRReference) (interface{}, error) {
+	commentID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return nil, badRequest("invalid comment ID: %s", r.PathValue("id"))
+	}
+	var req struct {
+		Body string `json:"body"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(nil, r.Body, 1<<20)).Decode(&req); err != nil {
+		return nil, badRequest("invalid request body: %v", err)
+	}
+	if strings.TrimSpace(req.Body) == "" {
+		return nil, badRequest("reply body is empty")
+	}
+
+	found, err := findReviewComment(s.client, prRef, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, &apiError{http.StatusNotFound, fmt.Errorf("review comment with ID %d not found in PR %d", commentID, prRef.Number)}
+	}
+	reply, err := s.client.ReplyToReviewComment(prRef.Owner, prRef.Repo, prRef.Number, commentID, withSignature(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	journalAction(prRef, journal.Entry{Action: "reply", CommentID: reply.ID, URL: reply.HTMLURL})
+	return reply, nil
+}
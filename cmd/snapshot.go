@@ -0,0 +1,447 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/state"
+	"github.com/spf13/cobra"
+)
+
+const snapshotStateDir = "snapshots"
+
+var snapshotNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+var snapshotDiffJsonOutput bool
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save named copies of a PR's comment state and compare them",
+	Long: `Save the full comment and thread state of a pull request under a name, and
+later report what changed between two snapshots: comments that are new,
+deleted, or edited, and threads that were resolved, reopened, or hidden.
+Handy for documenting what each review round changed.
+
+Snapshots are stored locally per PR. The PR is the current branch's PR, or
+the one given with --pr.
+
+Examples:
+  gh pr-comments snapshot save round-1
+  gh pr-comments snapshot save round-2 --pr owner/repo/123
+  gh pr-comments snapshot diff round-1 round-2
+  gh pr-comments snapshot diff round-2
+  gh pr-comments snapshot list`,
+}
+
+var snapshotSaveCmd = &cobra.Command{
+	Use:   "save <name>",
+	Short: "Save the PR's current comment state under a name",
+	Long: `Save the PR's current comments and thread states under name, replacing an
+existing snapshot of the same name. Names may contain letters, digits, '.',
+'_', and '-'.
+
+Examples:
+  gh pr-comments snapshot save round-1
+  gh pr-comments snapshot save before-rebase --pr 123`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSnapshotSave,
+}
+
+var snapshotDiffCmd = &cobra.Command{
+	Use:   "diff <a> [b]",
+	Short: "Show what changed between two snapshots",
+	Long: `Compare snapshot a with snapshot b and list every comment that is new,
+deleted, edited, resolved, unresolved, or hidden in b. Without b, a is
+compared with the PR as it is on GitHub now.
+
+Examples:
+  gh pr-comments snapshot diff round-1 round-2
+  gh pr-comments snapshot diff round-1
+  gh pr-comments snapshot diff round-1 round-2 --json`,
+	Args:              cobra.RangeArgs(1, 2),
+	RunE:              runSnapshotDiff,
+	ValidArgsFunction: completeSnapshotNames,
+}
+
+var snapshotListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the PR's saved snapshots",
+	Args:  cobra.NoArgs,
+	RunE:  runSnapshotList,
+}
+
+func init() {
+	snapshotDiffCmd.Flags().BoolVar(&snapshotDiffJsonOutput, "json", false, "Output in JSON format")
+	snapshotCmd.AddCommand(snapshotSaveCmd, snapshotDiffCmd, snapshotListCmd)
+	rootCmd.AddCommand(snapshotCmd)
+}
+
+// prSnapshot is the comment state of one PR at one point in time.
+type prSnapshot struct {
+	Name     string            `json:"name"`
+	PR       string            `json:"pr"`
+	TakenAt  time.Time         `json:"taken_at"`
+	HeadSHA  string            `json:"head_sha"`
+	Comments []snapshotComment `json:"comments"`
+}
+
+type snapshotComment struct {
+	ID        int64     `json:"id"`
+	Type      string    `json:"type"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	Location  string    `json:"location,omitempty"`
+	ThreadID  string    `json:"thread_id,omitempty"`
+	Resolved  bool      `json:"resolved"`
+	Hidden    bool      `json:"hidden"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// snapshotChange is one difference between two snapshots. Change is new,
+// deleted, edited, resolved, unresolved, or hidden; Before is the old body
+// of an edited comment.
+type snapshotChange struct {
+	Change   string `json:"change"`
+	ID       int64  `json:"id"`
+	Type     string `json:"type"`
+	Author   string `json:"author"`
+	Location string `json:"location,omitempty"`
+	Body     string `json:"body"`
+	Before   string `json:"before,omitempty"`
+}
+
+type snapshotDiff struct {
+	PR      string           `json:"pr"`
+	From    string           `json:"from"`
+	To      string           `json:"to"`
+	Changes []snapshotChange `json:"changes"`
+}
+
+func snapshotFile(prRef *github.PRReference, name string) string {
+	return filepath.Join(snapshotStateDir, state.PRKey(prRef.Owner, prRef.Repo, prRef.Number), name+".json")
+}
+
+func runSnapshotSave(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if !snapshotNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid snapshot name: %s (use letters, digits, '.', '_', and '-')", name)
+	}
+
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+	prRef, err := client.ResolvePRReference(withGlobalPR(nil))
+	if err != nil {
+		return err
+	}
+
+	snap, err := takeSnapshot(client, prRef, name)
+	if err != nil {
+		return err
+	}
+	if err := state.Save(snapshotFile(prRef, name), snap); err != nil {
+		return err
+	}
+
+	resolved := 0
+	for _, c := range snap.Comments {
+		if c.Resolved {
+			resolved++
+		}
+	}
+	fmt.Printf("Saved snapshot %q of PR #%d: %d comment(s), %d in resolved threads.\n",
+		name, prRef.Number, len(snap.Comments), resolved)
+	return nil
+}
+
+func takeSnapshot(client *github.Client, prRef *github.PRReference, name string) (*prSnapshot, error) {
+	// A snapshot records resolved and hidden states, so it must not be
+	// taken with them missing.
+	client.RequireCompleteStatus()
+	pr, err := client.GetPullRequest(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+	reviewComments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+	issueComments, err := client.GetIssueComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, err
+	}
+	threads, err := client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return nil, fmt.Errorf("get review threads: %w", err)
+	}
+	threadOf := make(map[int64]string)
+	for _, t := range threads {
+		for _, id := range t.CommentIDs {
+			threadOf[id] = t.ID
+		}
+	}
+
+	snap := &prSnapshot{
+		Name:     name,
+		PR:       state.PRKey(prRef.Owner, prRef.Repo, prRef.Number),
+		TakenAt:  time.Now(),
+		HeadSHA:  pr.Head.SHA,
+		Comments: []snapshotComment{},
+	}
+	for _, c := range reviewComments {
+		snap.Comments = append(snap.Comments, snapshotComment{
+			ID:        c.ID,
+			Type:      "review_comment",
+			Author:    c.User.Login,
+			Body:      c.Body,
+			Location:  c.Location(),
+			ThreadID:  threadOf[c.ID],
+			Resolved:  c.IsResolved,
+			Hidden:    c.IsMinimized,
+			UpdatedAt: c.UpdatedAt,
+		})
+	}
+	for _, c := range issueComments {
+		snap.Comments = append(snap.Comments, snapshotComment{
+			ID:        c.ID,
+			Type:      "issue_comment",
+			Author:    c.User.Login,
+			Body:      c.Body,
+			Hidden:    c.IsMinimized,
+			UpdatedAt: c.UpdatedAt,
+		})
+	}
+	sort.Slice(snap.Comments, func(i, j int) bool { return snap.Comments[i].ID < snap.Comments[j].ID })
+	return snap, nil
+}
+
+func loadSnapshot(prRef *github.PRReference, name string) (*prSnapshot, error) {
+	if !snapshotNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid snapshot name: %s", name)
+	}
+	var snap prSnapshot
+	if err := state.Load(snapshotFile(prRef, name), &snap); err != nil {
+		return nil, err
+	}
+	if snap.TakenAt.IsZero() {
+		return nil, fmt.Errorf("no snapshot named %q for PR #%d (see 'snapshot list')", name, prRef.Number)
+	}
+	return &snap, nil
+}
+
+func runSnapshotDiff(cmd *cobra.Command, args []string) error {
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+	prRef, err := client.ResolvePRReference(withGlobalPR(nil))
+	if err != nil {
+		return err
+	}
+
+	from, err := loadSnapshot(prRef, args[0])
+	if err != nil {
+		return err
+	}
+	var to *prSnapshot
+	if len(args) == 2 {
+		to, err = loadSnapshot(prRef, args[1])
+	} else {
+		to, err = takeSnapshot(client, prRef, "now")
+	}
+	if err != nil {
+		return err
+	}
+
+	diff := snapshotDiff{
+		PR:      from.PR,
+		From:    from.Name,
+		To:      to.Name,
+		Changes: diffSnapshots(from, to),
+	}
+	if snapshotDiffJsonOutput {
+		return writeJSON(diff)
+	}
+
+	fmt.Printf("PR #%d: %s (%s) → %s (%s)\n", prRef.Number,
+		from.Name, from.TakenAt.Local().Format("2006-01-02 15:04"),
+		to.Name, to.TakenAt.Local().Format("2006-01-02 15:04"))
+	if from.HeadSHA != to.HeadSHA {
+		fmt.Printf("Head moved %s → %s\n", shortCommit(from.HeadSHA), shortCommit(to.HeadSHA))
+	}
+	fmt.Println()
+	if len(diff.Changes) == 0 {
+		fmt.Println("No comment changes.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHANGE\tID\tTYPE\tAUTHOR\tLOCATION\tBODY")
+	counts := make(map[string]int)
+	for _, c := range diff.Changes {
+		counts[c.Change]++
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\n",
+			c.Change, c.ID, c.Type, c.Author, c.Location, github.TruncateString(c.Body, 40))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	var summary []string
+	for _, change := range snapshotChangeOrder {
+		if counts[change] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[change], change))
+		}
+	}
+	fmt.Printf("\n%s\n", strings.Join(summary, ", "))
+	return nil
+}
+
+var snapshotChangeOrder = []string{"new", "deleted", "edited", "resolved", "unresolved", "hidden"}
+
+// diffSnapshots lists the changes from a to b, grouped in
+// snapshotChangeOrder and by comment ID within each group. A comment can
+// appear more than once, e.g. both edited and resolved.
+func diffSnapshots(a, b *prSnapshot) []snapshotChange {
+	before := make(map[int64]snapshotComment, len(a.Comments))
+	for _, c := range a.Comments {
+		before[c.ID] = c
+	}
+	after := make(map[int64]bool, len(b.Comments))
+
+	var changes []snapshotChange
+	add := func(change string, c snapshotComment, old string) {
+		changes = append(changes, snapshotChange{
+			Change:   change,
+			ID:       c.ID,
+			Type:     c.Type,
+			Author:   c.Author,
+			Location: c.Location,
+			Body:     c.Body,
+			Before:   old,
+		})
+	}
+	for _, c := range b.Comments {
+		after[c.ID] = true
+		old, ok := before[c.ID]
+		if !ok {
+			add("new", c, "")
+			continue
+		}
+		if old.Body != c.Body {
+			add("edited", c, old.Body)
+		}
+		if !old.Resolved && c.Resolved {
+			add("resolved", c, "")
+		}
+		if old.Resolved && !c.Resolved {
+			add("unresolved", c, "")
+		}
+		if !old.Hidden && c.Hidden {
+			add("hidden", c, "")
+		}
+	}
+	for _, c := range a.Comments {
+		if !after[c.ID] {
+			add("deleted", c, "")
+		}
+	}
+
+	rank := make(map[string]int, len(snapshotChangeOrder))
+	for i, change := range snapshotChangeOrder {
+		rank[change] = i
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if rank[changes[i].Change] != rank[changes[j].Change] {
+			return rank[changes[i].Change] < rank[changes[j].Change]
+		}
+		return changes[i].ID < changes[j].ID
+	})
+	if changes == nil {
+		changes = []snapshotChange{}
+	}
+	return changes
+}
+
+func runSnapshotList(cmd *cobra.Command, args []string) error {
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+	prRef, err := client.ResolvePRReference(withGlobalPR(nil))
+	if err != nil {
+		return err
+	}
+
+	snaps, err := listSnapshots(prRef)
+	if err != nil {
+		return err
+	}
+	if len(snaps) == 0 {
+		fmt.Printf("No snapshots for PR #%d. Save one with 'snapshot save <name>'.\n", prRef.Number)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tTAKEN\tHEAD\tCOMMENTS")
+	for _, s := range snaps {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\n",
+			s.Name, s.TakenAt.Local().Format("2006-01-02 15:04"), shortCommit(s.HeadSHA), len(s.Comments))
+	}
+	return w.Flush()
+}
+
+// listSnapshots returns the PR's saved snapshots, oldest first.
+func listSnapshots(prRef *github.PRReference) ([]prSnapshot, error) {
+	dir := filepath.Join(state.Dir(), snapshotStateDir, state.PRKey(prRef.Owner, prRef.Repo, prRef.Number))
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read snapshots: %w", err)
+	}
+	var snaps []prSnapshot
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok || e.IsDir() {
+			continue
+		}
+		snap, err := loadSnapshot(prRef, name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping snapshot %s: %v\n", name, err)
+			continue
+		}
+		snaps = append(snaps, *snap)
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].TakenAt.Before(snaps[j].TakenAt) })
+	return snaps, nil
+}
+
+func completeSnapshotNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) >= 2 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	client, err := completionClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	prRef, err := client.ResolvePRReference(withGlobalPR(nil))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	snaps, _ := listSnapshots(prRef)
+	var names []string
+	for _, s := range snaps {
+		names = append(names, s.Name)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
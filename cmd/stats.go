@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsJsonOutput bool
+	statsByFile     bool
+	statsHeatmap    bool
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats [pr-reference]",
+	Short: "Show comment counts for a pull request",
+	Long: `Show how much review feedback a pull request has: comment and thread
+totals, and comments per author.
+
+--by-file counts review comments and threads per file instead, next to the
+number of lines the PR changes in that file. --heatmap ranks those files by
+comment density (review comments per changed line) and draws a bar for each,
+highlighting the most contentious parts of the PR. Files the PR no longer
+changes have no density and are listed last.
+
+If no PR reference is given, finds the PR for the current branch.
+
+PR reference can be:
+  - Full URL: https://github.com/owner/repo/pull/123
+  - Short form: owner/repo/123
+  - Just number: 123 (when in a repo context)
+  - Omitted: uses current branch's PR
+
+Examples:
+  gh pr-comments stats
+  gh pr-comments stats --by-file
+  gh pr-comments stats --by-file --heatmap
+  gh pr-comments stats owner/repo/123 --by-file --json`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runStats,
+}
+
+func init() {
+	statsCmd.Flags().BoolVar(&statsJsonOutput, "json", false, "Output in JSON format")
+	statsCmd.Flags().BoolVar(&statsByFile, "by-file", false, "Count review comments per file")
+	statsCmd.Flags().BoolVar(&statsHeatmap, "heatmap", false, "Rank files by comments per changed line (with --by-file)")
+	rootCmd.AddCommand(statsCmd)
+}
+
+type authorStats struct {
+	Author         string `json:"author"`
+	ReviewComments int    `json:"review_comments"`
+	IssueComments  int    `json:"issue_comments"`
+}
+
+type prStats struct {
+	ReviewComments  int           `json:"review_comments"`
+	IssueComments   int           `json:"issue_comments"`
+	Threads         int           `json:"threads"`
+	ResolvedThreads int           `json:"resolved_threads"`
+	Authors         []authorStats `json:"authors"`
+}
+
+type fileStats struct {
+	Path         string  `json:"path"`
+	Comments     int     `json:"comments"`
+	Threads      int     `json:"threads"`
+	Unresolved   int     `json:"unresolved_threads"`
+	ChangedLines int     `json:"changed_lines"`
+	Density      float64 `json:"density"`
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	if statsHeatmap && !statsByFile {
+		return fmt.Errorf("--heatmap requires --by-file")
+	}
+
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
+	if err != nil {
+		return err
+	}
+
+	comments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	if statsByFile {
+		files, err := client.GetPRFiles(prRef.Owner, prRef.Repo, prRef.Number)
+		if err != nil {
+			return err
+		}
+		stats := fileCommentStats(comments, files)
+		if statsHeatmap {
+			sortByDensity(stats)
+		}
+		if statsJsonOutput {
+			return writeJSON(stats)
+		}
+		if len(stats) == 0 {
+			fmt.Println("No review comments found")
+			return nil
+		}
+		printFileStats(stats, statsHeatmap)
+		return nil
+	}
+
+	issueComments, err := client.GetIssueComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+	stats := commentStats(comments, issueComments)
+	if statsJsonOutput {
+		return writeJSON(stats)
+	}
+	printStats(stats)
+	return nil
+}
+
+func commentStats(comments []github.ReviewComment, issueComments []github.IssueComment) *prStats {
+	stats := &prStats{ReviewComments: len(comments), IssueComments: len(issueComments), Authors: []authorStats{}}
+	byAuthor := make(map[string]*authorStats)
+	author := func(login string) *authorStats {
+		if a, ok := byAuthor[login]; ok {
+			return a
+		}
+		a := &authorStats{Author: login}
+		byAuthor[login] = a
+		return a
+	}
+	for _, c := range comments {
+		author(c.User.Login).ReviewComments++
+		if c.InReplyToID == 0 {
+			stats.Threads++
+			if c.IsResolved {
+				stats.ResolvedThreads++
+			}
+		}
+	}
+	for _, c := range issueComments {
+		author(c.User.Login).IssueComments++
+	}
+	for _, a := range byAuthor {
+		stats.Authors = append(stats.Authors, *a)
+	}
+	sort.Slice(stats.Authors, func(i, j int) bool {
+		ti := stats.Authors[i].ReviewComments + stats.Authors[i].IssueComments
+		tj := stats.Authors[j].ReviewComments + stats.Authors[j].IssueComments
+		if ti != tj {
+			return ti > tj
+		}
+		return stats.Authors[i].Author < stats.Authors[j].Author
+	})
+	return stats
+}
+
+func printStats(stats *prStats) {
+	fmt.Printf("Review comments: %d in %d thread(s), %d resolved\n", stats.ReviewComments, stats.Threads, stats.ResolvedThreads)
+	fmt.Printf("General comments: %d\n", stats.IssueComments)
+	if len(stats.Authors) == 0 {
+		return
+	}
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "AUTHOR\tREVIEW COMMENTS\tGENERAL COMMENTS")
+	for _, a := range stats.Authors {
+		fmt.Fprintf(w, "%s\t%d\t%d\n", a.Author, a.ReviewComments, a.IssueComments)
+	}
+	w.Flush()
+}
+
+// fileCommentStats counts review comments per commented file, most
+// commented first, with each file's changed lines from the PR diff.
+func fileCommentStats(comments []github.ReviewComment, files []github.CommitFile) []fileStats {
+	changed := make(map[string]int)
+	for _, f := range files {
+		changed[f.Filename] = f.Additions + f.Deletions
+	}
+
+	byPath := make(map[string]*fileStats)
+	for _, c := range comments {
+		s, ok := byPath[c.Path]
+		if !ok {
+			s = &fileStats{Path: c.Path, ChangedLines: changed[c.Path]}
+			byPath[c.Path] = s
+		}
+		s.Comments++
+		if c.InReplyToID == 0 {
+			s.Threads++
+			if !c.IsResolved {
+				s.Unresolved++
+			}
+		}
+	}
+
+	stats := []fileStats{}
+	for _, s := range byPath {
+		if s.ChangedLines > 0 {
+			s.Density = float64(s.Comments) / float64(s.ChangedLines)
+		}
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Comments != stats[j].Comments {
+			return stats[i].Comments > stats[j].Comments
+		}
+		return stats[i].Path < stats[j].Path
+	})
+	return stats
+}
+
+// sortByDensity orders files by comments per changed line, densest first.
+func sortByDensity(stats []fileStats) {
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].Density > stats[j].Density
+	})
+}
+
+func printFileStats(stats []fileStats, heatmap bool) {
+	var maxDensity float64
+	for _, s := range stats {
+		maxDensity = max(maxDensity, s.Density)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if heatmap {
+		fmt.Fprintln(w, "FILE\tCOMMENTS\tTHREADS\tUNRESOLVED\tCHANGED\tPER 100 LINES\tHEAT")
+	} else {
+		fmt.Fprintln(w, "FILE\tCOMMENTS\tTHREADS\tUNRESOLVED\tCHANGED")
+	}
+	for _, s := range stats {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d", s.Path, s.Comments, s.Threads, s.Unresolved, s.ChangedLines)
+		if heatmap {
+			if s.ChangedLines == 0 {
+				fmt.Fprint(w, "\t-\t")
+			} else {
+				fmt.Fprintf(w, "\t%.1f\t%s", s.Density*100, heatBar(s.Density, maxDensity))
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+}
+
+// heatBar draws density as a bar of up to 20 cells relative to the densest
+// file.
+func heatBar(density, maxDensity float64) string {
+	const width = 20
+	if maxDensity == 0 {
+		return ""
+	}
+	cells := int(density/maxDensity*width + 0.5)
+	return strings.Repeat("█", max(cells, 1))
+}
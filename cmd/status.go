@@ -0,0 +1,348 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statusJsonOutput   bool
+	statusRequirements bool
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status [pr-reference]",
+	Short: "Show PR metadata and review progress at a glance",
+	Long: `Show the pull request's description and metadata (state, draft, branches,
+labels, mergeability) together with a summary of reviews and review threads.
+
+With --requirements, the base branch's protection rules and rulesets are read
+and each review requirement (required approvals, no outstanding change
+requests, resolved conversations) is checked against the PR's current state.
+Requirements that cannot be checked from review data, such as code owner
+review, are reported as unknown. Reading classic branch protection needs
+admin access; without it only rulesets are used.
+
+If the branch has been force-pushed, a warning counts the unresolved threads
+opened before the last force push, since the lines they point at have
+probably moved.
+
+If no PR reference is given, finds the PR for the current branch.
+
+PR reference can be:
+  - Full URL: https://github.com/owner/repo/pull/123
+  - Short form: owner/repo/123
+  - Just number: 123 (when in a repo context)
+  - Omitted: uses current branch's PR
+
+Examples:
+  gh pr-comments status
+  gh pr-comments status owner/repo/123
+  gh pr-comments status --json
+  gh pr-comments status --requirements`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runStatus,
+}
+
+func init() {
+	statusCmd.Flags().BoolVar(&statusJsonOutput, "json", false, "Output in JSON format")
+	statusCmd.Flags().BoolVar(&statusRequirements, "requirements", false, "Check review state against the base branch's merge requirements")
+	rootCmd.AddCommand(statusCmd)
+}
+
+type statusOutput struct {
+	PullRequest       *github.PullRequest `json:"pull_request"`
+	ReviewStates      map[string]string   `json:"review_states"`
+	UnresolvedThreads int                 `json:"unresolved_threads"`
+	ResolvedThreads   int                 `json:"resolved_threads"`
+	IssueComments     int                 `json:"issue_comments"`
+	Requirements      *requirementsReport `json:"requirements,omitempty"`
+
+	// LastForcePush and StaleThreads flag unresolved threads opened before
+	// the branch was last force-pushed; their line anchors are likely off.
+	LastForcePush *github.TimelineEvent `json:"last_force_push,omitempty"`
+	StaleThreads  int                   `json:"stale_threads,omitempty"`
+}
+
+type requirementsReport struct {
+	Branch    string                    `json:"branch"`
+	Rules     *github.MergeRequirements `json:"rules"`
+	Checks    []requirementCheck        `json:"checks"`
+	Satisfied bool                      `json:"satisfied"`
+}
+
+// requirementCheck is one merge requirement; Status is met, unmet, or
+// unknown when it cannot be determined from review data.
+type requirementCheck struct {
+	Name     string `json:"name"`
+	Required string `json:"required"`
+	Actual   string `json:"actual"`
+	Status   string `json:"status"`
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
+	if err != nil {
+		return err
+	}
+
+	pr, err := client.GetPullRequest(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	reviews, err := client.GetReviews(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	threads, err := client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return fmt.Errorf("get review threads: %w", err)
+	}
+
+	issueComments, err := client.GetIssueComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	events, err := client.GetTimeline(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	output := statusOutput{
+		PullRequest:   pr,
+		ReviewStates:  latestReviewStates(reviews),
+		IssueComments: len(issueComments),
+		LastForcePush: lastForcePush(events),
+	}
+	for _, t := range threads {
+		if t.IsResolved {
+			output.ResolvedThreads++
+			continue
+		}
+		output.UnresolvedThreads++
+		if output.LastForcePush != nil && len(t.Comments) > 0 && t.Comments[0].CreatedAt.Before(output.LastForcePush.CreatedAt) {
+			output.StaleThreads++
+		}
+	}
+
+	if statusRequirements {
+		rules, err := client.GetMergeRequirements(prRef.Owner, prRef.Repo, pr.Base.Ref)
+		if err != nil {
+			return err
+		}
+		output.Requirements = checkRequirements(pr.Base.Ref, rules, output.ReviewStates, output.UnresolvedThreads)
+	}
+
+	if statusJsonOutput {
+		return writeJSON(output)
+	}
+
+	fmt.Printf("PR #%d: %s\n", pr.Number, pr.Title)
+	fmt.Println(strings.Repeat("─", 60))
+	for _, line := range prMetadataLines(pr) {
+		fmt.Println(line)
+	}
+	fmt.Printf("URL:       %s\n", pr.HTMLURL)
+	fmt.Println(strings.Repeat("─", 60))
+
+	fmt.Printf("Threads:   %d unresolved, %d resolved\n", output.UnresolvedThreads, output.ResolvedThreads)
+	if output.StaleThreads > 0 {
+		fmt.Printf("Warning:   %d unresolved thread(s) predate the force push by %s on %s; their anchors are likely outdated\n",
+			output.StaleThreads, output.LastForcePush.Actor, output.LastForcePush.CreatedAt.Format("2006-01-02 15:04"))
+	}
+	fmt.Printf("Comments:  %d issue comment(s)\n", output.IssueComments)
+	if len(output.ReviewStates) == 0 {
+		fmt.Println("Reviews:   none")
+	} else {
+		reviewers := make([]string, 0, len(output.ReviewStates))
+		for login := range output.ReviewStates {
+			reviewers = append(reviewers, login)
+		}
+		sort.Strings(reviewers)
+		fmt.Println("Reviews:")
+		for _, login := range reviewers {
+			fmt.Printf("  %-20s %s\n", login, output.ReviewStates[login])
+		}
+	}
+	fmt.Println(strings.Repeat("─", 60))
+	if output.Requirements != nil {
+		printRequirements(output.Requirements)
+		fmt.Println(strings.Repeat("─", 60))
+	}
+	fmt.Println()
+	if strings.TrimSpace(pr.Body) != "" {
+		fmt.Println(pr.Body)
+	} else {
+		fmt.Println("(no description)")
+	}
+	fmt.Println()
+	return nil
+}
+
+// prMetadataLines formats the PR's author, state, branches, labels, and
+// mergeability as aligned "Key: value" lines.
+func prMetadataLines(pr *github.PullRequest) []string {
+	state := pr.State
+	if pr.Draft {
+		state += " (draft)"
+	}
+	lines := []string{
+		fmt.Sprintf("Author:    %s", pr.User.Login),
+		fmt.Sprintf("State:     %s", state),
+		fmt.Sprintf("Branches:  %s ← %s", pr.Base.Ref, pr.Head.Ref),
+	}
+	if len(pr.Labels) > 0 {
+		lines = append(lines, fmt.Sprintf("Labels:    %s", strings.Join(pr.LabelNames(), ", ")))
+	}
+	lines = append(lines, fmt.Sprintf("Mergeable: %s", pr.MergeStatus()))
+	return lines
+}
+
+// latestReviewStates maps each reviewer to the state of their most recent
+// review that carries a verdict. Plain comments only count when the reviewer
+// has nothing else.
+func latestReviewStates(reviews []github.Review) map[string]string {
+	states := make(map[string]string)
+	for _, r := range latestReviews(reviews) {
+		states[r.User.Login] = r.State
+	}
+	return states
+}
+
+// latestReviews returns each reviewer's most recent review that carries a
+// verdict (or their most recent comment-only review if they have nothing
+// else), oldest first. Pending reviews are ignored.
+func latestReviews(reviews []github.Review) []github.Review {
+	sorted := make([]github.Review, len(reviews))
+	copy(sorted, reviews)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].SubmittedAt.Before(sorted[j].SubmittedAt)
+	})
+
+	latest := make(map[string]github.Review)
+	for _, r := range sorted {
+		if r.State == "PENDING" {
+			continue
+		}
+		if prev, ok := latest[r.User.Login]; ok && r.State == "COMMENTED" && prev.State != "COMMENTED" {
+			continue
+		}
+		latest[r.User.Login] = r
+	}
+
+	var result []github.Review
+	for _, r := range sorted {
+		if l, ok := latest[r.User.Login]; ok && l.ID == r.ID {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// checkRequirements compares the PR's review state with the branch rules.
+func checkRequirements(branch string, rules *github.MergeRequirements, states map[string]string, unresolved int) *requirementsReport {
+	report := &requirementsReport{Branch: branch, Rules: rules, Checks: []requirementCheck{}}
+
+	if rules.RequiredApprovals > 0 {
+		var approvers, blockers []string
+		for login, state := range states {
+			switch state {
+			case "APPROVED":
+				approvers = append(approvers, login)
+			case "CHANGES_REQUESTED":
+				blockers = append(blockers, login)
+			}
+		}
+		sort.Strings(blockers)
+		report.Checks = append(report.Checks, requirementCheck{
+			Name:     "approvals",
+			Required: fmt.Sprintf("%d approval(s)", rules.RequiredApprovals),
+			Actual:   fmt.Sprintf("%d approval(s)", len(approvers)),
+			Status:   metStatus(len(approvers) >= rules.RequiredApprovals),
+		})
+		actual := "none"
+		if len(blockers) > 0 {
+			actual = "requested by " + strings.Join(blockers, ", ")
+		}
+		report.Checks = append(report.Checks, requirementCheck{
+			Name:     "changes requested",
+			Required: "none",
+			Actual:   actual,
+			Status:   metStatus(len(blockers) == 0),
+		})
+	}
+	if rules.RequireConversationResolution {
+		report.Checks = append(report.Checks, requirementCheck{
+			Name:     "conversation resolution",
+			Required: "all threads resolved",
+			Actual:   fmt.Sprintf("%d unresolved thread(s)", unresolved),
+			Status:   metStatus(unresolved == 0),
+		})
+	}
+	if rules.RequireCodeOwnerReview {
+		report.Checks = append(report.Checks, requirementCheck{
+			Name:     "code owner review",
+			Required: "approval from a code owner",
+			Actual:   "not checked",
+			Status:   "unknown",
+		})
+	}
+	if rules.RequireLastPushApproval {
+		report.Checks = append(report.Checks, requirementCheck{
+			Name:     "last push approval",
+			Required: "approval from someone other than the last pusher",
+			Actual:   "not checked",
+			Status:   "unknown",
+		})
+	}
+
+	report.Satisfied = true
+	for _, c := range report.Checks {
+		if c.Status == "unmet" {
+			report.Satisfied = false
+		}
+	}
+	return report
+}
+
+func metStatus(ok bool) string {
+	if ok {
+		return "met"
+	}
+	return "unmet"
+}
+
+func printRequirements(report *requirementsReport) {
+	source := "no rules found"
+	if len(report.Rules.Sources) > 0 {
+		source = strings.Join(report.Rules.Sources, ", ")
+	}
+	fmt.Printf("Requirements for %s (%s):\n", report.Branch, source)
+	if len(report.Checks) == 0 {
+		fmt.Println("  No review requirements")
+	}
+	for _, c := range report.Checks {
+		fmt.Printf("  %-8s %-24s %s (required: %s)\n", "["+c.Status+"]", c.Name, c.Actual, c.Required)
+	}
+	for _, w := range report.Rules.Warnings {
+		fmt.Printf("  Note: %s\n", w)
+	}
+	if report.Satisfied {
+		fmt.Println("Review requirements: satisfied")
+	} else {
+		fmt.Println("Review requirements: not satisfied")
+	}
+}
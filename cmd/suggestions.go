@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/suggestion"
+	"github.com/spf13/cobra"
+)
+
+var (
+	suggestionsJsonOutput bool
+	suggestionsAll        bool
+	suggestionsPatch      bool
+)
+
+var suggestionsCmd = &cobra.Command{
+	Use:   "suggestions [pr-reference]",
+	Short: "List suggested changes from review comments",
+	Long: `List every suggestion block (` + "```suggestion" + `) found in review comments,
+with the file and lines it targets and its status:
+
+  pending - the target lines are unchanged and the suggestion is not applied
+  applied - the PR head already contains the suggested text
+  changed - the target lines changed since the comment was made
+
+By default, suggestions in resolved threads are hidden. Use --all to show them.
+
+With --patch, all pending suggestions are written to stdout as a unified diff
+against the PR head, ready for 'git apply'. Suggestions that overlap an
+earlier one in the same file are skipped with a warning.
+
+If no PR reference is given, finds the PR for the current branch.
+
+PR reference can be:
+  - Full URL: https://github.com/owner/repo/pull/123
+  - Short form: owner/repo/123
+  - Just number: 123 (when in a repo context)
+  - Omitted: uses current branch's PR
+
+Examples:
+  gh pr-comments suggestions
+  gh pr-comments suggestions --all
+  gh pr-comments suggestions owner/repo/123 --json
+  gh pr-comments suggestions --patch > fixes.patch && git apply fixes.patch`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runSuggestions,
+}
+
+func init() {
+	suggestionsCmd.Flags().BoolVar(&suggestionsJsonOutput, "json", false, "Output in JSON format")
+	suggestionsCmd.Flags().BoolVar(&suggestionsAll, "all", false, "Include suggestions in resolved threads")
+	suggestionsCmd.Flags().BoolVar(&suggestionsPatch, "patch", false, "Write pending suggestions as a unified diff")
+	rootCmd.AddCommand(suggestionsCmd)
+}
+
+type suggestionRow struct {
+	CommentID  int64  `json:"comment_id"`
+	Author     string `json:"author"`
+	File       string `json:"file"`
+	StartLine  int    `json:"start_line"`
+	EndLine    int    `json:"end_line"`
+	Resolved   bool   `json:"resolved"`
+	Outdated   bool   `json:"outdated"`
+	Status     string `json:"status"`
+	Suggestion string `json:"suggestion"`
+	URL        string `json:"url"`
+}
+
+func runSuggestions(cmd *cobra.Command, args []string) error {
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
+	if err != nil {
+		return err
+	}
+
+	pr, err := client.GetPullRequest(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	comments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	heads := newHeadFiles(client, prRef, pr.Head.SHA)
+
+	var rows []suggestionRow
+	for _, c := range comments {
+		if c.IsResolved && !suggestionsAll {
+			continue
+		}
+		if c.CurrentLine() == 0 {
+			continue
+		}
+		for _, s := range suggestion.Extract(c.Body) {
+			row := suggestionRow{
+				CommentID:  c.ID,
+				Author:     c.User.Login,
+				File:       c.Path,
+				Resolved:   c.IsResolved,
+				Outdated:   c.IsOutdated(),
+				Suggestion: s,
+				URL:        c.HTMLURL,
+			}
+			row.StartLine, row.EndLine = suggestionTarget(c)
+			row.Status = suggestionStatus(row, heads)
+			rows = append(rows, row)
+		}
+	}
+
+	if suggestionsPatch {
+		return writeSuggestionsPatch(rows, heads)
+	}
+
+	if suggestionsJsonOutput {
+		return writeJSON(rows)
+	}
+
+	if len(rows) == 0 {
+		fmt.Println("No suggestions found.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tFILE\tLINE\tSTATUS\tRESOLVED\tAUTHOR\tSUGGESTION")
+	for _, r := range rows {
+		line := fmt.Sprintf("%d", r.EndLine)
+		if r.StartLine != r.EndLine {
+			line = fmt.Sprintf("%d-%d", r.StartLine, r.EndLine)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%v\t%s\t%s\n",
+			r.CommentID, r.File, line, r.Status, r.Resolved, r.Author, github.TruncateString(r.Suggestion, 40))
+	}
+	return w.Flush()
+}
+
+func writeSuggestionsPatch(rows []suggestionRow, heads *headFiles) error {
+	editsByFile := make(map[string][]suggestion.Edit)
+	var files []string
+	for _, r := range rows {
+		if r.Status != "pending" {
+			continue
+		}
+		var replacement []string
+		if r.Suggestion != "" {
+			replacement = strings.Split(r.Suggestion, "\n")
+		}
+		if _, ok := editsByFile[r.File]; !ok {
+			files = append(files, r.File)
+		}
+		editsByFile[r.File] = append(editsByFile[r.File], suggestion.Edit{
+			Start:       r.StartLine,
+			End:         r.EndLine,
+			Replacement: replacement,
+		})
+	}
+
+	if len(files) == 0 {
+		fmt.Fprintln(os.Stderr, "No pending suggestions to export.")
+		return nil
+	}
+
+	sort.Strings(files)
+	for _, file := range files {
+		edits := editsByFile[file]
+		sort.SliceStable(edits, func(i, j int) bool {
+			return edits[i].Start < edits[j].Start
+		})
+
+		var kept []suggestion.Edit
+		for _, e := range edits {
+			if len(kept) > 0 && e.Start <= kept[len(kept)-1].End {
+				fmt.Fprintf(os.Stderr, "Warning: skipping suggestion on %s:%d-%d, it overlaps another suggestion\n", file, e.Start, e.End)
+				continue
+			}
+			kept = append(kept, e)
+		}
+
+		lines, err := heads.Lines(file)
+		if err != nil {
+			return err
+		}
+		fmt.Print(suggestion.Patch(file, lines, kept))
+	}
+	return nil
+}
+
+// suggestionTarget returns the first and last line a suggestion replaces.
+// Outdated comments only have their original position.
+func suggestionTarget(c github.ReviewComment) (int, int) {
+	end := c.CurrentLine()
+	start := end
+	if c.IsOutdated() {
+		if c.OriginalStartLine != nil {
+			start = *c.OriginalStartLine
+		}
+	} else if c.StartLine != nil {
+		start = *c.StartLine
+	}
+	return start, end
+}
+
+func suggestionStatus(r suggestionRow, heads *headFiles) string {
+	if r.Outdated {
+		return "changed"
+	}
+	lines, err := heads.Lines(r.File)
+	if err != nil || r.EndLine > len(lines) {
+		return "changed"
+	}
+	current := strings.Join(lines[r.StartLine-1:r.EndLine], "\n")
+	if current == r.Suggestion {
+		return "applied"
+	}
+	return "pending"
+}
+
+// headFiles fetches files at the PR head on demand and caches them by path.
+type headFiles struct {
+	client *github.Client
+	prRef  *github.PRReference
+	sha    string
+	files  map[string][]string
+	errs   map[string]error
+}
+
+func newHeadFiles(client *github.Client, prRef *github.PRReference, sha string) *headFiles {
+	return &headFiles{
+		client: client,
+		prRef:  prRef,
+		sha:    sha,
+		files:  make(map[string][]string),
+		errs:   make(map[string]error),
+	}
+}
+
+// Lines returns the file's lines without trailing newlines.
+func (h *headFiles) Lines(path string) ([]string, error) {
+	if lines, ok := h.files[path]; ok {
+		return lines, nil
+	}
+	if err, ok := h.errs[path]; ok {
+		return nil, err
+	}
+	data, err := h.client.GetFileContent(h.prRef.Owner, h.prRef.Repo, path, h.sha)
+	if err != nil {
+		h.errs[path] = err
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	h.files[path] = lines
+	return lines, nil
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/kballard/go-shellquote"
+	"github.com/spf13/cobra"
+)
+
+var (
+	summarizeCmdLine string
+	summarizeDryRun  bool
+)
+
+var summarizeCmd = &cobra.Command{
+	Use:   "summarize [pr-reference]",
+	Short: "Pipe unresolved threads to an external command, e.g. an LLM CLI",
+	Long: `Render every unresolved review thread as structured plain text and pipe it
+to an external command on stdin, printing whatever the command writes. Use
+it to plug in your own LLM for a summary of the current review round.
+
+The command comes from --cmd, or the GH_PR_COMMENTS_SUMMARIZE_CMD
+environment variable. It is split like a shell command line but not run
+through a shell; wrap pipelines in 'sh -c' if you need them.
+
+--dry-run prints the text that would be piped instead of running anything.
+
+If no PR reference is given, finds the PR for the current branch.
+
+PR reference can be:
+  - Full URL: https://github.com/owner/repo/pull/123
+  - Short form: owner/repo/123
+  - Just number: 123 (when in a repo context)
+  - Omitted: uses current branch's PR
+
+Examples:
+  gh pr-comments summarize --cmd 'llm -s "Summarize the open review feedback"'
+  GH_PR_COMMENTS_SUMMARIZE_CMD='ollama run llama3' gh pr-comments summarize
+  gh pr-comments summarize --dry-run`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runSummarize,
+}
+
+func init() {
+	summarizeCmd.Flags().StringVar(&summarizeCmdLine, "cmd", "", "Command to pipe the threads to (default: $GH_PR_COMMENTS_SUMMARIZE_CMD)")
+	summarizeCmd.Flags().BoolVar(&summarizeDryRun, "dry-run", false, "Print the text that would be piped instead of running the command")
+	rootCmd.AddCommand(summarizeCmd)
+}
+
+func runSummarize(cmd *cobra.Command, args []string) error {
+	cmdLine := summarizeCmdLine
+	if cmdLine == "" {
+		cmdLine = os.Getenv("GH_PR_COMMENTS_SUMMARIZE_CMD")
+	}
+	var parts []string
+	if !summarizeDryRun {
+		if cmdLine == "" {
+			return fmt.Errorf("no summarize command configured\nPass --cmd or set GH_PR_COMMENTS_SUMMARIZE_CMD")
+		}
+		var err error
+		parts, err = shellquote.Split(cmdLine)
+		if err != nil || len(parts) == 0 {
+			return fmt.Errorf("invalid summarize command: %q", cmdLine)
+		}
+	}
+
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
+	if err != nil {
+		return err
+	}
+
+	pr, err := client.GetPullRequest(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	threads, err := client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return fmt.Errorf("get review threads: %w", err)
+	}
+
+	var unresolved []github.ReviewThread
+	for _, t := range threads {
+		if !t.IsResolved {
+			unresolved = append(unresolved, t)
+		}
+	}
+	if len(unresolved) == 0 {
+		fmt.Println("No unresolved threads to summarize.")
+		return nil
+	}
+
+	input := summarizeInput(pr, unresolved)
+	if summarizeDryRun {
+		fmt.Print(input)
+		return nil
+	}
+
+	c := exec.Command(parts[0], parts[1:]...)
+	c.Stdin = strings.NewReader(input)
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("run %s: %w", parts[0], err)
+	}
+	return nil
+}
+
+// summarizeInput renders the PR and its unresolved threads as Markdown-ish
+// text that reads well to both people and language models.
+func summarizeInput(pr *github.PullRequest, threads []github.ReviewThread) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# PR #%d: %s\n\n", pr.Number, pr.Title)
+	fmt.Fprintf(&b, "Author: %s\n", pr.User.Login)
+	fmt.Fprintf(&b, "Branches: %s <- %s\n", pr.Base.Ref, pr.Head.Ref)
+	fmt.Fprintf(&b, "Unresolved threads: %d\n", len(threads))
+
+	for i, t := range threads {
+		state := "unresolved"
+		if t.IsOutdated {
+			state += ", outdated"
+		}
+		fmt.Fprintf(&b, "\n## Thread %d: %s (%s)\n", i+1, t.Location(), state)
+		for _, c := range t.Comments {
+			fmt.Fprintf(&b, "\n[%s, %s, comment %d]\n", c.Author, c.CreatedAt.Format("2006-01-02 15:04"), c.ID)
+			fmt.Fprintln(&b, strings.TrimSpace(c.Body))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	summaryStepSummary bool
+	summaryNoRedact    bool
+)
+
+var summaryCmd = &cobra.Command{
+	Use:   "summary [pr-reference]",
+	Short: "Render review states and unresolved threads as Markdown",
+	Long: `Render a Markdown report of a pull request's review feedback: each
+reviewer's latest review state and a table of unresolved threads with links.
+
+With --github-step-summary, the report is appended to the file named by
+$GITHUB_STEP_SUMMARY, so every GitHub Actions run shows outstanding review
+feedback on its summary page. Otherwise it is printed.
+
+Credentials pasted into comments (GitHub, AWS, Slack and other API tokens,
+JWTs, bearer tokens, private keys) are replaced with [REDACTED]. Add your
+own patterns with 'git config --add pr-comments.redact <regexp>'; only a
+group named "secret" is masked when the pattern has one. --no-redact
+turns this off.
+
+If no PR reference is given, finds the PR for the current branch (inside
+GitHub Actions, the PR that triggered the run).
+
+PR reference can be:
+  - Full URL: https://github.com/owner/repo/pull/123
+  - Short form: owner/repo/123
+  - Just number: 123 (when in a repo context)
+  - Omitted: uses current branch's PR
+
+Examples:
+  gh pr-comments summary
+  gh pr-comments summary owner/repo/123 > feedback.md
+  gh pr-comments summary --github-step-summary`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runSummary,
+}
+
+func init() {
+	summaryCmd.Flags().BoolVar(&summaryStepSummary, "github-step-summary", false, "Append the report to $GITHUB_STEP_SUMMARY")
+	summaryCmd.Flags().BoolVar(&summaryNoRedact, "no-redact", false, "Keep credentials found in comments")
+	rootCmd.AddCommand(summaryCmd)
+}
+
+func runSummary(cmd *cobra.Command, args []string) error {
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if summaryStepSummary && path == "" {
+		return fmt.Errorf("GITHUB_STEP_SUMMARY is not set; --github-step-summary only works inside GitHub Actions")
+	}
+	redactor, err := outputRedactor(summaryNoRedact)
+	if err != nil {
+		return err
+	}
+
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
+	if err != nil {
+		return err
+	}
+
+	pr, err := client.GetPullRequest(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+	reviews, err := client.GetReviews(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+	threads, err := client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return fmt.Errorf("get review threads: %w", err)
+	}
+
+	report := redactText(redactor, summaryMarkdown(pr, latestReviews(reviews), threads))
+	if !summaryStepSummary {
+		fmt.Print(report)
+		return nil
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open step summary: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(report); err != nil {
+		return fmt.Errorf("write step summary: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Wrote review summary for PR #%d to the job summary.\n", pr.Number)
+	return nil
+}
+
+func summaryMarkdown(pr *github.PullRequest, reviews []github.Review, threads []github.ReviewThread) string {
+	var unresolved []github.ReviewThread
+	for _, t := range threads {
+		if !t.IsResolved {
+			unresolved = append(unresolved, t)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Review feedback: [#%d %s](%s)\n\n", pr.Number, markdownCell(pr.Title), pr.HTMLURL)
+	fmt.Fprintf(&b, "%d unresolved of %d review thread(s).\n\n", len(unresolved), len(threads))
+
+	b.WriteString("### Reviews\n\n")
+	if len(reviews) == 0 {
+		b.WriteString("No reviews yet.\n\n")
+	} else {
+		b.WriteString("| Reviewer | State | Submitted |\n|---|---|---|\n")
+		for _, r := range reviews {
+			fmt.Fprintf(&b, "| @%s | %s | %s |\n", r.User.Login, r.State, r.SubmittedAt.Format("2006-01-02 15:04"))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("### Unresolved threads\n\n")
+	if len(unresolved) == 0 {
+		b.WriteString("None. :tada:\n")
+		return b.String()
+	}
+	b.WriteString("| Location | Author | Last activity | Comment |\n|---|---|---|---|\n")
+	for _, t := range unresolved {
+		if len(t.Comments) == 0 {
+			continue
+		}
+		first, last := t.Comments[0], t.LastComment()
+		location := markdownCell(t.Location())
+		if t.IsOutdated {
+			location += " (outdated)"
+		}
+		fmt.Fprintf(&b, "| [%s](%s#discussion_r%d) | @%s | %s by @%s | %s |\n",
+			location, pr.HTMLURL, first.ID, first.Author,
+			last.CreatedAt.Format("2006-01-02 15:04"), last.Author,
+			markdownCell(github.TruncateString(first.Body, 80)))
+	}
+	return b.String()
+}
+
+// markdownCell flattens s onto one line and escapes pipes so it fits in a
+// Markdown table cell.
+func markdownCell(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
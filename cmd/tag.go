@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/state"
+	"github.com/spf13/cobra"
+)
+
+const tagStateFile = "tags.json"
+
+var tagLabels = []string{"will-fix", "wont-fix", "question"}
+
+var (
+	tagPR         string
+	tagClear      bool
+	tagJsonOutput bool
+)
+
+var tagCmd = &cobra.Command{
+	Use:   "tag <comment-id> [will-fix|wont-fix|question]",
+	Short: "Label a comment locally for triage",
+	Long: `Attach a private triage label to a comment. Labels are stored in a local
+state file per PR and never sent to GitHub, so you can plan a response pass
+before touching the PR.
+
+Labels show up in the TAG column of 'list' and can be filtered with
+'list --tag'.
+
+With only a comment ID, prints the comment's current label.
+
+Examples:
+  gh pr-comments tag 2621968472 will-fix
+  gh pr-comments tag 2621968513 question
+  gh pr-comments tag 2621968472 --clear
+  gh pr-comments list --tag will-fix`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runTag,
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 1 {
+			return tagLabels, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeCommentIDs(cmd, args, toComplete)
+	},
+}
+
+func init() {
+	tagCmd.Flags().StringVar(&tagPR, "pr", "", "PR reference (e.g., owner/repo/123 or just 123)")
+	tagCmd.RegisterFlagCompletionFunc("pr", completePRs)
+	tagCmd.Flags().BoolVar(&tagClear, "clear", false, "Remove the comment's label")
+	tagCmd.Flags().BoolVar(&tagJsonOutput, "json", false, "Output in JSON format")
+	rootCmd.AddCommand(tagCmd)
+}
+
+// commentTags maps a PR key to the label of each tagged comment.
+type commentTags map[string]map[int64]string
+
+func runTag(cmd *cobra.Command, args []string) error {
+	commentID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid comment ID: %s", args[0])
+	}
+
+	label := ""
+	if len(args) == 2 {
+		if tagClear {
+			return fmt.Errorf("--clear cannot be combined with a label")
+		}
+		label = args[1]
+		if !isTagLabel(label) {
+			return fmt.Errorf("invalid label: %s (valid: will-fix, wont-fix, question)", label)
+		}
+	}
+
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	var prArgs []string
+	if tagPR != "" {
+		prArgs = []string{tagPR}
+	}
+
+	prRef, err := client.ResolvePRReference(prArgs)
+	if err != nil {
+		return fmt.Errorf("could not determine PR: %w\nPlease specify a PR with --pr or run from a branch with an associated PR", err)
+	}
+
+	tags := commentTags{}
+	if err := state.Load(tagStateFile, &tags); err != nil {
+		return err
+	}
+	key := state.PRKey(prRef.Owner, prRef.Repo, prRef.Number)
+
+	switch {
+	case tagClear:
+		delete(tags[key], commentID)
+		if len(tags[key]) == 0 {
+			delete(tags, key)
+		}
+		if err := state.Save(tagStateFile, tags); err != nil {
+			return err
+		}
+	case label != "":
+		item, err := findPRItem(client, prRef, commentID)
+		if err != nil {
+			return err
+		}
+		if item == nil {
+			return fmt.Errorf("comment with ID %d not found in PR %d", commentID, prRef.Number)
+		}
+		if tags[key] == nil {
+			tags[key] = make(map[int64]string)
+		}
+		tags[key][commentID] = label
+		if err := state.Save(tagStateFile, tags); err != nil {
+			return err
+		}
+	default:
+		label = tags[key][commentID]
+	}
+
+	if tagJsonOutput {
+		return writeJSON(struct {
+			ID  int64  `json:"id"`
+			Tag string `json:"tag"`
+		}{commentID, label})
+	}
+
+	switch {
+	case tagClear:
+		fmt.Printf("Cleared label on comment %d\n", commentID)
+	case label == "":
+		fmt.Printf("Comment %d has no label\n", commentID)
+	case len(args) == 2:
+		fmt.Printf("Tagged comment %d as %s\n", commentID, label)
+	default:
+		fmt.Printf("Comment %d: %s\n", commentID, label)
+	}
+	return nil
+}
+
+// loadTags returns the labels of comments on one PR.
+func loadTags(prRef *github.PRReference) (map[int64]string, error) {
+	tags := commentTags{}
+	if err := state.Load(tagStateFile, &tags); err != nil {
+		return nil, err
+	}
+	return tags[state.PRKey(prRef.Owner, prRef.Repo, prRef.Number)], nil
+}
+
+func isTagLabel(label string) bool {
+	for _, l := range tagLabels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
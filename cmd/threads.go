@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	threadsJsonOutput bool
+	threadsAll        bool
+	threadsResolved   string
+)
+
+var threadsCmd = &cobra.Command{
+	Use:   "threads [pr-reference]",
+	Short: "List review threads on a pull request",
+	Long: `List review threads as rows, with the GraphQL thread ID, location,
+resolution state, number of comments, and the most recent activity.
+
+Thread IDs can be passed to 'resolve --thread' directly.
+
+By default, resolved threads are hidden. Use --all to show all threads,
+or --resolved=true to show only resolved threads.
+
+If no PR reference is given, finds the PR for the current branch.
+
+Examples:
+  gh pr-comments threads
+  gh pr-comments threads --all
+  gh pr-comments threads owner/repo/123 --json
+  gh pr-comments resolve --thread PRRT_kwDOABCD1234`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runThreads,
+}
+
+func init() {
+	threadsCmd.Flags().BoolVar(&threadsJsonOutput, "json", false, "Output in JSON format")
+	threadsCmd.Flags().BoolVar(&threadsAll, "all", false, "Show all threads including resolved")
+	threadsCmd.Flags().StringVar(&threadsResolved, "resolved", "", "Filter by resolved status (true/false)")
+	threadsCmd.RegisterFlagCompletionFunc("resolved", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"true\tShow only resolved threads", "false\tShow only unresolved threads"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	rootCmd.AddCommand(threadsCmd)
+}
+
+type threadRow struct {
+	ID           string                 `json:"id"`
+	Location     string                 `json:"location"`
+	Resolved     bool                   `json:"resolved"`
+	Outdated     bool                   `json:"outdated"`
+	CommentCount int                    `json:"comment_count"`
+	LastAuthor   string                 `json:"last_author"`
+	LastActivity string                 `json:"last_activity"`
+	Comments     []github.ThreadComment `json:"comments"`
+}
+
+func runThreads(cmd *cobra.Command, args []string) error {
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
+	if err != nil {
+		return err
+	}
+
+	threads, err := client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return fmt.Errorf("get review threads: %w", err)
+	}
+
+	rows := buildThreadRows(threads, threadsAll, threadsResolved)
+
+	if threadsJsonOutput {
+		return writeJSON(rows)
+	}
+
+	if len(rows) == 0 {
+		fmt.Println("No review threads found.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "THREAD ID\tLOCATION\tRESOLVED\tCOMMENTS\tLAST AUTHOR\tLAST ACTIVITY")
+	for _, r := range rows {
+		fmt.Fprintf(w, "%s\t%s\t%v\t%d\t%s\t%s\n",
+			r.ID, r.Location, r.Resolved, r.CommentCount, r.LastAuthor, r.LastActivity)
+	}
+	return w.Flush()
+}
+
+// buildThreadRows turns threads into rows, keeping only unresolved threads
+// unless all is set or resolved asks for "true".
+func buildThreadRows(threads []github.ReviewThread, all bool, resolved string) []threadRow {
+	var rows []threadRow
+	for _, t := range threads {
+		if !all {
+			if resolved == "true" && !t.IsResolved {
+				continue
+			}
+			if (resolved == "false" || resolved == "") && t.IsResolved {
+				continue
+			}
+		}
+
+		row := threadRow{
+			ID:           t.ID,
+			Location:     t.Location(),
+			Resolved:     t.IsResolved,
+			Outdated:     t.IsOutdated,
+			CommentCount: len(t.Comments),
+			Comments:     t.Comments,
+		}
+		if last := t.LastComment(); last != nil {
+			row.LastAuthor = last.Author
+			row.LastActivity = last.CreatedAt.Format("2006-01-02 15:04")
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
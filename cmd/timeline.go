@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var timelineJsonOutput bool
+
+var timelineCmd = &cobra.Command{
+	Use:   "timeline [pr-reference]",
+	Short: "Show reviews, comments, pushes, and review requests in order",
+	Long: `Show the pull request's history as one chronological list: reviews,
+general comments, pushed commits, force pushes, and review requests.
+
+Inline review comments are counted on the review they belong to; use 'list'
+or 'tree' to read them. Commits are placed at their commit date, which can
+be earlier than when they were pushed.
+
+If no PR reference is given, finds the PR for the current branch.
+
+PR reference can be:
+  - Full URL: https://github.com/owner/repo/pull/123
+  - Short form: owner/repo/123
+  - Just number: 123 (when in a repo context)
+  - Omitted: uses current branch's PR
+
+Examples:
+  gh pr-comments timeline
+  gh pr-comments timeline owner/repo/123
+  gh pr-comments timeline --json`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runTimeline,
+}
+
+func init() {
+	timelineCmd.Flags().BoolVar(&timelineJsonOutput, "json", false, "Output in JSON format")
+	rootCmd.AddCommand(timelineCmd)
+}
+
+func runTimeline(cmd *cobra.Command, args []string) error {
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
+	if err != nil {
+		return err
+	}
+
+	events, err := client.GetTimeline(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+
+	if timelineJsonOutput {
+		if events == nil {
+			events = []github.TimelineEvent{}
+		}
+		return writeJSON(events)
+	}
+
+	if len(events) == 0 {
+		fmt.Println("No timeline events found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tEVENT\tACTOR\tDETAIL")
+	for _, e := range events {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", e.CreatedAt.Format("2006-01-02 15:04"), strings.ReplaceAll(e.Kind, "_", " "), e.Actor, e.Detail)
+	}
+	w.Flush()
+	fmt.Printf("\nTotal: %d event(s)\n", len(events))
+	return nil
+}
+
+// lastPushTime returns when the PR's head last changed: the latest commit
+// date or force push, whichever is later.
+func lastPushTime(events []github.TimelineEvent) time.Time {
+	var last time.Time
+	for _, e := range events {
+		if (e.Kind == "commit" || e.Kind == "force_push") && e.CreatedAt.After(last) {
+			last = e.CreatedAt
+		}
+	}
+	return last
+}
+
+// lastForcePush returns the most recent force push, or nil if the branch was
+// never force-pushed.
+func lastForcePush(events []github.TimelineEvent) *github.TimelineEvent {
+	var last *github.TimelineEvent
+	for i, e := range events {
+		if e.Kind == "force_push" && (last == nil || e.CreatedAt.After(last.CreatedAt)) {
+			last = &events[i]
+		}
+	}
+	return last
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	todoPR     string
+	todoOutput string
+	todoPost   bool
+	todoAll    bool
+)
+
+var todoCmd = &cobra.Command{
+	Use:   "todo",
+	Short: "Turn unresolved review threads into a Markdown checklist",
+	Long: `Print a Markdown checklist with one item per unresolved review thread:
+its location, a one-line summary of the first comment, and a link.
+
+With --all, resolved threads are included as checked items, which makes the
+list useful for tracking progress through a large review round.
+
+The checklist is printed to stdout, written to a file with --output, or
+posted as a PR comment with --post.
+
+Examples:
+  gh pr-comments todo
+  gh pr-comments todo --all --output REVIEW_TODO.md
+  gh pr-comments todo --post`,
+	Args: cobra.NoArgs,
+	RunE: runTodo,
+}
+
+func init() {
+	todoCmd.Flags().StringVar(&todoPR, "pr", "", "PR reference (e.g., owner/repo/123 or just 123)")
+	todoCmd.RegisterFlagCompletionFunc("pr", completePRs)
+	todoCmd.Flags().StringVarP(&todoOutput, "output", "o", "", "Write the checklist to this file")
+	todoCmd.Flags().BoolVar(&todoPost, "post", false, "Post the checklist as a PR comment")
+	todoCmd.Flags().BoolVar(&todoAll, "all", false, "Include resolved threads as checked items")
+	rootCmd.AddCommand(todoCmd)
+}
+
+func runTodo(cmd *cobra.Command, args []string) error {
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	var prArgs []string
+	if todoPR != "" {
+		prArgs = []string{todoPR}
+	}
+
+	prRef, err := client.ResolvePRReference(prArgs)
+	if err != nil {
+		return fmt.Errorf("could not determine PR: %w\nPlease specify a PR with --pr or run from a branch with an associated PR", err)
+	}
+
+	threads, err := client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return fmt.Errorf("get review threads: %w", err)
+	}
+
+	comments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+	urlByID := make(map[int64]string)
+	for _, c := range comments {
+		urlByID[c.ID] = c.HTMLURL
+	}
+
+	checklist := buildTodoChecklist(prRef, threads, urlByID)
+
+	if todoOutput != "" {
+		if err := os.WriteFile(todoOutput, []byte(checklist), 0o644); err != nil {
+			return fmt.Errorf("write checklist: %w", err)
+		}
+		fmt.Printf("Wrote checklist to %s\n", todoOutput)
+	}
+
+	if todoPost {
+		created, err := client.CreateIssueComment(prRef.Owner, prRef.Repo, prRef.Number, checklist)
+		if err != nil {
+			return err
+		}
+		journalAction(prRef, journal.Entry{Action: "issue_comment", CommentID: created.ID, URL: created.HTMLURL})
+		fmt.Printf("Posted checklist: %s\n", created.HTMLURL)
+	}
+
+	if todoOutput == "" && !todoPost {
+		fmt.Print(checklist)
+	}
+	return nil
+}
+
+func buildTodoChecklist(prRef *github.PRReference, threads []github.ReviewThread, urlByID map[int64]string) string {
+	var b strings.Builder
+	open, done := 0, 0
+
+	var items []string
+	for _, t := range threads {
+		if t.IsResolved && !todoAll {
+			continue
+		}
+		if len(t.Comments) == 0 {
+			continue
+		}
+
+		box := "[ ]"
+		if t.IsResolved {
+			box = "[x]"
+			done++
+		} else {
+			open++
+		}
+
+		first := t.Comments[0]
+		item := fmt.Sprintf("- %s `%s` %s (@%s)", box, t.Location(), todoSummary(first.Body), first.Author)
+		if url := urlByID[first.ID]; url != "" {
+			item += fmt.Sprintf(" — [link](%s)", url)
+		}
+		items = append(items, item)
+	}
+
+	fmt.Fprintf(&b, "## Review TODO for %s/%s#%d\n\n", prRef.Owner, prRef.Repo, prRef.Number)
+	if len(items) == 0 {
+		b.WriteString("No unresolved review threads.\n")
+		return b.String()
+	}
+	if todoAll {
+		fmt.Fprintf(&b, "%d of %d done\n\n", done, open+done)
+	}
+	for _, item := range items {
+		b.WriteString(item)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// todoSummary returns the first non-empty line of a comment body, without
+// leading Markdown decoration, shortened to fit a checklist line.
+func todoSummary(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#>*_-` "))
+		if line != "" {
+			return github.TruncateString(line, 80)
+		}
+	}
+	return "(no text)"
+}
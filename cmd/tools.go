@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	toolsOpenAI    bool
+	toolsAnthropic bool
+)
+
+var toolsCmd = &cobra.Command{
+	Use:   "tools",
+	Short: "Print tool definitions for agent frameworks",
+	Long: `Print a JSON tool (function) definition for every command, generated from
+the commands' own names, descriptions, arguments, and flags, so agent
+orchestrators can expose gh pr-comments without hand-written schemas.
+
+Each tool is named after its command path (e.g. gh_pr_comments_list,
+gh_pr_comments_notifications_done). Its parameters are the command's flags,
+named as on the command line, plus "args", an array of positional
+arguments. To run a tool call, invoke the command with each parameter as
+--name=value (repeated for arrays) followed by the args.
+
+--openai emits the Chat Completions "tools" array; --anthropic (the default)
+emits the Messages API "tools" array.
+
+Examples:
+  gh pr-comments tools --anthropic > tools.json
+  gh pr-comments tools --openai | jq '.[].function.name'`,
+	Args: cobra.NoArgs,
+	RunE: runTools,
+}
+
+func init() {
+	toolsCmd.Flags().BoolVar(&toolsOpenAI, "openai", false, "Emit OpenAI function-calling tool definitions")
+	toolsCmd.Flags().BoolVar(&toolsAnthropic, "anthropic", false, "Emit Anthropic tool-use definitions")
+	toolsCmd.MarkFlagsMutuallyExclusive("openai", "anthropic")
+	rootCmd.AddCommand(toolsCmd)
+}
+
+type toolDefinition struct {
+	Name        string
+	Description string
+	Schema      map[string]interface{}
+}
+
+type anthropicTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"input_schema"`
+}
+
+type openAITool struct {
+	Type     string         `json:"type"`
+	Function openAIFunction `json:"function"`
+}
+
+type openAIFunction struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Parameters  map[string]interface{} `json:"parameters"`
+}
+
+func runTools(cmd *cobra.Command, args []string) error {
+	defs := collectToolDefinitions(rootCmd)
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if toolsOpenAI {
+		tools := make([]openAITool, 0, len(defs))
+		for _, d := range defs {
+			tools = append(tools, openAITool{
+				Type:     "function",
+				Function: openAIFunction{Name: d.Name, Description: d.Description, Parameters: d.Schema},
+			})
+		}
+		return enc.Encode(tools)
+	}
+
+	tools := make([]anthropicTool, 0, len(defs))
+	for _, d := range defs {
+		tools = append(tools, anthropicTool{Name: d.Name, Description: d.Description, InputSchema: d.Schema})
+	}
+	return enc.Encode(tools)
+}
+
+// collectToolDefinitions walks the command tree depth first, in cobra's
+// (alphabetical) order, and describes every runnable command.
+func collectToolDefinitions(parent *cobra.Command) []toolDefinition {
+	var defs []toolDefinition
+	for _, c := range parent.Commands() {
+		switch c.Name() {
+		case "help", "completion", "tools":
+			continue
+		}
+		if c.Hidden || c.Deprecated != "" {
+			continue
+		}
+		if c.Runnable() {
+			defs = append(defs, toolDefinitionFor(c))
+		}
+		defs = append(defs, collectToolDefinitions(c)...)
+	}
+	return defs
+}
+
+func toolDefinitionFor(c *cobra.Command) toolDefinition {
+	path := strings.TrimPrefix(c.CommandPath(), rootCmd.Name()+" ")
+	properties := make(map[string]interface{})
+
+	usage := strings.TrimSpace(strings.TrimPrefix(c.Use, c.Name()))
+	var required []string
+	if usage != "" {
+		properties["args"] = map[string]interface{}{
+			"type":        "array",
+			"items":       map[string]interface{}{"type": "string"},
+			"description": "Positional arguments: " + usage,
+		}
+		if strings.Contains(usage, "<") {
+			required = append(required, "args")
+		}
+	}
+
+	addFlag := func(f *pflag.Flag) {
+		if f.Hidden || f.Name == "help" {
+			return
+		}
+		if _, ok := properties[f.Name]; ok {
+			return
+		}
+		properties[f.Name] = flagSchema(f)
+	}
+	c.LocalFlags().VisitAll(addFlag)
+	c.InheritedFlags().VisitAll(addFlag)
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	return toolDefinition{
+		Name:        "gh_pr_comments_" + strings.NewReplacer(" ", "_", "-", "_").Replace(path),
+		Description: fmt.Sprintf("%s. Runs `gh pr-comments %s`.", strings.TrimSuffix(c.Short, "."), path),
+		Schema:      schema,
+	}
+}
+
+// flagSchema maps a pflag type to a JSON Schema type, falling back to string
+// for types such as durations that are passed as text. Defaults are given in
+// the declared type.
+func flagSchema(f *pflag.Flag) map[string]interface{} {
+	schema := map[string]interface{}{"description": f.Usage}
+	itemType := ""
+	switch f.Value.Type() {
+	case "bool":
+		schema["type"] = "boolean"
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "count":
+		schema["type"] = "integer"
+	case "float32", "float64":
+		schema["type"] = "number"
+	case "stringSlice", "stringArray":
+		itemType = "string"
+	case "intSlice", "int32Slice", "int64Slice", "uintSlice":
+		itemType = "integer"
+	default:
+		schema["type"] = "string"
+	}
+	if itemType != "" {
+		schema["type"] = "array"
+		schema["items"] = map[string]interface{}{"type": itemType}
+	}
+	if def, ok := flagDefault(f, schema["type"].(string), itemType); ok {
+		schema["default"] = def
+	}
+	return schema
+}
+
+// flagDefault converts a flag's default to the JSON type of its schema. Zero
+// values are omitted.
+func flagDefault(f *pflag.Flag, typ, itemType string) (interface{}, bool) {
+	if f.DefValue == "" || f.DefValue == "false" || f.DefValue == "0" || f.DefValue == "[]" {
+		return nil, false
+	}
+	switch typ {
+	case "boolean":
+		v, err := strconv.ParseBool(f.DefValue)
+		return v, err == nil
+	case "integer":
+		v, err := strconv.ParseInt(f.DefValue, 10, 64)
+		return v, err == nil
+	case "number":
+		v, err := strconv.ParseFloat(f.DefValue, 64)
+		return v, err == nil
+	case "array":
+		sv, ok := f.Value.(pflag.SliceValue)
+		if !ok {
+			return nil, false
+		}
+		items := sv.GetSlice()
+		if itemType == "string" {
+			return items, true
+		}
+		ints := make([]int64, 0, len(items))
+		for _, item := range items {
+			v, err := strconv.ParseInt(item, 10, 64)
+			if err != nil {
+				return nil, false
+			}
+			ints = append(ints, v)
+		}
+		return ints, true
+	}
+	return f.DefValue, true
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/trailer"
+	"github.com/spf13/cobra"
+)
+
+var trailerAmend bool
+
+var trailerCmd = &cobra.Command{
+	Use:               "trailer <comment-id...>",
+	Short:             "Print Resolves-Comment trailers for the threads a commit addresses",
+	ValidArgsFunction: completeReviewCommentIDs,
+	Long: `Print a Resolves-Comment trailer with the permalink of each review
+comment, ready to paste into a commit message:
+
+  Resolves-Comment: https://github.com/owner/repo/pull/123#discussion_r2621968472
+
+With --amend, the trailers are added to the HEAD commit's message instead.
+Once the commit is pushed, 'resolve --from-commits' resolves the threads it
+names. The comments must belong to the PR of the current branch (or --pr).
+
+Examples:
+  gh pr-comments trailer 2621968472 2621968473
+  gh pr-comments trailer 2621968472 --amend
+  git commit -m "Handle poll errors" -m "$(gh pr-comments trailer 2621968513)"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runTrailer,
+}
+
+func init() {
+	trailerCmd.Flags().BoolVar(&trailerAmend, "amend", false, "Add the trailers to the HEAD commit's message")
+	rootCmd.AddCommand(trailerCmd)
+}
+
+func runTrailer(cmd *cobra.Command, args []string) error {
+	var ids []int64
+	for _, arg := range args {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid comment ID: %s", arg)
+		}
+		ids = append(ids, id)
+	}
+
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(nil))
+	if err != nil {
+		return err
+	}
+
+	comments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return err
+	}
+	urls := make(map[int64]string, len(comments))
+	for _, c := range comments {
+		urls[c.ID] = c.HTMLURL
+	}
+
+	var trailers []string
+	for _, id := range ids {
+		url, ok := urls[id]
+		if !ok {
+			return fmt.Errorf("review comment with ID %d not found in PR %d", id, prRef.Number)
+		}
+		trailers = append(trailers, trailer.Format(url))
+	}
+
+	if !trailerAmend {
+		for _, t := range trailers {
+			fmt.Println(t)
+		}
+		return nil
+	}
+
+	if err := github.AmendTrailers(trailers); err != nil {
+		return err
+	}
+	fmt.Printf("Added %d %s trailer(s) to HEAD\n", len(trailers), trailer.ResolvesComment)
+	return nil
+}
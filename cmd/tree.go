@@ -1,11 +1,12 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
+	"runtime"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/STRRL/gh-pr-comments/internal/github"
 	"github.com/spf13/cobra"
@@ -13,7 +14,13 @@ import (
 
 var (
 	treeJsonOutput bool
-	treeAll        bool
+	treeFilter     commentFilter
+	treeASCII      bool
+	treeCompact    bool
+	treeDepth      string
+	treeCollapse   bool
+	treeNoEmoji    bool
+	treeLatest     bool
 )
 
 var treeCmd = &cobra.Command{
@@ -23,6 +30,15 @@ var treeCmd = &cobra.Command{
 
 By default, resolved comments are hidden. Use --all to show all comments.
 
+Filters (--author, --file, --outdated, --resolved) work like the list
+command's and are applied before rendering. Reviews left without matching
+comments are omitted. --latest-per-marker keeps only the newest of the
+issue comments an author reposted under the same bot marker.
+
+Box-drawing characters are replaced with plain ASCII when --ascii is given,
+or automatically when the locale does not use UTF-8 (use --ascii=false to
+force box-drawing output).
+
 If no PR reference is given, finds the PR for the current branch.
 
 PR reference can be:
@@ -36,14 +52,42 @@ Examples:
   gh pr-comments tree --all
   gh pr-comments tree https://github.com/owner/repo/pull/123
   gh pr-comments tree owner/repo/123
-  gh pr-comments tree 123`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runTree,
+  gh pr-comments tree 123
+  gh pr-comments tree --ascii
+  gh pr-comments tree --author reviewer --file internal/
+  gh pr-comments tree --compact
+  gh pr-comments tree --depth reviews
+  gh pr-comments tree --collapse-bots
+  gh pr-comments tree --latest-per-marker`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runTree,
 }
 
 func init() {
 	treeCmd.Flags().BoolVar(&treeJsonOutput, "json", false, "Output in JSON format")
-	treeCmd.Flags().BoolVar(&treeAll, "all", false, "Show all comments including resolved")
+	treeCmd.Flags().BoolVar(&treeFilter.All, "all", false, "Show all comments including resolved")
+	treeCmd.Flags().StringVar(&treeFilter.Author, "author", "", "Filter by author")
+	treeCmd.Flags().StringVar(&treeFilter.File, "file", "", "Filter by file path, directory, or glob")
+	treeCmd.RegisterFlagCompletionFunc("file", completeCommentedFiles)
+	treeCmd.Flags().StringVar(&treeFilter.Outdated, "outdated", "", "Filter by outdated status (true/false)")
+	treeCmd.Flags().StringVar(&treeFilter.Marker, "marker", "", "Filter by bot marker (substring match)")
+	treeCmd.Flags().BoolVar(&treeLatest, "latest-per-marker", false, "Show only the newest issue comment per bot marker, dropping reposted copies")
+	treeCmd.Flags().StringVar(&treeFilter.Resolved, "resolved", "", "Filter by resolved status (true/false)")
+	treeCmd.RegisterFlagCompletionFunc("outdated", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"true\tShow only outdated comments", "false\tShow only non-outdated comments"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	treeCmd.RegisterFlagCompletionFunc("resolved", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"true\tShow only resolved comments", "false\tShow only unresolved comments"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	treeCmd.Flags().BoolVar(&treeASCII, "ascii", false, "Draw the tree with ASCII characters only")
+	treeCmd.Flags().BoolVar(&treeCompact, "compact", false, "One line per comment, without body previews")
+	treeCmd.Flags().BoolVar(&treeCollapse, "collapse-bots", false, "Fold reviews from bot accounts into one summary line per bot")
+	treeCmd.Flags().BoolVar(&treeNoEmoji, "no-emoji", false, "Show :shortcode: emoji as written")
+	treeCmd.Flags().StringVar(&treeDepth, "depth", "replies", "How deep to expand the tree (reviews/comments/replies)")
+	treeCmd.RegisterFlagCompletionFunc("depth", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"reviews\tOnly reviews", "comments\tReviews and their comments", "replies\tEverything, including replies"}, cobra.ShellCompDirectiveNoFileComp
+	})
 }
 
 type TreeOutput struct {
@@ -53,17 +97,32 @@ type TreeOutput struct {
 }
 
 type ReviewWithComments struct {
-	Review   github.Review           `json:"review"`
-	Comments []github.ReviewComment  `json:"comments"`
+	Review   github.Review        `json:"review"`
+	Comments []CommentWithReplies `json:"comments"`
+
+	// nestedReplies counts this review's replies that are shown under a
+	// thread started in another review.
+	nestedReplies int
+}
+
+type CommentWithReplies struct {
+	github.ReviewComment
+	Replies []github.ReviewComment `json:"replies,omitempty"`
 }
 
 func runTree(cmd *cobra.Command, args []string) error {
+	switch treeDepth {
+	case "reviews", "comments", "replies":
+	default:
+		return fmt.Errorf("invalid depth: %s (valid: reviews, comments, replies)", treeDepth)
+	}
+
 	client, err := github.NewClient()
 	if err != nil {
 		return err
 	}
 
-	prRef, err := client.ResolvePRReference(args)
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
 	if err != nil {
 		return err
 	}
@@ -88,19 +147,39 @@ func runTree(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	commentsByReview := make(map[int64][]github.ReviewComment)
-	for _, c := range reviewComments {
-		if !treeAll && c.IsResolved {
-			continue
+	visible := treeFilter.filterReviewComments(reviewComments)
+
+	if treeLatest {
+		issueComments = latestPerMarker(issueComments)
+	}
+	var visibleIssueComments []github.IssueComment
+	for _, c := range issueComments {
+		if treeFilter.matchIssueComment(c) {
+			visibleIssueComments = append(visibleIssueComments, c)
 		}
+	}
+	issueComments = visibleIssueComments
+
+	commentsByReview := make(map[int64][]CommentWithReplies)
+	nestedReplies := make(map[int64]int)
+	for _, c := range nestReplies(visible) {
 		commentsByReview[c.PullRequestReviewID] = append(commentsByReview[c.PullRequestReviewID], c)
+		for _, reply := range c.Replies {
+			if reply.PullRequestReviewID != c.PullRequestReviewID {
+				nestedReplies[reply.PullRequestReviewID]++
+			}
+		}
 	}
 
 	var reviewsWithComments []ReviewWithComments
 	for _, r := range reviews {
+		if treeFilter.Active() && len(commentsByReview[r.ID]) == 0 && !treeShowsEmptyReview(r) {
+			continue
+		}
 		reviewsWithComments = append(reviewsWithComments, ReviewWithComments{
-			Review:   r,
-			Comments: commentsByReview[r.ID],
+			Review:        r,
+			Comments:      commentsByReview[r.ID],
+			nestedReplies: nestedReplies[r.ID],
 		})
 	}
 
@@ -118,89 +197,278 @@ func runTree(cmd *cobra.Command, args []string) error {
 			Reviews:       reviewsWithComments,
 			IssueComments: issueComments,
 		}
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(output)
+		return writeJSON(output)
+	}
+
+	if !cmd.Flags().Changed("ascii") {
+		treeASCII = !localeSupportsUTF8()
+	}
+	glyphs := unicodeGlyphs
+	if treeASCII {
+		glyphs = asciiGlyphs
 	}
 
-	printTree(pr, reviewsWithComments, issueComments)
+	printTree(glyphs, pr, reviewsWithComments, issueComments)
 	return nil
 }
 
-func printTree(pr *github.PullRequest, reviews []ReviewWithComments, issueComments []github.IssueComment) {
-	fmt.Printf("PR #%d: %s\n", pr.Number, pr.Title)
-	fmt.Println("\u2502")
-
-	for i, r := range reviews {
-		isLastReview := i == len(reviews)-1 && len(issueComments) == 0
-		prefix := "\u251c\u2500\u2500"
-		childPrefix := "\u2502   "
-		if isLastReview {
-			prefix = "\u2514\u2500\u2500"
-			childPrefix = "    "
-		}
-
-		submitted := ""
-		if !r.Review.SubmittedAt.IsZero() {
-			submitted = r.Review.SubmittedAt.Format("2006-01-02")
-		}
-
-		fmt.Printf("%s Review %d by %s (%s) - %s\n",
-			prefix, r.Review.ID, r.Review.User.Login, r.Review.State, submitted)
-
-		if r.Review.Body != "" {
-			body := github.TruncateString(r.Review.Body, 60)
-			fmt.Printf("%s\u2502   %s\n", childPrefix, body)
-		}
-
-		if len(r.Comments) == 0 {
-			fmt.Printf("%s\u2514\u2500\u2500 (no inline comments)\n", childPrefix)
-		} else {
-			for j, c := range r.Comments {
-				isLastComment := j == len(r.Comments)-1
-				commentPrefix := "\u251c\u2500\u2500"
-				if isLastComment {
-					commentPrefix = "\u2514\u2500\u2500"
-				}
-
-				line := ""
-				if c.OriginalLine != nil {
-					line = fmt.Sprintf(":%d", *c.OriginalLine)
-				}
-				var marks []string
-				if c.IsOutdated() {
-					marks = append(marks, "outdated")
-				}
-				if c.IsResolved {
-					marks = append(marks, "resolved")
-				}
-				markStr := ""
-				if len(marks) > 0 {
-					markStr = " (" + strings.Join(marks, ", ") + ")"
-				}
-
-				fmt.Printf("%s%s [%d] %s%s%s\n", childPrefix, commentPrefix, c.ID, c.Path, line, markStr)
-
-				bodyPrefix := childPrefix + "\u2502   "
-				if isLastComment {
-					bodyPrefix = childPrefix + "    "
-				}
-				body := github.TruncateString(c.Body, 60)
-				fmt.Printf("%s\u2514\u2500\u2500 %s\n", bodyPrefix, body)
+// treeShowsEmptyReview keeps reviews without matching comments visible when
+// the only filter is the author, so an author's review bodies stay listed.
+func treeShowsEmptyReview(r github.Review) bool {
+	onlyAuthor := treeFilter.Author != "" && treeFilter.File == "" && treeFilter.Outdated == "" && treeFilter.Resolved == "" && treeFilter.Marker == ""
+	return onlyAuthor && strings.EqualFold(r.User.Login, treeFilter.Author)
+}
+
+// nestReplies attaches each reply to the thread's root comment. Replies whose
+// root is not in comments are kept as roots so nothing is dropped.
+func nestReplies(comments []github.ReviewComment) []CommentWithReplies {
+	present := make(map[int64]bool)
+	for _, c := range comments {
+		if c.InReplyToID == 0 {
+			present[c.ID] = true
+		}
+	}
+
+	repliesByRoot := make(map[int64][]github.ReviewComment)
+	var roots []CommentWithReplies
+	for _, c := range comments {
+		if c.InReplyToID != 0 && present[c.InReplyToID] {
+			repliesByRoot[c.InReplyToID] = append(repliesByRoot[c.InReplyToID], c)
+			continue
+		}
+		roots = append(roots, CommentWithReplies{ReviewComment: c})
+	}
+
+	for i := range roots {
+		roots[i].Replies = repliesByRoot[roots[i].ID]
+	}
+	return roots
+}
+
+type treeNode struct {
+	Label    string
+	Details  []string
+	Children []*treeNode
+	Spacer   bool
+}
+
+type treeGlyphs struct {
+	Tee   string
+	Elbow string
+	Pipe  string
+	Space string
+}
+
+var (
+	unicodeGlyphs = treeGlyphs{
+		Tee:   "\u251c\u2500\u2500 ",
+		Elbow: "\u2514\u2500\u2500 ",
+		Pipe:  "\u2502   ",
+		Space: "    ",
+	}
+	asciiGlyphs = treeGlyphs{
+		Tee:   "|-- ",
+		Elbow: "\\-- ",
+		Pipe:  "|   ",
+		Space: "    ",
+	}
+)
+
+// localeSupportsUTF8 follows the POSIX precedence of LC_ALL, LC_CTYPE, and
+// LANG. An unset locale is assumed to be UTF-8 capable, except on Windows
+// consoles outside Windows Terminal.
+func localeSupportsUTF8() bool {
+	for _, env := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := os.Getenv(env); v != "" {
+			v = strings.ToLower(v)
+			return strings.Contains(v, "utf-8") || strings.Contains(v, "utf8")
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return os.Getenv("WT_SESSION") != ""
+	}
+	return true
+}
+
+func renderTree(g treeGlyphs, nodes []*treeNode, prefix string) {
+	for i, n := range nodes {
+		branch, indent := g.Tee, g.Pipe
+		if i == len(nodes)-1 {
+			branch, indent = g.Elbow, g.Space
+		}
+		fmt.Printf("%s%s%s\n", prefix, branch, n.Label)
+
+		childPrefix := prefix + indent
+		for _, d := range n.Details {
+			bar := g.Space
+			if len(n.Children) > 0 {
+				bar = g.Pipe
 			}
+			fmt.Printf("%s%s%s\n", childPrefix, bar, d)
+		}
+
+		renderTree(g, n.Children, childPrefix)
+
+		if n.Spacer {
+			fmt.Printf("%s\n", childPrefix)
+		}
+	}
+}
+
+func printTree(g treeGlyphs, pr *github.PullRequest, reviews []ReviewWithComments, issueComments []github.IssueComment) {
+	fmt.Printf("PR #%d: %s\n", pr.Number, pr.Title)
+	for _, line := range prMetadataLines(pr) {
+		fmt.Printf("  %s\n", line)
+	}
+	if body := strings.TrimSpace(pr.Body); body != "" {
+		fmt.Printf("  Body:      %s\n", github.TruncateString(bodyText(body, treeNoEmoji), 60))
+	}
+	fmt.Println(strings.TrimRight(g.Pipe, " "))
+
+	var nodes []*treeNode
+	if treeCollapse {
+		nodes = collapsedReviewNodes(reviews)
+	} else {
+		for _, r := range reviews {
+			nodes = append(nodes, reviewNode(r))
 		}
-		fmt.Printf("%s\n", childPrefix)
 	}
 
 	if len(issueComments) > 0 {
-		fmt.Printf("\u2514\u2500\u2500 Issue Comments (%d)\n", len(issueComments))
-		for i, c := range issueComments {
-			isLast := i == len(issueComments)-1
-			prefix := "    \u251c\u2500\u2500"
-			if isLast {
-				prefix = "    \u2514\u2500\u2500"
-			}
-			fmt.Printf("%s %d by %s - %s\n", prefix, c.ID, c.User.Login, c.CreatedAt.Format("2006-01-02"))
+		group := &treeNode{Label: fmt.Sprintf("Issue Comments (%d)", len(issueComments))}
+		for _, c := range issueComments {
+			group.Children = append(group.Children, &treeNode{
+				Label: fmt.Sprintf("%d by %s - %s", c.ID, c.User.Login, c.CreatedAt.Format("2006-01-02")),
+			})
+		}
+		nodes = append(nodes, group)
+	}
+
+	renderTree(g, nodes, "")
+}
+
+// collapsedReviewNodes keeps human reviews expanded and folds each bot's
+// reviews into a single line placed where its latest review would be.
+func collapsedReviewNodes(reviews []ReviewWithComments) []*treeNode {
+	type botSummary struct {
+		reviews  int
+		comments int
+		latest   time.Time
+	}
+	bots := make(map[string]*botSummary)
+	for _, r := range reviews {
+		if !r.Review.User.IsBot() {
+			continue
+		}
+		b := bots[r.Review.User.Login]
+		if b == nil {
+			b = &botSummary{}
+			bots[r.Review.User.Login] = b
+		}
+		b.reviews++
+		b.comments += len(r.Comments)
+		if r.Review.SubmittedAt.After(b.latest) {
+			b.latest = r.Review.SubmittedAt
+		}
+	}
+
+	var nodes []*treeNode
+	seen := make(map[string]int)
+	for _, r := range reviews {
+		login := r.Review.User.Login
+		b, isBot := bots[login]
+		if !isBot {
+			nodes = append(nodes, reviewNode(r))
+			continue
+		}
+		seen[login]++
+		if seen[login] < b.reviews {
+			continue
+		}
+		latest := ""
+		if !b.latest.IsZero() {
+			latest = b.latest.Format("2006-01-02")
+		}
+		nodes = append(nodes, &treeNode{
+			Label: fmt.Sprintf("%s: %d review(s), %d comment(s) (collapsed) - latest %s", login, b.reviews, b.comments, latest),
+		})
+	}
+	return nodes
+}
+
+func reviewNode(r ReviewWithComments) *treeNode {
+	submitted := ""
+	if !r.Review.SubmittedAt.IsZero() {
+		submitted = r.Review.SubmittedAt.Format("2006-01-02")
+	}
+
+	node := &treeNode{
+		Label:  fmt.Sprintf("Review %d by %s (%s) - %s", r.Review.ID, r.Review.User.Login, r.Review.State, submitted),
+		Spacer: !treeCompact && treeDepth != "reviews",
+	}
+
+	if treeDepth == "reviews" {
+		if len(r.Comments) > 0 {
+			node.Label += fmt.Sprintf(" [%d comment(s)]", len(r.Comments))
+		}
+		return node
+	}
+
+	if r.Review.Body != "" && !treeCompact {
+		node.Details = append(node.Details, github.TruncateString(bodyText(r.Review.Body, treeNoEmoji), 60))
+	}
+
+	if len(r.Comments) == 0 {
+		label := "(no inline comments)"
+		if r.nestedReplies > 0 {
+			label = fmt.Sprintf("(%d reply(s), shown under their threads)", r.nestedReplies)
+		}
+		node.Children = append(node.Children, &treeNode{Label: label})
+		return node
+	}
+
+	for _, c := range r.Comments {
+		node.Children = append(node.Children, commentNode(c))
+	}
+	return node
+}
+
+func commentNode(c CommentWithReplies) *treeNode {
+	var marks []string
+	if c.IsFileLevel() {
+		marks = append(marks, "file")
+	}
+	if c.IsOutdated() {
+		marks = append(marks, "outdated")
+	}
+	if c.IsResolved {
+		marks = append(marks, "resolved")
+	}
+	markStr := ""
+	if len(marks) > 0 {
+		markStr = " (" + strings.Join(marks, ", ") + ")"
+	}
+
+	node := &treeNode{Label: fmt.Sprintf("[%d] %s%s", c.ID, c.Location(), markStr)}
+	if treeDepth == "comments" && len(c.Replies) > 0 {
+		node.Label += fmt.Sprintf(" [%d reply(s)]", len(c.Replies))
+	}
+	if treeCompact {
+		node.Label += " by " + c.User.Login
+	} else {
+		node.Children = append(node.Children, &treeNode{Label: github.TruncateString(bodyText(c.Body, treeNoEmoji), 60)})
+	}
+
+	if treeDepth != "replies" {
+		return node
+	}
+
+	for _, reply := range c.Replies {
+		label := fmt.Sprintf("[%d] %s", reply.ID, reply.User.Login)
+		if !treeCompact {
+			label += ": " + github.TruncateString(bodyText(reply.Body, treeNoEmoji), 50)
 		}
+		node.Children = append(node.Children, &treeNode{Label: label})
 	}
+	return node
 }
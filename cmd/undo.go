@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/journal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	undoPR         string
+	undoLast       int
+	undoList       bool
+	undoDryRun     bool
+	undoJsonOutput bool
+)
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Revert recent resolve and hide actions",
+	Long: `Every change this tool makes on GitHub (resolving threads, hiding comments
+and reviews, posting replies and comments) is recorded in a local journal.
+'undo' reverts the most recent reversible actions, newest first:
+
+  resolve - the thread is unresolved
+  hide    - the comment or review is unhidden
+
+Only actions on the current branch's PR (or --pr) are listed and reverted.
+Replies and new comments are journaled for reference but are not reverted.
+Note that 'resolve' hides reviews whose comments are all resolved; those
+hides are journaled too and count toward --last.
+
+The journal keeps the most recent 1000 actions across all PRs.
+
+Examples:
+  gh pr-comments undo
+  gh pr-comments undo --last 5 --dry-run
+  gh pr-comments undo --list --pr owner/repo/123`,
+	Args: cobra.NoArgs,
+	RunE: runUndo,
+}
+
+func init() {
+	undoCmd.Flags().StringVar(&undoPR, "pr", "", "PR reference (e.g., owner/repo/123)")
+	undoCmd.RegisterFlagCompletionFunc("pr", completePRs)
+	undoCmd.Flags().IntVar(&undoLast, "last", 1, "Number of reversible actions to revert")
+	undoCmd.Flags().BoolVar(&undoList, "list", false, "Show the journal instead of reverting")
+	undoCmd.Flags().BoolVar(&undoDryRun, "dry-run", false, "Show what would be reverted")
+	undoCmd.Flags().BoolVar(&undoJsonOutput, "json", false, "Output in JSON format")
+	rootCmd.AddCommand(undoCmd)
+}
+
+type undoResult struct {
+	Entry   journal.Entry `json:"entry"`
+	Action  string        `json:"action"`
+	Success bool          `json:"success"`
+	Error   string        `json:"error,omitempty"`
+}
+
+func runUndo(cmd *cobra.Command, args []string) error {
+	if undoLast < 1 {
+		return fmt.Errorf("--last must be at least 1")
+	}
+
+	if err := requireLiveData("undo"); err != nil {
+		return err
+	}
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	var prArgs []string
+	if undoPR != "" {
+		prArgs = []string{undoPR}
+	}
+	prRef, err := client.ResolvePRReference(prArgs)
+	if err != nil {
+		return fmt.Errorf("could not determine PR: %w\nPlease specify a PR with --pr or run from a branch with an associated PR", err)
+	}
+
+	entries, err := journal.Read()
+	if err != nil {
+		return err
+	}
+	entries = journal.ForPR(entries, prRef.Owner+"/"+prRef.Repo, prRef.Number)
+
+	if undoList {
+		return printJournal(entries)
+	}
+
+	targets := journal.Reversible(entries)
+	if len(targets) > undoLast {
+		targets = targets[:undoLast]
+	}
+	if len(targets) == 0 {
+		if undoJsonOutput {
+			return writeJSON(nil)
+		}
+		fmt.Println("Nothing to undo.")
+		return nil
+	}
+
+	var results []undoResult
+	for _, e := range targets {
+		result := undoResult{Entry: e, Action: e.Inverse()}
+		if undoDryRun {
+			result.Success = true
+			results = append(results, result)
+			continue
+		}
+
+		var opErr error
+		switch e.Action {
+		case "resolve":
+			opErr = client.UnresolveThread(e.ThreadID)
+		case "hide":
+			opErr = client.UnminimizeComment(e.NodeID)
+		}
+		if opErr != nil {
+			result.Error = opErr.Error()
+		} else {
+			result.Success = true
+			journalAction(prRef, journal.Entry{
+				Action:   result.Action,
+				ThreadID: e.ThreadID,
+				NodeID:   e.NodeID,
+				Reverts:  e.ID,
+			})
+		}
+		results = append(results, result)
+	}
+
+	if undoJsonOutput {
+		return writeJSON(results)
+	}
+
+	failCount := 0
+	for _, r := range results {
+		target := r.Entry.ThreadID
+		if target == "" {
+			target = r.Entry.NodeID
+		}
+		switch {
+		case undoDryRun:
+			fmt.Printf("Would %s %s (from %s)\n", r.Action, target, r.Entry.Time.Format("2006-01-02 15:04"))
+		case r.Success:
+			fmt.Printf("Reverted: %s %s\n", r.Action, target)
+		default:
+			failCount++
+			fmt.Fprintf(os.Stderr, "Failed to %s %s: %s\n", r.Action, target, r.Error)
+		}
+	}
+
+	fmt.Println(strings.Repeat("─", 40))
+	if undoDryRun {
+		fmt.Printf("Dry run: %d action(s) would be reverted\n", len(results))
+	} else {
+		fmt.Printf("Processed: %d succeeded, %d failed\n", len(results)-failCount, failCount)
+	}
+	return nil
+}
+
+func printJournal(entries []journal.Entry) error {
+	if undoJsonOutput {
+		if entries == nil {
+			entries = []journal.Entry{}
+		}
+		return writeJSON(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("The journal is empty.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tACTION\tTARGET\tDETAIL")
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		target := e.ThreadID
+		if target == "" {
+			target = e.NodeID
+		}
+		if target == "" && e.CommentID != 0 {
+			target = fmt.Sprintf("%d", e.CommentID)
+		}
+		detail := e.URL
+		if e.Reason != "" {
+			detail = e.Reason
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", e.Time.Format("2006-01-02 15:04:05"), e.Action, target, detail)
+	}
+	return w.Flush()
+}
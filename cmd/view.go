@@ -1,38 +1,117 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/STRRL/gh-pr-comments/internal/journal"
 	"github.com/spf13/cobra"
 )
 
-var viewJsonOutput bool
+var (
+	viewJsonOutput bool
+	viewContext    int
+	viewNoColor    bool
+	viewNoEmoji    bool
+	viewReviewID   int64
+	viewThread     int64
+	viewHistory    int64
+	viewEvolution  int64
+)
 
 var viewCmd = &cobra.Command{
-	Use:               "view <id>",
-	Aliases:           []string{"show"},
-	Short:             "View full content of a review comment, review, or issue comment",
+	Use:     "view <id>...",
+	Aliases: []string{"show"},
+	Short:   "View full content of a review comment, review, or issue comment",
 	Long: `View the full content of an item by its ID.
 
 Automatically detects the type (review comment, review, or issue comment).
 
 The ID can be found from the 'list', 'reviews', or 'tree' command output.
+A link copied from GitHub works too (e.g. .../pull/123#discussion_r2621968472,
+#issuecomment-..., or #pullrequestreview-...); the PR is then taken from the
+link. Passing such a link directly to 'gh pr-comments' also shows it.
+
+Several IDs can be given at once; they are fetched together and printed one
+after another (or as a JSON array with --json). --review-id shows a review
+followed by all of its review comments. --thread shows the whole conversation
+a review comment belongs to: the original comment and every reply in order,
+with the thread's resolution state and participants.
+
+--history shows an audit trail for a review comment: when each comment in its
+thread was posted, every edit to the comment's body and who made it, and who
+resolved the thread. GitHub does not record when a thread was resolved or
+unresolved, so those events only appear with times when they were made
+through this tool and are found in its local journal.
+
+--evolution fetches the commented file at the commit the comment was made on
+and at the PR head, and shows the diff of the commented lines between the
+two, to check whether the concern was actually fixed.
+
+Diff hunks are colored and syntax-highlighted when writing to a terminal.
+Use --no-color (or set NO_COLOR) to print them as plain text.
 
 Examples:
   gh pr-comments view 2621968472
   gh pr-comments show 3581523351
-  gh pr-comments view 2621968472 --json`,
-	Args:              cobra.ExactArgs(1),
-	RunE:              runView,
-	ValidArgsFunction: completeCommentIDs,
+  gh pr-comments view 2621968472 --json
+  gh pr-comments view https://github.com/owner/repo/pull/123#discussion_r2621968472
+  gh pr-comments view 2621968472 2621968480 2621968495
+  gh pr-comments view --review-id 3581523351
+  gh pr-comments view --thread 2621968480
+  gh pr-comments view --history 2621968480
+  gh pr-comments view --evolution 2621968480
+  gh pr-comments view 2621968472 --context 5
+  gh pr-comments view 2621968472 --no-color`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		modes := 0
+		for _, id := range []int64{viewThread, viewHistory, viewEvolution} {
+			if id != 0 {
+				modes++
+			}
+		}
+		if modes > 1 {
+			return fmt.Errorf("only one of --thread, --history, and --evolution can be used")
+		}
+		if modes == 1 {
+			if len(args) > 0 || viewReviewID != 0 {
+				return fmt.Errorf("--thread, --history, and --evolution cannot be combined with IDs or --review-id")
+			}
+			return nil
+		}
+		if len(args) == 0 && viewReviewID == 0 {
+			return fmt.Errorf("requires at least one ID, --review-id, --thread, --history, or --evolution")
+		}
+		return nil
+	},
+	RunE: runView,
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		// Every argument is an ID, so keep completing after the first one.
+		return completeCommentIDs(cmd, nil, toComplete)
+	},
 }
 
 func init() {
 	viewCmd.Flags().BoolVar(&viewJsonOutput, "json", false, "Output in JSON format")
+	viewCmd.Flags().BoolVar(&viewNoColor, "no-color", false, "Disable diff coloring and syntax highlighting")
+	viewCmd.Flags().BoolVar(&viewNoEmoji, "no-emoji", false, "Show :shortcode: emoji as written")
+	viewCmd.Flags().Int64Var(&viewReviewID, "review-id", 0, "Show a review together with all of its comments")
+	viewCmd.Flags().Int64Var(&viewThread, "thread", 0, "Show the whole conversation thread containing this review comment")
+	viewCmd.RegisterFlagCompletionFunc("thread", completeReviewCommentIDs)
+	viewCmd.Flags().Int64Var(&viewHistory, "history", 0, "Show who resolved, unresolved, and edited the thread containing this review comment")
+	viewCmd.RegisterFlagCompletionFunc("history", completeReviewCommentIDs)
+	viewCmd.Flags().Int64Var(&viewEvolution, "evolution", 0, "Diff the lines a review comment points at between its commit and the PR head")
+	viewCmd.RegisterFlagCompletionFunc("evolution", completeReviewCommentIDs)
+	viewCmd.Flags().IntVar(&viewContext, "context", 0, "Show N lines of the commented file from the local working tree")
+	viewCmd.RegisterFlagCompletionFunc("review-id", completeReviewIDs)
 	rootCmd.AddCommand(viewCmd)
 }
 
@@ -42,106 +121,355 @@ func runView(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	id := args[0]
+	ids := make([]int64, 0, len(args))
+	var linkedPR *github.PRReference
+	for _, arg := range args {
+		if ref, id, ok := github.ParseCommentURL(arg); ok {
+			if linkedPR != nil && *linkedPR != *ref {
+				return fmt.Errorf("links point at different pull requests: %s/%s#%d and %s/%s#%d",
+					linkedPR.Owner, linkedPR.Repo, linkedPR.Number, ref.Owner, ref.Repo, ref.Number)
+			}
+			linkedPR = ref
+			ids = append(ids, id)
+			continue
+		}
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid ID: %s", arg)
+		}
+		ids = append(ids, id)
+	}
+
+	prRef := linkedPR
+	if prRef == nil {
+		prRef, err = client.ResolvePRReference(withGlobalPR(nil))
+		if err != nil {
+			return fmt.Errorf("could not determine PR: %w\nPlease run this command from a branch with an associated PR", err)
+		}
+	}
 
-	prRef, err := client.ResolvePRReference(nil)
-	if err != nil {
-		return fmt.Errorf("could not determine PR: %w\nPlease run this command from a branch with an associated PR", err)
+	if viewThread != 0 {
+		return runViewThread(client, prRef, viewThread)
+	}
+	if viewHistory != 0 {
+		return runViewHistory(client, prRef, viewHistory)
+	}
+	if viewEvolution != 0 {
+		return runViewEvolution(client, prRef, viewEvolution)
 	}
 
-	if found, err := tryViewReviewComment(client, prRef, id); err != nil {
+	idx, err := loadPRItems(client, prRef)
+	if err != nil {
 		return err
-	} else if found {
-		return nil
 	}
 
-	if found, err := tryViewReview(client, prRef, id); err != nil {
-		return err
-	} else if found {
-		return nil
+	var items []*prItem
+	if viewReviewID != 0 {
+		reviewItems := idx.ReviewItems(viewReviewID)
+		if reviewItems == nil {
+			return fmt.Errorf("review with ID %d not found in PR %d", viewReviewID, prRef.Number)
+		}
+		items = append(items, reviewItems...)
+	}
+	for _, id := range ids {
+		item := idx.Find(id)
+		if item == nil {
+			return fmt.Errorf("item with ID %d not found in PR %d (searched review comments, reviews, and issue comments)", id, prRef.Number)
+		}
+		items = append(items, item)
+	}
+
+	if viewJsonOutput {
+		if len(items) == 1 && viewReviewID == 0 {
+			return writeJSON(items[0].value())
+		}
+		return writeJSON(items)
 	}
 
-	if found, err := tryViewIssueComment(client, prRef, id); err != nil {
+	notes, err := loadNotes(prRef)
+	if err != nil {
 		return err
-	} else if found {
-		return nil
 	}
 
-	return fmt.Errorf("item with ID %s not found in PR %d (searched review comments, reviews, and issue comments)", id, prRef.Number)
+	for i, item := range items {
+		if i > 0 {
+			fmt.Println(strings.Repeat("═", 60))
+			fmt.Println()
+		}
+		printItemDetail(item)
+		if note, ok := notes[item.ID()]; ok {
+			printNote(note)
+		}
+	}
+	return nil
+}
+
+type threadView struct {
+	RootID       int64                  `json:"root_id"`
+	Location     string                 `json:"location"`
+	Resolved     bool                   `json:"resolved"`
+	Outdated     bool                   `json:"outdated"`
+	Participants []string               `json:"participants"`
+	Comments     []github.ReviewComment `json:"comments"`
 }
 
-func tryViewReviewComment(client *github.Client, prRef *github.PRReference, commentID string) (bool, error) {
+func runViewThread(client *github.Client, prRef *github.PRReference, commentID int64) error {
 	comments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
 	if err != nil {
-		return false, err
+		return err
 	}
 
+	thread := buildThreadView(comments, commentID)
+	if thread == nil {
+		return fmt.Errorf("review comment with ID %d not found in PR %d", commentID, prRef.Number)
+	}
+
+	if viewJsonOutput {
+		return writeJSON(thread)
+	}
+
+	printThreadDetail(thread)
+	return nil
+}
+
+// buildThreadView collects the root of the thread containing commentID and
+// all replies to it, oldest first. GitHub always points in_reply_to_id at the
+// thread's first comment, so one hop is enough to find the root.
+func buildThreadView(comments []github.ReviewComment, commentID int64) *threadView {
+	rootID := int64(0)
 	for _, c := range comments {
-		if fmt.Sprintf("%d", c.ID) == commentID {
-			if viewJsonOutput {
-				enc := json.NewEncoder(os.Stdout)
-				enc.SetIndent("", "  ")
-				return true, enc.Encode(c)
+		if c.ID == commentID {
+			rootID = c.ID
+			if c.InReplyToID != 0 {
+				rootID = c.InReplyToID
 			}
+			break
+		}
+	}
+	if rootID == 0 {
+		return nil
+	}
 
-			printReviewCommentDetail(c)
-			return true, nil
+	var thread []github.ReviewComment
+	for _, c := range comments {
+		if c.ID == rootID || c.InReplyToID == rootID {
+			thread = append(thread, c)
 		}
 	}
+	if len(thread) == 0 {
+		return nil
+	}
+	sort.SliceStable(thread, func(i, j int) bool {
+		return thread[i].CreatedAt.Before(thread[j].CreatedAt)
+	})
 
-	return false, nil
+	root := thread[0]
+	view := &threadView{
+		RootID:   root.ID,
+		Location: root.Location(),
+		Resolved: root.IsResolved,
+		Outdated: root.IsOutdated(),
+		Comments: thread,
+	}
+	seen := make(map[string]bool)
+	for _, c := range thread {
+		if !seen[c.User.Login] {
+			seen[c.User.Login] = true
+			view.Participants = append(view.Participants, c.User.Login)
+		}
+	}
+	return view
 }
 
-func tryViewReview(client *github.Client, prRef *github.PRReference, reviewID string) (bool, error) {
-	reviews, err := client.GetReviews(prRef.Owner, prRef.Repo, prRef.Number)
+func printThreadDetail(t *threadView) {
+	root := t.Comments[0]
+	fmt.Printf("Thread on %s\n", t.Location)
+	fmt.Println(strings.Repeat("─", 60))
+	fmt.Printf("Root ID:      %d\n", t.RootID)
+	fmt.Printf("Resolved:     %v\n", t.Resolved)
+	fmt.Printf("Outdated:     %v\n", t.Outdated)
+	fmt.Printf("Participants: %s\n", strings.Join(t.Participants, ", "))
+	fmt.Printf("Comments:     %d\n", len(t.Comments))
+	fmt.Printf("URL:          %s\n", root.HTMLURL)
+	fmt.Println(strings.Repeat("─", 60))
+
+	if root.DiffHunk != "" {
+		if colorEnabled(viewNoColor) {
+			fmt.Println(highlightDiffHunk(root.DiffHunk, root.Path))
+		} else {
+			fmt.Println(root.DiffHunk)
+		}
+		fmt.Println(strings.Repeat("─", 60))
+	}
+
+	for _, c := range t.Comments {
+		fmt.Println()
+		fmt.Printf("%s · %s · %d\n", c.User.Login, c.CreatedAt.Format("2006-01-02 15:04"), c.ID)
+		fmt.Println()
+		fmt.Println(bodyText(c.Body, viewNoEmoji))
+	}
+	fmt.Println()
+}
+
+// commentHistory is the audit trail of a review comment and its thread.
+type commentHistory struct {
+	CommentID  int64                `json:"comment_id"`
+	Location   string               `json:"location"`
+	ThreadID   string               `json:"thread_id"`
+	Resolved   bool                 `json:"resolved"`
+	ResolvedBy string               `json:"resolved_by,omitempty"`
+	Edits      []github.CommentEdit `json:"edits"`
+	Events     []historyEvent       `json:"events"`
+}
+
+// historyEvent is one entry in a comment's history. Kind is commented,
+// edited, or an action from the local journal (resolve, unresolve, hide,
+// unhide).
+type historyEvent struct {
+	Time   time.Time `json:"time"`
+	Kind   string    `json:"kind"`
+	Actor  string    `json:"actor"`
+	Detail string    `json:"detail,omitempty"`
+}
+
+func runViewHistory(client *github.Client, prRef *github.PRReference, commentID int64) error {
+	comments, err := client.GetReviewComments(prRef.Owner, prRef.Repo, prRef.Number)
 	if err != nil {
-		return false, err
+		return err
+	}
+	thread := buildThreadView(comments, commentID)
+	if thread == nil {
+		return fmt.Errorf("review comment with ID %d not found in PR %d", commentID, prRef.Number)
 	}
 
-	for _, r := range reviews {
-		if fmt.Sprintf("%d", r.ID) == reviewID {
-			if viewJsonOutput {
-				enc := json.NewEncoder(os.Stdout)
-				enc.SetIndent("", "  ")
-				return true, enc.Encode(r)
-			}
+	threads, err := client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return fmt.Errorf("get review threads: %w", err)
+	}
 
-			printReviewDetail(r)
-			return true, nil
+	history := &commentHistory{
+		CommentID: commentID,
+		Location:  thread.Location,
+		Resolved:  thread.Resolved,
+		Edits:     []github.CommentEdit{},
+	}
+	for _, t := range threads {
+		if slices.Contains(t.CommentIDs, commentID) {
+			history.ThreadID = t.ID
+			history.Resolved = t.IsResolved
+			history.ResolvedBy = t.ResolvedBy
+			break
 		}
 	}
 
-	return false, nil
-}
+	nodeIDs := make(map[string]bool)
+	for _, c := range thread.Comments {
+		nodeIDs[c.NodeID] = true
+		history.Events = append(history.Events, historyEvent{
+			Time:   c.CreatedAt,
+			Kind:   "commented",
+			Actor:  c.User.Login,
+			Detail: fmt.Sprintf("%d: %s", c.ID, github.TruncateString(c.Body, 50)),
+		})
+		if c.ID != commentID {
+			continue
+		}
+		edits, err := client.GetCommentEdits(c.NodeID)
+		if err != nil {
+			return err
+		}
+		if edits != nil {
+			history.Edits = edits
+		}
+		for _, e := range edits {
+			history.Events = append(history.Events, historyEvent{
+				Time:   e.EditedAt,
+				Kind:   "edited",
+				Actor:  e.Editor,
+				Detail: fmt.Sprintf("%d: %s", c.ID, github.TruncateString(e.Body, 50)),
+			})
+		}
+	}
 
-func tryViewIssueComment(client *github.Client, prRef *github.PRReference, commentID string) (bool, error) {
-	comments, err := client.GetIssueComments(prRef.Owner, prRef.Repo, prRef.Number)
+	entries, err := journal.Read()
 	if err != nil {
-		return false, err
+		return err
+	}
+	for _, e := range entries {
+		if (history.ThreadID != "" && e.ThreadID == history.ThreadID) || (e.NodeID != "" && nodeIDs[e.NodeID]) {
+			history.Events = append(history.Events, historyEvent{
+				Time:   e.Time,
+				Kind:   e.Action,
+				Actor:  "you (local journal)",
+				Detail: e.Reason,
+			})
+		}
 	}
+	sort.SliceStable(history.Events, func(i, j int) bool {
+		return history.Events[i].Time.Before(history.Events[j].Time)
+	})
 
-	for _, c := range comments {
-		if fmt.Sprintf("%d", c.ID) == commentID {
-			if viewJsonOutput {
-				enc := json.NewEncoder(os.Stdout)
-				enc.SetIndent("", "  ")
-				return true, enc.Encode(c)
-			}
+	if viewJsonOutput {
+		return writeJSON(history)
+	}
+
+	printCommentHistory(history)
+	return nil
+}
+
+func printCommentHistory(h *commentHistory) {
+	fmt.Printf("History of comment %d on %s\n", h.CommentID, h.Location)
+	fmt.Println(strings.Repeat("─", 60))
+	switch {
+	case !h.Resolved:
+		fmt.Println("Thread:    unresolved")
+	case h.ResolvedBy != "":
+		fmt.Printf("Thread:    resolved by %s\n", h.ResolvedBy)
+	default:
+		fmt.Println("Thread:    resolved")
+	}
+	fmt.Printf("Edits:     %d\n", len(h.Edits))
+	fmt.Println(strings.Repeat("─", 60))
 
-			printIssueCommentDetail(c)
-			return true, nil
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tEVENT\tACTOR\tDETAIL")
+	for _, e := range h.Events {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", e.Time.Format("2006-01-02 15:04"), e.Kind, e.Actor, e.Detail)
+	}
+	w.Flush()
+	fmt.Println()
+	fmt.Println("Note: GitHub does not record when threads are resolved; resolve and")
+	fmt.Println("unresolve times come only from this tool's local journal.")
+}
+
+func printItemDetail(item *prItem) {
+	switch {
+	case item.ReviewComment != nil:
+		printReviewCommentDetail(*item.ReviewComment)
+		if viewContext > 0 {
+			printLocalContext(*item.ReviewComment, viewContext)
 		}
+	case item.Review != nil:
+		printReviewDetail(*item.Review)
+	case item.IssueComment != nil:
+		printIssueCommentDetail(*item.IssueComment)
 	}
+}
 
-	return false, nil
+func printNote(note commentNote) {
+	fmt.Println(strings.Repeat("─", 60))
+	fmt.Printf("Note (local, %s):\n", note.UpdatedAt.Format("2006-01-02 15:04"))
+	fmt.Println(strings.Repeat("─", 60))
+	fmt.Println(note.Body)
+	fmt.Println()
 }
 
 func printReviewCommentDetail(c github.ReviewComment) {
 	fmt.Printf("Review Comment %d\n", c.ID)
 	fmt.Println(strings.Repeat("─", 60))
-	fmt.Printf("File:      %s", c.Path)
-	if c.OriginalLine != nil {
-		fmt.Printf(":%d", *c.OriginalLine)
+	fmt.Printf("File:      %s", c.Location())
+	if c.IsFileLevel() {
+		fmt.Print(" (file-level comment)")
 	}
 	fmt.Println()
 	fmt.Printf("Author:    %s\n", c.User.Login)
@@ -149,18 +477,35 @@ func printReviewCommentDetail(c github.ReviewComment) {
 	fmt.Printf("Review ID: %d\n", c.PullRequestReviewID)
 	fmt.Printf("Outdated:  %v\n", c.IsOutdated())
 	fmt.Printf("Resolved:  %v\n", c.IsResolved)
+	printHiddenLine(c.IsMinimized, c.MinimizedReason)
 	fmt.Printf("URL:       %s\n", c.HTMLURL)
 	fmt.Println(strings.Repeat("─", 60))
 	fmt.Println()
-	fmt.Println(c.Body)
+	fmt.Println(bodyText(c.Body, viewNoEmoji))
 	fmt.Println()
 
 	if c.DiffHunk != "" {
 		fmt.Println(strings.Repeat("─", 60))
 		fmt.Println("Diff context:")
 		fmt.Println(strings.Repeat("─", 60))
-		fmt.Println(c.DiffHunk)
+		if colorEnabled(viewNoColor) {
+			fmt.Println(highlightDiffHunk(c.DiffHunk, c.Path))
+		} else {
+			fmt.Println(c.DiffHunk)
+		}
+	}
+}
+
+func printHiddenLine(minimized bool, reason string) {
+	if !minimized {
+		fmt.Println("Hidden:    false")
+		return
 	}
+	if reason == "" {
+		fmt.Println("Hidden:    true")
+		return
+	}
+	fmt.Printf("Hidden:    true (%s)\n", reason)
 }
 
 func printReviewDetail(r github.Review) {
@@ -175,7 +520,7 @@ func printReviewDetail(r github.Review) {
 	fmt.Println(strings.Repeat("─", 60))
 	fmt.Println()
 	if r.Body != "" {
-		fmt.Println(r.Body)
+		fmt.Println(bodyText(r.Body, viewNoEmoji))
 	} else {
 		fmt.Println("(no body)")
 	}
@@ -190,9 +535,56 @@ func printIssueCommentDetail(c github.IssueComment) {
 	if !c.UpdatedAt.IsZero() && c.UpdatedAt != c.CreatedAt {
 		fmt.Printf("Updated:   %s\n", c.UpdatedAt.Format("2006-01-02 15:04:05"))
 	}
+	printHiddenLine(c.IsMinimized, c.MinimizedReason)
 	fmt.Printf("URL:       %s\n", c.HTMLURL)
 	fmt.Println(strings.Repeat("─", 60))
 	fmt.Println()
-	fmt.Println(c.Body)
+	fmt.Println(bodyText(c.Body, viewNoEmoji))
 	fmt.Println()
 }
+
+func printLocalContext(c github.ReviewComment, n int) {
+	fmt.Println(strings.Repeat("─", 60))
+	fmt.Println("Working tree:")
+	fmt.Println(strings.Repeat("─", 60))
+
+	root, err := github.GetRepoRoot()
+	if err != nil {
+		fmt.Printf("(unavailable: %v)\n", err)
+		return
+	}
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(c.Path)))
+	if err != nil {
+		fmt.Printf("(unavailable: %v)\n", err)
+		return
+	}
+
+	line := c.CurrentLine()
+	if c.IsFileLevel() || line == 0 {
+		fmt.Println("(file-level comment, no line to show)")
+		return
+	}
+	if c.IsOutdated() {
+		fmt.Println("(comment is outdated; showing its original line, which may have moved)")
+	}
+
+	firstLine := line
+	if c.StartLine != nil && c.Line != nil {
+		firstLine = *c.StartLine
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	start := max(firstLine-n, 1)
+	end := min(line+n, len(lines))
+	if start > len(lines) {
+		fmt.Printf("(line %d is past the end of %s, which has %d lines)\n", line, c.Path, len(lines))
+		return
+	}
+	for i := start; i <= end; i++ {
+		marker := " "
+		if i >= firstLine && i <= line {
+			marker = ">"
+		}
+		fmt.Printf("%s %5d  %s\n", marker, i, lines[i-1])
+	}
+}
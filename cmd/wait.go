@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/STRRL/gh-pr-comments/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	waitUntil    string
+	waitTimeout  time.Duration
+	waitInterval time.Duration
+)
+
+var waitCmd = &cobra.Command{
+	Use:   "wait [pr-reference]",
+	Short: "Block until review feedback is settled",
+	Long: `Poll the pull request until a condition holds, then exit 0. If the timeout
+passes first, exit 1.
+
+Conditions:
+  resolved - every review thread is resolved
+  approved - at least one reviewer's latest verdict is APPROVED
+
+API errors while polling are reported and retried on the next poll.
+
+If no PR reference is given, finds the PR for the current branch.
+
+PR reference can be:
+  - Full URL: https://github.com/owner/repo/pull/123
+  - Short form: owner/repo/123
+  - Just number: 123 (when in a repo context)
+  - Omitted: uses current branch's PR
+
+Examples:
+  gh pr-comments wait --until resolved
+  gh pr-comments wait --until approved --timeout 2h --interval 1m
+  gh pr-comments wait owner/repo/123 --until resolved && gh pr merge --squash`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: prArgAt(0),
+	RunE:        runWait,
+}
+
+func init() {
+	waitCmd.Flags().StringVar(&waitUntil, "until", "resolved", "Condition to wait for (resolved/approved)")
+	waitCmd.Flags().DurationVar(&waitTimeout, "timeout", 30*time.Minute, "Give up after this long")
+	waitCmd.Flags().DurationVar(&waitInterval, "interval", 30*time.Second, "Time between polls")
+	waitCmd.RegisterFlagCompletionFunc("until", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"resolved\tAll review threads resolved", "approved\tAn approving review"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	rootCmd.AddCommand(waitCmd)
+}
+
+func runWait(cmd *cobra.Command, args []string) error {
+	var poll func(client *github.Client, prRef *github.PRReference) (bool, string, error)
+	switch waitUntil {
+	case "resolved":
+		poll = pollResolved
+	case "approved":
+		poll = pollApproved
+	default:
+		return fmt.Errorf("invalid --until value: %s (valid: resolved, approved)", waitUntil)
+	}
+	if waitInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	if err := requireLiveData("wait"); err != nil {
+		return err
+	}
+	client, err := github.NewClient()
+	if err != nil {
+		return err
+	}
+
+	prRef, err := client.ResolvePRReference(withGlobalPR(args))
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(waitTimeout)
+	for {
+		done, detail, err := poll(client, prRef)
+		switch {
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		case done:
+			fmt.Printf("PR #%d: %s\n", prRef.Number, detail)
+			return nil
+		default:
+			fmt.Fprintf(os.Stderr, "[%s] PR #%d: %s\n", time.Now().Format("15:04:05"), prRef.Number, detail)
+		}
+
+		if time.Now().Add(waitInterval).After(deadline) {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("timed out after %s waiting for PR #%d to be %s", waitTimeout, prRef.Number, waitUntil)
+		}
+		time.Sleep(waitInterval)
+	}
+}
+
+func pollResolved(client *github.Client, prRef *github.PRReference) (bool, string, error) {
+	threads, err := client.GetReviewThreads(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return false, "", fmt.Errorf("get review threads: %w", err)
+	}
+	check := threadCheck(threads)
+	return check.Status == "pass", check.Detail, nil
+}
+
+func pollApproved(client *github.Client, prRef *github.PRReference) (bool, string, error) {
+	reviews, err := client.GetReviews(prRef.Owner, prRef.Repo, prRef.Number)
+	if err != nil {
+		return false, "", err
+	}
+	for login, state := range latestReviewStates(reviews) {
+		if state == "APPROVED" {
+			return true, "approved by " + login, nil
+		}
+	}
+	return false, "waiting for an approval", nil
+}
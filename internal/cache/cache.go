@@ -0,0 +1,513 @@
+// Package cache stores GitHub API responses on disk. They back --offline and
+// the fallback when GitHub cannot be reached; serving fresh reads from them
+// is opt-in through GH_PR_COMMENTS_CACHE_TTL.
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/config"
+)
+
+// DefaultTTL is how long a cached response is served when
+// GH_PR_COMMENTS_CACHE_TTL is not set: never, so commands see GitHub as it
+// is unless the user accepts older answers.
+const DefaultTTL = 0
+
+// Dir returns the directory holding cached responses.
+func Dir() string {
+	if dir := os.Getenv("GH_PR_COMMENTS_CACHE_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(config.CacheDir(), "pr-comments")
+}
+
+// TTL returns the configured cache lifetime. GH_PR_COMMENTS_CACHE_TTL takes a
+// Go duration such as 10m; 0, the default, disables reading from the cache.
+func TTL() (time.Duration, error) {
+	v := os.Getenv("GH_PR_COMMENTS_CACHE_TTL")
+	if v == "" {
+		return DefaultTTL, nil
+	}
+	ttl, err := time.ParseDuration(v)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("invalid GH_PR_COMMENTS_CACHE_TTL value: %s (expected a duration such as 10m)", v)
+	}
+	return ttl, nil
+}
+
+// Entry is one cached response. PR is the pull request it belongs to, as
+// owner/repo#number, or just owner/repo for repository-level reads such as
+// commits.
+type Entry struct {
+	PR       string      `json:"pr,omitempty"`
+	Method   string      `json:"method"`
+	URL      string      `json:"url"`
+	StoredAt time.Time   `json:"stored_at"`
+	Status   int         `json:"status"`
+	Header   http.Header `json:"header"`
+	Body     []byte      `json:"body"`
+}
+
+// Transport is an http.RoundTripper that answers reads from the cache while
+// they are younger than TTL and stores every successful read. Any other
+// successful request (REST writes and GraphQL mutations) invalidates the
+// cached reads of the PR or repository it wrote to, since it may have
+// changed what they returned.
+//
+// When the network cannot be reached, a read falls back to its cached
+// response however old it is, whatever TTL is. Live turns both off, for
+// callers whose answers must reflect GitHub right now; their reads still
+// refresh the cache. Offline skips the network altogether: reads are only
+// answered from the cache and writes fail. Fallbacks and offline reads are
+// reported by ServedStale.
+type Transport struct {
+	Base    http.RoundTripper
+	TTL     time.Duration
+	Live    bool
+	Offline bool
+}
+
+var (
+	staleMu    sync.Mutex
+	staleSince time.Time
+)
+
+// ServedStale reports whether any response was served from the cache in
+// place of the network, and when the oldest of them was stored.
+func ServedStale() (time.Time, bool) {
+	staleMu.Lock()
+	defer staleMu.Unlock()
+	return staleSince, !staleSince.IsZero()
+}
+
+func markStale(storedAt time.Time) {
+	staleMu.Lock()
+	defer staleMu.Unlock()
+	if staleSince.IsZero() || storedAt.Before(staleSince) {
+		staleSince = storedAt
+	}
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isRead(req, body) {
+		if t.Offline {
+			return nil, fmt.Errorf("offline: cannot send %s %s", req.Method, req.URL.Path)
+		}
+		res, err := t.base().RoundTrip(req)
+		if err == nil && res.StatusCode < 300 {
+			_ = invalidate(req, body)
+		}
+		return res, err
+	}
+
+	key := cacheKey(req, body)
+	if t.Offline {
+		e, err := load(key)
+		if err != nil {
+			return nil, fmt.Errorf("offline: %s %s has not been cached; run 'gh pr-comments prefetch' while online", req.Method, req.URL.Path)
+		}
+		markStale(e.StoredAt)
+		return e.response(req), nil
+	}
+	if !t.Live && t.TTL > 0 {
+		if e, err := load(key); err == nil && time.Since(e.StoredAt) < t.TTL {
+			return e.response(req), nil
+		}
+	}
+
+	res, err := t.base().RoundTrip(req)
+	if err != nil {
+		// The request never reached GitHub (and was not cancelled), so an
+		// old answer beats none. Live callers get the error.
+		if !t.Live && req.Context().Err() == nil {
+			if e, lerr := load(key); lerr == nil {
+				markStale(e.StoredAt)
+				return e.response(req), nil
+			}
+		}
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return res, nil
+	}
+	data, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(data))
+	if !isGraphQLError(req, data) {
+		_ = store(key, &Entry{
+			PR:       scopeOf(req, body),
+			Method:   req.Method,
+			URL:      req.URL.String(),
+			StoredAt: time.Now(),
+			Status:   res.StatusCode,
+			Header:   res.Header.Clone(),
+			Body:     data,
+		})
+	}
+	return res, nil
+}
+
+// Clear removes every cached response. Only the cache's own files are
+// removed, since GH_PR_COMMENTS_CACHE_DIR may point at a shared directory.
+func Clear() error {
+	dirEntries, err := os.ReadDir(Dir())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("clear cache: %w", err)
+	}
+	for _, de := range dirEntries {
+		name := de.Name()
+		if de.IsDir() || !(strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".tmp")) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(Dir(), name)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("clear cache: %w", err)
+		}
+	}
+	return nil
+}
+
+// invalidate removes the cached reads a successful write may have changed:
+// those of the PR or repository its REST path names or, for a GraphQL
+// mutation, which only names node IDs, those of every PR with a cached
+// response containing one of the IDs.
+func invalidate(req *http.Request, body []byte) error {
+	infos, err := List()
+	if err != nil {
+		return err
+	}
+	scopes := make(map[string]bool)
+	if scope := scopeOf(req, body); scope != "" {
+		scopes[scope] = true
+	}
+	var ids [][]byte
+	if isGraphQL(req) {
+		ids = mutationIDs(body)
+	}
+	matched := make(map[string]bool)
+	for _, info := range infos {
+		if len(ids) == 0 {
+			break
+		}
+		e, err := load(info.Key)
+		if err != nil {
+			continue
+		}
+		for _, id := range ids {
+			if bytes.Contains(e.Body, id) {
+				matched[info.Key] = true
+				if e.PR != "" {
+					scopes[e.PR] = true
+				}
+				break
+			}
+		}
+	}
+	var keys []string
+	for _, info := range infos {
+		if matched[info.Key] || inScope(info.PR, scopes) {
+			keys = append(keys, info.Key)
+		}
+	}
+	return Remove(keys)
+}
+
+// inScope reports whether a response cached for pr belongs to one of
+// scopes: the PR itself, or any PR of a repository-level scope.
+func inScope(pr string, scopes map[string]bool) bool {
+	if scopes[pr] {
+		return true
+	}
+	repo, _, ok := strings.Cut(pr, "#")
+	return ok && scopes[repo]
+}
+
+// mutationIDs returns the node IDs a GraphQL mutation acts on: the string
+// values of its variables whose names end in "Id".
+func mutationIDs(body []byte) [][]byte {
+	var payload struct {
+		Variables map[string]interface{} `json:"variables"`
+	}
+	if json.Unmarshal(body, &payload) != nil {
+		return nil
+	}
+	var ids [][]byte
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for k, v := range m {
+			if id, ok := v.(string); ok && id != "" && (strings.HasSuffix(k, "Id") || strings.HasSuffix(k, "ID")) {
+				ids = append(ids, []byte(strconv.Quote(id)))
+			}
+			walk(v)
+		}
+	}
+	walk(payload.Variables)
+	return ids
+}
+
+// Info describes a cached response without its body.
+type Info struct {
+	Key      string
+	PR       string
+	Method   string
+	URL      string
+	StoredAt time.Time
+	Size     int64
+}
+
+// List returns every cached response, oldest first. Files that cannot be
+// parsed are listed with a zero StoredAt so GC removes them.
+func List() ([]Info, error) {
+	dirEntries, err := os.ReadDir(Dir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read cache: %w", err)
+	}
+	var infos []Info
+	for _, de := range dirEntries {
+		name := de.Name()
+		if de.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		fi, err := de.Info()
+		if err != nil {
+			continue
+		}
+		info := Info{Key: strings.TrimSuffix(name, ".json"), Size: fi.Size()}
+		if e, err := load(info.Key); err == nil {
+			info.PR, info.Method, info.URL, info.StoredAt = e.PR, e.Method, e.URL, e.StoredAt
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].StoredAt.Before(infos[j].StoredAt) })
+	return infos, nil
+}
+
+// Remove deletes the cached responses with the given keys.
+func Remove(keys []string) error {
+	for _, key := range keys {
+		if err := os.Remove(entryPath(key)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove cache entry: %w", err)
+		}
+	}
+	return nil
+}
+
+// GC removes responses older than ttl, unreadable entries, and temp files
+// left behind by interrupted writes. It returns how many files were removed
+// and how many bytes that freed.
+func GC(ttl time.Duration) (int, int64, error) {
+	dirEntries, err := os.ReadDir(Dir())
+	if os.IsNotExist(err) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("read cache: %w", err)
+	}
+	removed, freed := 0, int64(0)
+	for _, de := range dirEntries {
+		name := de.Name()
+		fi, err := de.Info()
+		if de.IsDir() || err != nil {
+			continue
+		}
+		stale := false
+		switch {
+		case strings.HasSuffix(name, ".tmp"):
+			stale = time.Since(fi.ModTime()) > time.Minute
+		case strings.HasSuffix(name, ".json"):
+			e, err := load(strings.TrimSuffix(name, ".json"))
+			stale = err != nil || time.Since(e.StoredAt) >= ttl
+		}
+		if !stale {
+			continue
+		}
+		if err := os.Remove(filepath.Join(Dir(), name)); err != nil && !os.IsNotExist(err) {
+			return removed, freed, fmt.Errorf("remove cache entry: %w", err)
+		}
+		removed++
+		freed += fi.Size()
+	}
+	return removed, freed, nil
+}
+
+var restScopePattern = regexp.MustCompile(`^/repos/([^/]+)/([^/]+)(?:/(?:pulls|issues)/(\d+))?`)
+
+// scopeOf works out which PR (or repository) a read belongs to, from the
+// REST path or the owner/repo/number GraphQL variables.
+func scopeOf(req *http.Request, body []byte) string {
+	if isGraphQL(req) {
+		var payload struct {
+			Variables struct {
+				Owner  string `json:"owner"`
+				Repo   string `json:"repo"`
+				Number int    `json:"number"`
+			} `json:"variables"`
+		}
+		if json.Unmarshal(body, &payload) != nil || payload.Variables.Owner == "" {
+			return ""
+		}
+		v := payload.Variables
+		if v.Number == 0 {
+			return v.Owner + "/" + v.Repo
+		}
+		return fmt.Sprintf("%s/%s#%d", v.Owner, v.Repo, v.Number)
+	}
+	m := restScopePattern.FindStringSubmatch(strings.TrimPrefix(req.URL.Path, "/api/v3"))
+	if m == nil {
+		return ""
+	}
+	if m[3] == "" {
+		return m[1] + "/" + m[2]
+	}
+	return fmt.Sprintf("%s/%s#%s", m[1], m[2], m[3])
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+func isGraphQL(req *http.Request) bool {
+	return req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/graphql")
+}
+
+// isRead reports whether req only reads data: a GET, or a GraphQL query
+// that is not a mutation.
+func isRead(req *http.Request, body []byte) bool {
+	if req.Method == http.MethodGet {
+		return true
+	}
+	if !isGraphQL(req) {
+		return false
+	}
+	var payload struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return false
+	}
+	return !strings.HasPrefix(strings.TrimSpace(payload.Query), "mutation")
+}
+
+// isGraphQLError reports whether a GraphQL response carries errors; those
+// come back with status 200 but must not be cached.
+func isGraphQLError(req *http.Request, data []byte) bool {
+	if !isGraphQL(req) {
+		return false
+	}
+	var payload struct {
+		Errors []json.RawMessage `json:"errors"`
+	}
+	return json.Unmarshal(data, &payload) != nil || len(payload.Errors) > 0
+}
+
+// cacheKey identifies a request by its method, URL, body, and the headers
+// that change the response, including the token so accounts never share
+// entries.
+func cacheKey(req *http.Request, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s\n%s\n", req.Method, req.URL.String(), req.Header.Get("Accept"), req.Header.Get("Authorization"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func entryPath(key string) string {
+	return filepath.Join(Dir(), key+".json")
+}
+
+func load(key string) (*Entry, error) {
+	data, err := os.ReadFile(entryPath(key))
+	if err != nil {
+		return nil, err
+	}
+	var e Entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func store(key string, e *Entry) error {
+	if err := os.MkdirAll(Dir(), 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(Dir(), key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), entryPath(key))
+}
+
+func (e *Entry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+		StatusCode:    e.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,169 @@
+// Package emoji renders GitHub :shortcode: emoji as Unicode for terminal
+// output.
+package emoji
+
+import "regexp"
+
+// shortcodePattern matches a :shortcode: candidate.
+var shortcodePattern = regexp.MustCompile(`:([a-z0-9_+-]+):`)
+
+// Render replaces the GitHub shortcodes in s that it knows, such as
+// ":white_check_mark:", with their emoji. Unknown shortcodes are kept.
+func Render(s string) string {
+	return shortcodePattern.ReplaceAllStringFunc(s, func(match string) string {
+		if e, ok := shortcodes[match[1:len(match)-1]]; ok {
+			return e
+		}
+		return match
+	})
+}
+
+// shortcodes covers the emoji GitHub users and review bots use most, keyed
+// by GitHub's shortcode names.
+var shortcodes = map[string]string{
+	"+1":                         "👍",
+	"-1":                         "👎",
+	"100":                        "💯",
+	"alarm_clock":                "⏰",
+	"arrow_down":                 "⬇️",
+	"arrow_forward":              "▶️",
+	"arrow_left":                 "⬅️",
+	"arrow_right":                "➡️",
+	"arrow_up":                   "⬆️",
+	"arrows_counterclockwise":    "🔄",
+	"art":                        "🎨",
+	"ballot_box_with_check":      "☑️",
+	"bangbang":                   "‼️",
+	"bar_chart":                  "📊",
+	"beetle":                     "🐞",
+	"bell":                       "🔔",
+	"black_circle":               "⚫",
+	"blue_book":                  "📘",
+	"blue_heart":                 "💙",
+	"bomb":                       "💣",
+	"book":                       "📖",
+	"bookmark":                   "🔖",
+	"books":                      "📚",
+	"boom":                       "💥",
+	"brain":                      "🧠",
+	"broken_heart":               "💔",
+	"bug":                        "🐛",
+	"bulb":                       "💡",
+	"calendar":                   "📆",
+	"chart_with_downwards_trend": "📉",
+	"chart_with_upwards_trend":   "📈",
+	"checkered_flag":             "🏁",
+	"clap":                       "👏",
+	"clipboard":                  "📋",
+	"clock1":                     "🕐",
+	"closed_lock_with_key":       "🔐",
+	"cloud":                      "☁️",
+	"coffee":                     "☕",
+	"collision":                  "💥",
+	"computer":                   "💻",
+	"confused":                   "😕",
+	"construction":               "🚧",
+	"crossed_fingers":            "🤞",
+	"crystal_ball":               "🔮",
+	"dart":                       "🎯",
+	"dash":                       "💨",
+	"dizzy":                      "💫",
+	"dna":                        "🧬",
+	"exclamation":                "❗",
+	"eyes":                       "👀",
+	"file_folder":                "📁",
+	"fire":                       "🔥",
+	"gear":                       "⚙️",
+	"ghost":                      "👻",
+	"gift":                       "🎁",
+	"globe_with_meridians":       "🌐",
+	"green_circle":               "🟢",
+	"green_heart":                "💚",
+	"grey_exclamation":           "❕",
+	"grey_question":              "❔",
+	"hammer":                     "🔨",
+	"hammer_and_wrench":          "🛠️",
+	"heart":                      "❤️",
+	"heavy_check_mark":           "✔️",
+	"heavy_minus_sign":           "➖",
+	"heavy_multiplication_x":     "✖️",
+	"heavy_plus_sign":            "➕",
+	"hocho":                      "🔪",
+	"hooray":                     "🎉",
+	"hourglass":                  "⌛",
+	"hourglass_flowing_sand":     "⏳",
+	"information_source":         "ℹ️",
+	"key":                        "🔑",
+	"label":                      "🏷️",
+	"ladybug":                    "🐞",
+	"laughing":                   "😆",
+	"lock":                       "🔒",
+	"loudspeaker":                "📢",
+	"mag":                        "🔍",
+	"mag_right":                  "🔎",
+	"mega":                       "📣",
+	"memo":                       "📝",
+	"microscope":                 "🔬",
+	"no_entry":                   "⛔",
+	"no_entry_sign":              "🚫",
+	"notebook":                   "📓",
+	"ok":                         "🆗",
+	"ok_hand":                    "👌",
+	"orange_circle":              "🟠",
+	"package":                    "📦",
+	"page_facing_up":             "📄",
+	"page_with_curl":             "📃",
+	"paperclip":                  "📎",
+	"partying_face":              "🥳",
+	"pencil":                     "📝",
+	"pencil2":                    "✏️",
+	"point_down":                 "👇",
+	"point_right":                "👉",
+	"pray":                       "🙏",
+	"purple_circle":              "🟣",
+	"pushpin":                    "📌",
+	"question":                   "❓",
+	"rabbit":                     "🐰",
+	"rabbit2":                    "🐇",
+	"recycle":                    "♻️",
+	"red_circle":                 "🔴",
+	"repeat":                     "🔁",
+	"robot":                      "🤖",
+	"rocket":                     "🚀",
+	"rotating_light":             "🚨",
+	"scroll":                     "📜",
+	"see_no_evil":                "🙈",
+	"shield":                     "🛡️",
+	"shipit":                     "🐿️",
+	"skull":                      "💀",
+	"smile":                      "😄",
+	"smiley":                     "😃",
+	"sparkles":                   "✨",
+	"speech_balloon":             "💬",
+	"star":                       "⭐",
+	"star2":                      "🌟",
+	"stop_sign":                  "🛑",
+	"straight_ruler":             "📏",
+	"stopwatch":                  "⏱️",
+	"sweat_smile":                "😅",
+	"tada":                       "🎉",
+	"test_tube":                  "🧪",
+	"thinking":                   "🤔",
+	"thought_balloon":            "💭",
+	"thumbsdown":                 "👎",
+	"thumbsup":                   "👍",
+	"toolbox":                    "🧰",
+	"triangular_flag_on_post":    "🚩",
+	"trophy":                     "🏆",
+	"twisted_rightwards_arrows":  "🔀",
+	"unlock":                     "🔓",
+	"warning":                    "⚠️",
+	"wave":                       "👋",
+	"white_check_mark":           "✅",
+	"white_circle":               "⚪",
+	"wrench":                     "🔧",
+	"x":                          "❌",
+	"yellow_circle":              "🟡",
+	"yellow_heart":               "💛",
+	"zap":                        "⚡",
+}
@@ -0,0 +1,71 @@
+package github
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	gh "github.com/cli/go-gh/v2"
+	"github.com/cli/go-gh/v2/pkg/auth"
+)
+
+// Account selects the gh credentials NewClient uses instead of the active
+// account of the default host. It is a login ("octocat"), a host
+// ("ghe.example.com"), or both ("octocat@ghe.example.com").
+var Account string
+
+// ConfiguredAccount returns the account set for the current repository: the
+// GH_PR_COMMENTS_ACCOUNT environment variable, or else the repository's
+// pr-comments.account git config.
+func ConfiguredAccount() string {
+	if account := os.Getenv("GH_PR_COMMENTS_ACCOUNT"); account != "" {
+		return account
+	}
+	output, err := exec.Command("git", "config", "--get", "pr-comments.account").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// parseAccount splits an Account value into a host and a login, either of
+// which may be empty.
+func parseAccount(account string) (host, login string) {
+	if login, host, ok := strings.Cut(account, "@"); ok {
+		return host, login
+	}
+	if strings.Contains(account, ".") {
+		return account, ""
+	}
+	return "", account
+}
+
+// accountCredentials returns the host and token for account. Tokens for an
+// account other than the host's active one come from 'gh auth token --user',
+// which reads them from gh's credential store.
+func accountCredentials(account string) (host, token string, err error) {
+	host, login := parseAccount(account)
+	if host == "" {
+		host, _ = auth.DefaultHost()
+	}
+	host = auth.NormalizeHostname(host)
+
+	if login == "" {
+		token, _ = auth.TokenForHost(host)
+		if token == "" {
+			return "", "", fmt.Errorf("not logged in to %s; run 'gh auth login --hostname %s'", host, host)
+		}
+		return host, token, nil
+	}
+
+	stdout, stderr, err := gh.Exec("auth", "token", "--hostname", host, "--user", login)
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", "", fmt.Errorf("no credentials for account %s on %s: %s (run 'gh auth login --hostname %s')", login, host, msg, host)
+	}
+	return host, strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,113 @@
+package github
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// AttachmentsBranch holds files attached to comments. GitHub's user-content
+// upload flow is only open to browser sessions, so attachments are committed
+// to this orphan branch instead and linked from there. Callers choose the
+// repository, normally the PR's head repository, which its author can push
+// to even when it is a fork.
+const AttachmentsBranch = "gh-pr-comments-attachments"
+
+// unsafeFileChars are replaced in attachment names so their URLs need no
+// escaping.
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// UploadAttachment commits data as a file named name to AttachmentsBranch,
+// creating the branch on first use, and returns a URL that serves the raw
+// file to anyone who can read the repository. Identical files share a path.
+func (c *Client) UploadAttachment(owner, repo, name string, data []byte) (string, error) {
+	sum := sha256.Sum256(data)
+	base := unsafeFileChars.ReplaceAllString(path.Base(name), "-")
+	filePath := hex.EncodeToString(sum[:])[:12] + "-" + base
+
+	var parents []string
+	var baseTree string
+	var ref struct {
+		Object struct {
+			SHA string `json:"sha"`
+		} `json:"object"`
+	}
+	refPath := fmt.Sprintf("repos/%s/%s/git/ref/heads/%s", owner, repo, AttachmentsBranch)
+	if err := c.rest.Get(refPath, &ref); err == nil {
+		var head struct {
+			Tree struct {
+				SHA string `json:"sha"`
+			} `json:"tree"`
+		}
+		if err := c.rest.Get(fmt.Sprintf("repos/%s/%s/git/commits/%s", owner, repo, ref.Object.SHA), &head); err != nil {
+			return "", fmt.Errorf("upload %s: %w", name, err)
+		}
+		parents, baseTree = []string{ref.Object.SHA}, head.Tree.SHA
+	} else if !IsNotFound(err) {
+		return "", fmt.Errorf("upload %s: %w", name, err)
+	}
+
+	var blob, tree, commit struct {
+		SHA     string `json:"sha"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := c.sendJSON("POST", fmt.Sprintf("repos/%s/%s/git/blobs", owner, repo), map[string]string{
+		"content":  base64.StdEncoding.EncodeToString(data),
+		"encoding": "base64",
+	}, &blob); err != nil {
+		return "", fmt.Errorf("upload %s: %w", name, err)
+	}
+	treeRequest := map[string]interface{}{
+		"tree": []map[string]string{{"path": filePath, "mode": "100644", "type": "blob", "sha": blob.SHA}},
+	}
+	if baseTree != "" {
+		treeRequest["base_tree"] = baseTree
+	}
+	if err := c.sendJSON("POST", fmt.Sprintf("repos/%s/%s/git/trees", owner, repo), treeRequest, &tree); err != nil {
+		return "", fmt.Errorf("upload %s: %w", name, err)
+	}
+	// [skip ci] keeps push-triggered workflows from running on the branch.
+	if err := c.sendJSON("POST", fmt.Sprintf("repos/%s/%s/git/commits", owner, repo), map[string]interface{}{
+		"message": "Attach " + base + " [skip ci]",
+		"tree":    tree.SHA,
+		"parents": append([]string{}, parents...),
+	}, &commit); err != nil {
+		return "", fmt.Errorf("upload %s: %w", name, err)
+	}
+
+	if len(parents) == 0 {
+		err := c.sendJSON("POST", fmt.Sprintf("repos/%s/%s/git/refs", owner, repo), map[string]string{
+			"ref": "refs/heads/" + AttachmentsBranch,
+			"sha": commit.SHA,
+		}, nil)
+		if err != nil {
+			return "", fmt.Errorf("create %s branch: %w", AttachmentsBranch, err)
+		}
+	} else {
+		err := c.sendJSON("PATCH", fmt.Sprintf("repos/%s/%s/git/refs/heads/%s", owner, repo, AttachmentsBranch), map[string]string{
+			"sha": commit.SHA,
+		}, nil)
+		if err != nil {
+			return "", fmt.Errorf("update %s branch: %w", AttachmentsBranch, err)
+		}
+	}
+
+	blobURL := strings.Replace(commit.HTMLURL, "/commit/", "/blob/", 1)
+	return blobURL + "/" + filePath + "?raw=true", nil
+}
+
+// sendJSON sends body as a JSON request and decodes the response into
+// response, when given.
+func (c *Client) sendJSON(method, apiPath string, body, response interface{}) error {
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("encode request body: %w", err)
+	}
+	return c.rest.Do(method, apiPath, bytes.NewBuffer(jsonData), response)
+}
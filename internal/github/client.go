@@ -2,15 +2,21 @@ package github
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
-	"os/exec"
 	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/STRRL/gh-pr-comments/internal/cache"
 	"github.com/cli/go-gh/v2/pkg/api"
 	"github.com/cli/go-gh/v2/pkg/repository"
 	graphql "github.com/cli/shurcooL-graphql"
@@ -19,14 +25,46 @@ import (
 type Client struct {
 	rest    *api.RESTClient
 	graphql *api.GraphQLClient
+
+	// strict turns failures to fetch comment state, which are otherwise
+	// only warned about, into errors.
+	strict bool
+}
+
+// RequireCompleteStatus makes the client fail instead of warning when the
+// resolved or hidden state of comments cannot be fetched, for callers that
+// must not work from partial data.
+func (c *Client) RequireCompleteStatus() {
+	c.strict = true
 }
 
+// NoCache makes NewClient's clients always read from the network, without
+// falling back to the cache when it cannot be reached. They still refresh
+// the local cache and invalidate it after writes.
+var NoCache bool
+
+// Offline makes NewClient's clients answer reads only from the local cache,
+// whatever their age, and refuse writes.
+var Offline bool
+
 func NewClient() (*Client, error) {
-	restClient, err := api.DefaultRESTClient()
+	ttl, err := cache.TTL()
+	if err != nil {
+		return nil, err
+	}
+	opts := api.ClientOptions{Transport: &cache.Transport{TTL: ttl, Live: NoCache, Offline: Offline}}
+	if Account != "" {
+		opts.Host, opts.AuthToken, err = accountCredentials(Account)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	restClient, err := api.NewRESTClient(opts)
 	if err != nil {
 		return nil, fmt.Errorf("create REST client: %w", err)
 	}
-	graphqlClient, err := api.DefaultGraphQLClient()
+	graphqlClient, err := api.NewGraphQLClient(opts)
 	if err != nil {
 		return nil, fmt.Errorf("create GraphQL client: %w", err)
 	}
@@ -39,6 +77,25 @@ type PRReference struct {
 	Number int
 }
 
+// commentURLPattern matches PR links that point at a single comment or
+// review, as copied from the GitHub UI.
+var commentURLPattern = regexp.MustCompile(`github\.com/([^/]+)/([^/]+)/pull/(\d+)[^#]*#(?:discussion_r|r|issuecomment-|pullrequestreview-)(\d+)$`)
+
+// ParseCommentURL extracts the PR and the comment or review ID from a link
+// such as https://github.com/owner/repo/pull/123#discussion_r2621968472.
+func ParseCommentURL(s string) (*PRReference, int64, bool) {
+	m := commentURLPattern.FindStringSubmatch(s)
+	if m == nil {
+		return nil, 0, false
+	}
+	num, _ := strconv.Atoi(m[3])
+	id, err := strconv.ParseInt(m[4], 10, 64)
+	if err != nil {
+		return nil, 0, false
+	}
+	return &PRReference{Owner: m[1], Repo: m[2], Number: num}, id, true
+}
+
 func ParsePRReference(ref string) (*PRReference, error) {
 	urlPattern := regexp.MustCompile(`github\.com/([^/]+)/([^/]+)/pull/(\d+)`)
 	if matches := urlPattern.FindStringSubmatch(ref); matches != nil {
@@ -67,6 +124,40 @@ func (c *Client) GetCurrentRepo() (owner, repo string, err error) {
 	return currentRepo.Owner, currentRepo.Name, nil
 }
 
+func (c *Client) GetCurrentUser() (*User, error) {
+	var user User
+	if err := c.rest.Get("user", &user); err != nil {
+		return nil, fmt.Errorf("get current user: %w", err)
+	}
+	return &user, nil
+}
+
+func (c *Client) SearchPullRequests(query string) ([]IssueSearchResult, error) {
+	var allResults []IssueSearchResult
+	page := 1
+	perPage := 100
+
+	for {
+		var response struct {
+			Items []IssueSearchResult `json:"items"`
+		}
+		path := fmt.Sprintf("search/issues?q=%s&per_page=%d&page=%d", url.QueryEscape(query+" is:pr"), perPage, page)
+		if err := c.rest.Get(path, &response); err != nil {
+			return nil, fmt.Errorf("search pull requests: %w", err)
+		}
+
+		allResults = append(allResults, response.Items...)
+
+		// The search API caps results at 1000 items.
+		if len(response.Items) < perPage || len(allResults) >= 1000 {
+			break
+		}
+		page++
+	}
+
+	return allResults, nil
+}
+
 func (c *Client) GetPullRequest(owner, repo string, number int) (*PullRequest, error) {
 	var pr PullRequest
 	path := fmt.Sprintf("repos/%s/%s/pulls/%d", owner, repo, number)
@@ -76,13 +167,306 @@ func (c *Client) GetPullRequest(owner, repo string, number int) (*PullRequest, e
 	return &pr, nil
 }
 
+func (c *Client) GetRepository(owner, repo string) (*Repository, error) {
+	var r Repository
+	if err := c.rest.Get(fmt.Sprintf("repos/%s/%s", owner, repo), &r); err != nil {
+		return nil, fmt.Errorf("get repository: %w", err)
+	}
+	return &r, nil
+}
+
+func (c *Client) ListPullRequests(owner, repo, state string) ([]PullRequest, error) {
+	var allPRs []PullRequest
+	page := 1
+	perPage := 100
+
+	for {
+		var prs []PullRequest
+		path := fmt.Sprintf("repos/%s/%s/pulls?state=%s&per_page=%d&page=%d", owner, repo, url.QueryEscape(state), perPage, page)
+		if err := c.rest.Get(path, &prs); err != nil {
+			return nil, fmt.Errorf("list pull requests: %w", err)
+		}
+
+		allPRs = append(allPRs, prs...)
+
+		if len(prs) < perPage {
+			break
+		}
+		page++
+	}
+
+	return allPRs, nil
+}
+
+// GetMergeRequirements reads the review rules for a branch from its classic
+// branch protection and from any rulesets that apply to it. Branch protection
+// can only be read with admin access; when it cannot be read, a warning is
+// recorded and the rulesets are still used.
+func (c *Client) GetMergeRequirements(owner, repo, branch string) (*MergeRequirements, error) {
+	req := &MergeRequirements{}
+
+	var protection struct {
+		RequiredPullRequestReviews *struct {
+			RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+			RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+			DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+			RequireLastPushApproval      bool `json:"require_last_push_approval"`
+		} `json:"required_pull_request_reviews"`
+		RequiredConversationResolution *struct {
+			Enabled bool `json:"enabled"`
+		} `json:"required_conversation_resolution"`
+	}
+	path := fmt.Sprintf("repos/%s/%s/branches/%s/protection", owner, repo, branch)
+	err := c.rest.Get(path, &protection)
+	var httpErr *api.HTTPError
+	switch {
+	case err == nil:
+		var approvals int
+		var codeOwner, dismissStale, lastPush, conversations bool
+		if r := protection.RequiredPullRequestReviews; r != nil {
+			approvals = r.RequiredApprovingReviewCount
+			codeOwner = r.RequireCodeOwnerReviews
+			dismissStale = r.DismissStaleReviews
+			lastPush = r.RequireLastPushApproval
+		}
+		if r := protection.RequiredConversationResolution; r != nil {
+			conversations = r.Enabled
+		}
+		req.merge("branch protection", approvals, codeOwner, dismissStale, lastPush, conversations)
+	case errors.As(err, &httpErr) && httpErr.StatusCode == 404 && httpErr.Message == "Branch not protected":
+	case errors.As(err, &httpErr) && httpErr.StatusCode == 404:
+		// Protected branches look missing to users without admin access.
+		req.Warnings = append(req.Warnings, "classic branch protection not found or not readable without admin access")
+	case errors.As(err, &httpErr) && httpErr.StatusCode == 403:
+		req.Warnings = append(req.Warnings, "classic branch protection requires admin access to read")
+	default:
+		return nil, fmt.Errorf("get branch protection: %w", err)
+	}
+
+	var rules []struct {
+		Type       string `json:"type"`
+		Parameters struct {
+			RequiredApprovingReviewCount   int  `json:"required_approving_review_count"`
+			RequireCodeOwnerReview         bool `json:"require_code_owner_review"`
+			DismissStaleReviewsOnPush      bool `json:"dismiss_stale_reviews_on_push"`
+			RequireLastPushApproval        bool `json:"require_last_push_approval"`
+			RequiredReviewThreadResolution bool `json:"required_review_thread_resolution"`
+		} `json:"parameters"`
+		RulesetID int64 `json:"ruleset_id"`
+	}
+	path = fmt.Sprintf("repos/%s/%s/rules/branches/%s?per_page=100", owner, repo, branch)
+	if err := c.rest.Get(path, &rules); err != nil {
+		return nil, fmt.Errorf("get branch rules: %w", err)
+	}
+	for _, r := range rules {
+		if r.Type != "pull_request" {
+			continue
+		}
+		p := r.Parameters
+		req.merge(fmt.Sprintf("ruleset %d", r.RulesetID), p.RequiredApprovingReviewCount, p.RequireCodeOwnerReview,
+			p.DismissStaleReviewsOnPush, p.RequireLastPushApproval, p.RequiredReviewThreadResolution)
+	}
+
+	return req, nil
+}
+
+func (c *Client) GetFileContent(owner, repo, path, ref string) ([]byte, error) {
+	var file struct {
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	apiPath := fmt.Sprintf("repos/%s/%s/contents/%s", owner, repo, strings.Join(segments, "/"))
+	if ref != "" {
+		apiPath += "?ref=" + url.QueryEscape(ref)
+	}
+	if err := c.rest.Get(apiPath, &file); err != nil {
+		return nil, fmt.Errorf("get file %s: %w", path, err)
+	}
+	switch file.Encoding {
+	case "base64":
+	case "none":
+		// Files over 1 MB come without their content.
+		return nil, fmt.Errorf("get file %s: file is too large for the contents API", path)
+	default:
+		return nil, fmt.Errorf("get file %s: unsupported encoding %q", path, file.Encoding)
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(file.Content, "\n", ""))
+	if err != nil {
+		return nil, fmt.Errorf("decode file %s: %w", path, err)
+	}
+	return data, nil
+}
+
+// IsNotFound reports whether err is a 404 from the GitHub API.
+func IsNotFound(err error) bool {
+	var httpErr *api.HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == 404
+}
+
+// IsUnprocessable reports whether err is a 422 from the GitHub API, as when
+// a review comment is placed on a line outside the diff.
+func IsUnprocessable(err error) bool {
+	var httpErr *api.HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == 422
+}
+
+// ListRepoNotifications returns the user's notifications for a repository,
+// including ones already marked as read.
+func (c *Client) ListRepoNotifications(owner, repo string) ([]Notification, error) {
+	var allNotifications []Notification
+	page := 1
+	perPage := 50
+
+	for {
+		var notifications []Notification
+		path := fmt.Sprintf("repos/%s/%s/notifications?all=true&per_page=%d&page=%d", owner, repo, perPage, page)
+		if err := c.rest.Get(path, &notifications); err != nil {
+			return nil, fmt.Errorf("list notifications: %w", err)
+		}
+
+		allNotifications = append(allNotifications, notifications...)
+
+		if len(notifications) < perPage {
+			break
+		}
+		page++
+	}
+
+	return allNotifications, nil
+}
+
+func (c *Client) MarkNotificationRead(threadID string) error {
+	if err := c.rest.Patch("notifications/threads/"+threadID, nil, nil); err != nil {
+		return fmt.Errorf("mark notification read: %w", err)
+	}
+	return nil
+}
+
+// MarkNotificationDone removes the notification from the inbox.
+func (c *Client) MarkNotificationDone(threadID string) error {
+	if err := c.rest.Delete("notifications/threads/"+threadID, nil); err != nil {
+		return fmt.Errorf("mark notification done: %w", err)
+	}
+	return nil
+}
+
+// ListAssignees returns the users who can be assigned to issues in the
+// repository, which are the users with push access.
+func (c *Client) ListAssignees(owner, repo string) ([]User, error) {
+	var allUsers []User
+	page := 1
+	perPage := 100
+
+	for {
+		var users []User
+		path := fmt.Sprintf("repos/%s/%s/assignees?per_page=%d&page=%d", owner, repo, perPage, page)
+		if err := c.rest.Get(path, &users); err != nil {
+			return nil, fmt.Errorf("list assignees: %w", err)
+		}
+
+		allUsers = append(allUsers, users...)
+
+		if len(users) < perPage {
+			break
+		}
+		page++
+	}
+
+	return allUsers, nil
+}
+
 func (c *Client) GetReviews(owner, repo string, number int) ([]Review, error) {
-	var reviews []Review
-	path := fmt.Sprintf("repos/%s/%s/pulls/%d/reviews", owner, repo, number)
-	if err := c.rest.Get(path, &reviews); err != nil {
-		return nil, fmt.Errorf("get reviews: %w", err)
+	var allReviews []Review
+	page := 1
+	perPage := 100
+
+	for {
+		var reviews []Review
+		path := fmt.Sprintf("repos/%s/%s/pulls/%d/reviews?per_page=%d&page=%d", owner, repo, number, perPage, page)
+		if err := c.rest.Get(path, &reviews); err != nil {
+			return nil, fmt.Errorf("get reviews: %w", err)
+		}
+
+		allReviews = append(allReviews, reviews...)
+
+		if len(reviews) < perPage {
+			break
+		}
+		page++
+	}
+
+	statusMap, err := c.getReviewStatus(owner, repo, number)
+	if err != nil && c.strict {
+		return nil, fmt.Errorf("fetch hidden status: %w", err)
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: fetch hidden status: %v\n", err)
+	} else {
+		for i := range allReviews {
+			if status, ok := statusMap[allReviews[i].ID]; ok {
+				allReviews[i].IsMinimized = status.minimized
+				allReviews[i].MinimizedReason = status.minimizedReason
+			}
+		}
 	}
-	return reviews, nil
+
+	return allReviews, nil
+}
+
+// getReviewStatus returns whether each review of a PR is hidden, which the
+// REST API does not expose.
+func (c *Client) getReviewStatus(owner, repo string, number int) (map[int64]commentStatus, error) {
+	result := make(map[int64]commentStatus)
+	var cursor *graphql.String
+
+	for {
+		var query struct {
+			Repository struct {
+				PullRequest struct {
+					Reviews struct {
+						PageInfo struct {
+							HasNextPage bool
+							EndCursor   string
+						}
+						Nodes []struct {
+							DatabaseId      int64
+							IsMinimized     bool
+							MinimizedReason string
+						}
+					} `graphql:"reviews(first: 100, after: $cursor)"`
+				} `graphql:"pullRequest(number: $number)"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}
+
+		variables := map[string]interface{}{
+			"owner":  graphql.String(owner),
+			"repo":   graphql.String(repo),
+			"number": graphql.Int(number),
+			"cursor": cursor,
+		}
+
+		if err := c.graphql.Query("GetReviewStatus", &query, variables); err != nil {
+			return nil, err
+		}
+
+		for _, review := range query.Repository.PullRequest.Reviews.Nodes {
+			result[review.DatabaseId] = commentStatus{
+				minimized:       review.IsMinimized,
+				minimizedReason: strings.ToLower(review.MinimizedReason),
+			}
+		}
+
+		if !query.Repository.PullRequest.Reviews.PageInfo.HasNextPage {
+			break
+		}
+		endCursor := graphql.String(query.Repository.PullRequest.Reviews.PageInfo.EndCursor)
+		cursor = &endCursor
+	}
+
+	return result, nil
 }
 
 func (c *Client) GetReviewComments(owner, repo string, number int) ([]ReviewComment, error) {
@@ -105,13 +489,17 @@ func (c *Client) GetReviewComments(owner, repo string, number int) ([]ReviewComm
 		page++
 	}
 
-	resolvedMap, err := c.getResolvedStatus(owner, repo, number)
-	if err != nil {
+	statusMap, err := c.getReviewCommentStatus(owner, repo, number)
+	if err != nil && c.strict {
+		return nil, fmt.Errorf("fetch resolved status: %w", err)
+	} else if err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: fetch resolved status: %v\n", err)
 	} else {
 		for i := range allComments {
-			if resolved, ok := resolvedMap[allComments[i].ID]; ok {
-				allComments[i].IsResolved = resolved
+			if status, ok := statusMap[allComments[i].ID]; ok {
+				allComments[i].IsResolved = status.resolved
+				allComments[i].IsMinimized = status.minimized
+				allComments[i].MinimizedReason = status.minimizedReason
 			}
 		}
 	}
@@ -119,8 +507,15 @@ func (c *Client) GetReviewComments(owner, repo string, number int) ([]ReviewComm
 	return allComments, nil
 }
 
-func (c *Client) getResolvedStatus(owner, repo string, number int) (map[int64]bool, error) {
-	result := make(map[int64]bool)
+// commentStatus is the state of a comment that only GraphQL exposes.
+type commentStatus struct {
+	resolved        bool
+	minimized       bool
+	minimizedReason string
+}
+
+func (c *Client) getReviewCommentStatus(owner, repo string, number int) (map[int64]commentStatus, error) {
+	result := make(map[int64]commentStatus)
 	var cursor *graphql.String
 
 	for {
@@ -136,7 +531,9 @@ func (c *Client) getResolvedStatus(owner, repo string, number int) (map[int64]bo
 							IsResolved bool
 							Comments   struct {
 								Nodes []struct {
-									DatabaseId int64
+									DatabaseId      int64
+									IsMinimized     bool
+									MinimizedReason string
 								}
 							} `graphql:"comments(first: 100)"`
 						}
@@ -158,7 +555,11 @@ func (c *Client) getResolvedStatus(owner, repo string, number int) (map[int64]bo
 
 		for _, thread := range query.Repository.PullRequest.ReviewThreads.Nodes {
 			for _, comment := range thread.Comments.Nodes {
-				result[comment.DatabaseId] = thread.IsResolved
+				result[comment.DatabaseId] = commentStatus{
+					resolved:        thread.IsResolved,
+					minimized:       comment.IsMinimized,
+					minimizedReason: strings.ToLower(comment.MinimizedReason),
+				}
 			}
 		}
 
@@ -188,9 +589,22 @@ func (c *Client) GetReviewThreads(owner, repo string, number int) ([]ReviewThrea
 						Nodes []struct {
 							ID         string
 							IsResolved bool
-							Comments   struct {
+							ResolvedBy struct {
+								Login string
+							}
+							IsOutdated  bool
+							Path        string
+							Line        *int
+							StartLine   *int
+							SubjectType string
+							Comments    struct {
 								Nodes []struct {
 									DatabaseId int64
+									Author     struct {
+										Login string
+									}
+									Body      string
+									CreatedAt time.Time
 								}
 							} `graphql:"comments(first: 100)"`
 						}
@@ -212,13 +626,27 @@ func (c *Client) GetReviewThreads(owner, repo string, number int) ([]ReviewThrea
 
 		for _, node := range query.Repository.PullRequest.ReviewThreads.Nodes {
 			var commentIDs []int64
+			var comments []ThreadComment
 			for _, c := range node.Comments.Nodes {
 				commentIDs = append(commentIDs, c.DatabaseId)
+				comments = append(comments, ThreadComment{
+					ID:        c.DatabaseId,
+					Author:    c.Author.Login,
+					Body:      c.Body,
+					CreatedAt: c.CreatedAt,
+				})
 			}
 			threads = append(threads, ReviewThread{
-				ID:         node.ID,
-				IsResolved: node.IsResolved,
-				CommentIDs: commentIDs,
+				ID:          node.ID,
+				IsResolved:  node.IsResolved,
+				ResolvedBy:  node.ResolvedBy.Login,
+				IsOutdated:  node.IsOutdated,
+				Path:        node.Path,
+				Line:        node.Line,
+				StartLine:   node.StartLine,
+				SubjectType: node.SubjectType,
+				CommentIDs:  commentIDs,
+				Comments:    comments,
 			})
 		}
 
@@ -232,6 +660,47 @@ func (c *Client) GetReviewThreads(owner, repo string, number int) ([]ReviewThrea
 	return threads, nil
 }
 
+// GetCommentEdits returns the edit history of a comment, oldest first.
+// Comments that were never edited have no entries.
+func (c *Client) GetCommentEdits(nodeID string) ([]CommentEdit, error) {
+	var query struct {
+		Node struct {
+			Comment struct {
+				UserContentEdits struct {
+					Nodes []struct {
+						EditedAt time.Time
+						Editor   struct {
+							Login string
+						}
+						Diff string
+					}
+				} `graphql:"userContentEdits(first: 100)"`
+			} `graphql:"... on Comment"`
+		} `graphql:"node(id: $id)"`
+	}
+
+	variables := map[string]interface{}{
+		"id": graphql.ID(nodeID),
+	}
+
+	if err := c.graphql.Query("GetCommentEdits", &query, variables); err != nil {
+		return nil, fmt.Errorf("get comment edits: %w", err)
+	}
+
+	var edits []CommentEdit
+	for _, n := range query.Node.Comment.UserContentEdits.Nodes {
+		edits = append(edits, CommentEdit{
+			EditedAt: n.EditedAt,
+			Editor:   n.Editor.Login,
+			Body:     n.Diff,
+		})
+	}
+	sort.SliceStable(edits, func(i, j int) bool {
+		return edits[i].EditedAt.Before(edits[j].EditedAt)
+	})
+	return edits, nil
+}
+
 func (c *Client) ResolveThread(threadID string) error {
 	type ResolveReviewThreadInput struct {
 		ThreadID graphql.ID `json:"threadId"`
@@ -255,6 +724,29 @@ func (c *Client) ResolveThread(threadID string) error {
 	return nil
 }
 
+func (c *Client) UnresolveThread(threadID string) error {
+	type UnresolveReviewThreadInput struct {
+		ThreadID graphql.ID `json:"threadId"`
+	}
+	var mutation struct {
+		UnresolveReviewThread struct {
+			Thread struct {
+				IsResolved bool
+			}
+		} `graphql:"unresolveReviewThread(input: $input)"`
+	}
+	variables := map[string]interface{}{
+		"input": UnresolveReviewThreadInput{
+			ThreadID: graphql.ID(threadID),
+		},
+	}
+	if err := c.graphql.Mutate("UnresolveReviewThread", &mutation, variables); err != nil {
+		return fmt.Errorf("unresolve thread: %w", err)
+	}
+
+	return nil
+}
+
 func (c *Client) GetIssueComments(owner, repo string, number int) ([]IssueComment, error) {
 	var allComments []IssueComment
 	page := 1
@@ -275,9 +767,380 @@ func (c *Client) GetIssueComments(owner, repo string, number int) ([]IssueCommen
 		page++
 	}
 
+	statusMap, err := c.getIssueCommentStatus(owner, repo, number)
+	if err != nil && c.strict {
+		return nil, fmt.Errorf("fetch minimized status: %w", err)
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: fetch minimized status: %v\n", err)
+	} else {
+		for i := range allComments {
+			if status, ok := statusMap[allComments[i].ID]; ok {
+				allComments[i].IsMinimized = status.minimized
+				allComments[i].MinimizedReason = status.minimizedReason
+			}
+		}
+	}
+
+	return allComments, nil
+}
+
+func (c *Client) getIssueCommentStatus(owner, repo string, number int) (map[int64]commentStatus, error) {
+	result := make(map[int64]commentStatus)
+	var cursor *graphql.String
+
+	for {
+		var query struct {
+			Repository struct {
+				PullRequest struct {
+					Comments struct {
+						PageInfo struct {
+							HasNextPage bool
+							EndCursor   string
+						}
+						Nodes []struct {
+							DatabaseId      int64
+							IsMinimized     bool
+							MinimizedReason string
+						}
+					} `graphql:"comments(first: 100, after: $cursor)"`
+				} `graphql:"pullRequest(number: $number)"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}
+
+		variables := map[string]interface{}{
+			"owner":  graphql.String(owner),
+			"repo":   graphql.String(repo),
+			"number": graphql.Int(number),
+			"cursor": cursor,
+		}
+
+		if err := c.graphql.Query("GetIssueCommentStatus", &query, variables); err != nil {
+			return nil, err
+		}
+
+		for _, comment := range query.Repository.PullRequest.Comments.Nodes {
+			result[comment.DatabaseId] = commentStatus{
+				minimized:       comment.IsMinimized,
+				minimizedReason: strings.ToLower(comment.MinimizedReason),
+			}
+		}
+
+		if !query.Repository.PullRequest.Comments.PageInfo.HasNextPage {
+			break
+		}
+		endCursor := graphql.String(query.Repository.PullRequest.Comments.PageInfo.EndCursor)
+		cursor = &endCursor
+	}
+
+	return result, nil
+}
+
+func (c *Client) GetPRCommits(owner, repo string, number int) ([]PRCommit, error) {
+	var allCommits []PRCommit
+	page := 1
+	perPage := 100
+
+	for {
+		var commits []PRCommit
+		path := fmt.Sprintf("repos/%s/%s/pulls/%d/commits?per_page=%d&page=%d", owner, repo, number, perPage, page)
+		if err := c.rest.Get(path, &commits); err != nil {
+			return nil, fmt.Errorf("get PR commits: %w", err)
+		}
+
+		allCommits = append(allCommits, commits...)
+
+		if len(commits) < perPage {
+			break
+		}
+		page++
+	}
+
+	return allCommits, nil
+}
+
+// GetPRFiles returns the files a pull request changes, with their line
+// counts.
+func (c *Client) GetPRFiles(owner, repo string, number int) ([]CommitFile, error) {
+	var allFiles []CommitFile
+	page := 1
+	perPage := 100
+
+	for {
+		var files []CommitFile
+		path := fmt.Sprintf("repos/%s/%s/pulls/%d/files?per_page=%d&page=%d", owner, repo, number, perPage, page)
+		if err := c.rest.Get(path, &files); err != nil {
+			return nil, fmt.Errorf("get PR files: %w", err)
+		}
+
+		allFiles = append(allFiles, files...)
+
+		if len(files) < perPage {
+			break
+		}
+		page++
+	}
+
+	return allFiles, nil
+}
+
+// GetCommit fetches a single commit including the files it changed.
+func (c *Client) GetCommit(owner, repo, sha string) (*PRCommit, error) {
+	var commit PRCommit
+	path := fmt.Sprintf("repos/%s/%s/commits/%s", owner, repo, sha)
+	if err := c.rest.Get(path, &commit); err != nil {
+		return nil, fmt.Errorf("get commit %s: %w", sha, err)
+	}
+	return &commit, nil
+}
+
+func (c *Client) GetCommitComments(owner, repo, sha string) ([]CommitComment, error) {
+	var allComments []CommitComment
+	page := 1
+	perPage := 100
+
+	for {
+		var comments []CommitComment
+		path := fmt.Sprintf("repos/%s/%s/commits/%s/comments?per_page=%d&page=%d", owner, repo, sha, perPage, page)
+		if err := c.rest.Get(path, &comments); err != nil {
+			return nil, fmt.Errorf("get commit comments: %w", err)
+		}
+
+		allComments = append(allComments, comments...)
+
+		if len(comments) < perPage {
+			break
+		}
+		page++
+	}
+
 	return allComments, nil
 }
 
+func (c *Client) GetCheckRuns(owner, repo, sha string) ([]CheckRun, error) {
+	var allRuns []CheckRun
+	page := 1
+	perPage := 100
+
+	for {
+		var resp struct {
+			TotalCount int        `json:"total_count"`
+			CheckRuns  []CheckRun `json:"check_runs"`
+		}
+		path := fmt.Sprintf("repos/%s/%s/commits/%s/check-runs?per_page=%d&page=%d", owner, repo, sha, perPage, page)
+		if err := c.rest.Get(path, &resp); err != nil {
+			return nil, fmt.Errorf("get check runs: %w", err)
+		}
+
+		allRuns = append(allRuns, resp.CheckRuns...)
+
+		if len(resp.CheckRuns) < perPage || len(allRuns) >= resp.TotalCount {
+			break
+		}
+		page++
+	}
+
+	return allRuns, nil
+}
+
+// GetCommitStatuses returns the latest status for each context on a commit.
+func (c *Client) GetCommitStatuses(owner, repo, sha string) ([]CommitStatus, error) {
+	var combined struct {
+		Statuses []CommitStatus `json:"statuses"`
+	}
+	path := fmt.Sprintf("repos/%s/%s/commits/%s/status?per_page=100", owner, repo, sha)
+	if err := c.rest.Get(path, &combined); err != nil {
+		return nil, fmt.Errorf("get commit statuses: %w", err)
+	}
+	return combined.Statuses, nil
+}
+
+// GetTimeline returns the reviews, comments, commits, force pushes, and
+// review requests of a pull request, oldest first.
+func (c *Client) GetTimeline(owner, repo string, number int) ([]TimelineEvent, error) {
+	type actor struct {
+		Login string
+	}
+	type commitRef struct {
+		Oid string
+	}
+
+	var events []TimelineEvent
+	var cursor *graphql.String
+
+	for {
+		var query struct {
+			Repository struct {
+				PullRequest struct {
+					TimelineItems struct {
+						PageInfo struct {
+							HasNextPage bool
+							EndCursor   string
+						}
+						Nodes []struct {
+							Typename          string `graphql:"__typename"`
+							PullRequestReview struct {
+								Author      actor
+								State       string
+								SubmittedAt time.Time
+								CreatedAt   time.Time
+								URL         string
+								Comments    struct {
+									TotalCount int
+								}
+							} `graphql:"... on PullRequestReview"`
+							IssueComment struct {
+								Author    actor
+								CreatedAt time.Time
+								URL       string
+								Body      string
+							} `graphql:"... on IssueComment"`
+							PullRequestCommit struct {
+								Commit struct {
+									Oid             string
+									MessageHeadline string
+									CommittedDate   time.Time
+									Author          struct {
+										User actor
+										Name string
+									}
+								}
+							} `graphql:"... on PullRequestCommit"`
+							HeadRefForcePushedEvent struct {
+								Actor        actor
+								CreatedAt    time.Time
+								BeforeCommit commitRef
+								AfterCommit  commitRef
+							} `graphql:"... on HeadRefForcePushedEvent"`
+							ReviewRequestedEvent struct {
+								Actor             actor
+								CreatedAt         time.Time
+								RequestedReviewer struct {
+									User actor `graphql:"... on User"`
+									Team struct {
+										Name string
+									} `graphql:"... on Team"`
+								}
+							} `graphql:"... on ReviewRequestedEvent"`
+							ReviewRequestRemovedEvent struct {
+								Actor             actor
+								CreatedAt         time.Time
+								RequestedReviewer struct {
+									User actor `graphql:"... on User"`
+									Team struct {
+										Name string
+									} `graphql:"... on Team"`
+								}
+							} `graphql:"... on ReviewRequestRemovedEvent"`
+						}
+					} `graphql:"timelineItems(first: 100, after: $cursor, itemTypes: [PULL_REQUEST_REVIEW, ISSUE_COMMENT, PULL_REQUEST_COMMIT, HEAD_REF_FORCE_PUSHED_EVENT, REVIEW_REQUESTED_EVENT, REVIEW_REQUEST_REMOVED_EVENT])"`
+				} `graphql:"pullRequest(number: $number)"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}
+
+		variables := map[string]interface{}{
+			"owner":  graphql.String(owner),
+			"repo":   graphql.String(repo),
+			"number": graphql.Int(number),
+			"cursor": cursor,
+		}
+
+		if err := c.graphql.Query("GetTimeline", &query, variables); err != nil {
+			return nil, fmt.Errorf("get timeline: %w", err)
+		}
+
+		for _, node := range query.Repository.PullRequest.TimelineItems.Nodes {
+			switch node.Typename {
+			case "PullRequestReview":
+				r := node.PullRequestReview
+				at := r.SubmittedAt
+				if at.IsZero() {
+					at = r.CreatedAt
+				}
+				events = append(events, TimelineEvent{
+					Kind:      "review",
+					CreatedAt: at,
+					Actor:     r.Author.Login,
+					Detail:    fmt.Sprintf("%s (%d comment(s))", r.State, r.Comments.TotalCount),
+					URL:       r.URL,
+				})
+			case "IssueComment":
+				ic := node.IssueComment
+				events = append(events, TimelineEvent{
+					Kind:      "comment",
+					CreatedAt: ic.CreatedAt,
+					Actor:     ic.Author.Login,
+					Detail:    TruncateString(ic.Body, 60),
+					URL:       ic.URL,
+				})
+			case "PullRequestCommit":
+				commit := node.PullRequestCommit.Commit
+				author := commit.Author.User.Login
+				if author == "" {
+					author = commit.Author.Name
+				}
+				events = append(events, TimelineEvent{
+					Kind:      "commit",
+					CreatedAt: commit.CommittedDate,
+					Actor:     author,
+					Detail:    shortSHA(commit.Oid) + " " + commit.MessageHeadline,
+					SHA:       commit.Oid,
+				})
+			case "HeadRefForcePushedEvent":
+				fp := node.HeadRefForcePushedEvent
+				events = append(events, TimelineEvent{
+					Kind:      "force_push",
+					CreatedAt: fp.CreatedAt,
+					Actor:     fp.Actor.Login,
+					Detail:    fmt.Sprintf("%s → %s", shortSHA(fp.BeforeCommit.Oid), shortSHA(fp.AfterCommit.Oid)),
+					SHA:       fp.AfterCommit.Oid,
+					BeforeSHA: fp.BeforeCommit.Oid,
+				})
+			case "ReviewRequestedEvent":
+				rr := node.ReviewRequestedEvent
+				events = append(events, TimelineEvent{
+					Kind:      "review_requested",
+					CreatedAt: rr.CreatedAt,
+					Actor:     rr.Actor.Login,
+					Detail:    reviewerName(rr.RequestedReviewer.User.Login, rr.RequestedReviewer.Team.Name),
+				})
+			case "ReviewRequestRemovedEvent":
+				rr := node.ReviewRequestRemovedEvent
+				events = append(events, TimelineEvent{
+					Kind:      "review_request_removed",
+					CreatedAt: rr.CreatedAt,
+					Actor:     rr.Actor.Login,
+					Detail:    reviewerName(rr.RequestedReviewer.User.Login, rr.RequestedReviewer.Team.Name),
+				})
+			}
+		}
+
+		if !query.Repository.PullRequest.TimelineItems.PageInfo.HasNextPage {
+			break
+		}
+		endCursor := graphql.String(query.Repository.PullRequest.TimelineItems.PageInfo.EndCursor)
+		cursor = &endCursor
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+	return events, nil
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+func reviewerName(login, team string) string {
+	if login != "" {
+		return login
+	}
+	return "team " + team
+}
+
 func (c *Client) ReplyToReviewComment(owner, repo string, prNumber int, commentID int64, body string) (*ReviewComment, error) {
 	var reply ReviewComment
 	path := fmt.Sprintf("repos/%s/%s/pulls/%d/comments/%d/replies", owner, repo, prNumber, commentID)
@@ -292,6 +1155,85 @@ func (c *Client) ReplyToReviewComment(owner, repo string, prNumber int, commentI
 	return &reply, nil
 }
 
+// DeleteReviewComment deletes a review comment, such as a reply that has to
+// be taken back.
+func (c *Client) DeleteReviewComment(owner, repo string, commentID int64) error {
+	path := fmt.Sprintf("repos/%s/%s/pulls/comments/%d", owner, repo, commentID)
+	if err := c.rest.Delete(path, nil); err != nil {
+		return fmt.Errorf("delete comment: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) CreateIssue(owner, repo, title, body string, labels []string) (*Issue, error) {
+	var created Issue
+	path := fmt.Sprintf("repos/%s/%s/issues", owner, repo)
+	payload := map[string]interface{}{"title": title, "body": body}
+	if len(labels) > 0 {
+		payload["labels"] = labels
+	}
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode request body: %w", err)
+	}
+	if err := c.rest.Post(path, bytes.NewBuffer(jsonData), &created); err != nil {
+		return nil, fmt.Errorf("create issue: %w", err)
+	}
+	return &created, nil
+}
+
+func (c *Client) CreateIssueComment(owner, repo string, number int, body string) (*IssueComment, error) {
+	var created IssueComment
+	path := fmt.Sprintf("repos/%s/%s/issues/%d/comments", owner, repo, number)
+	payload := map[string]string{"body": body}
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode request body: %w", err)
+	}
+	if err := c.rest.Post(path, bytes.NewBuffer(jsonData), &created); err != nil {
+		return nil, fmt.Errorf("create issue comment: %w", err)
+	}
+	return &created, nil
+}
+
+// AddReaction reacts to a review comment (kind "review_comment") or an issue
+// comment (kind "issue_comment") with content such as "eyes" or "+1".
+func (c *Client) AddReaction(owner, repo, kind string, commentID int64, content string) error {
+	var path string
+	switch kind {
+	case "review_comment":
+		path = fmt.Sprintf("repos/%s/%s/pulls/comments/%d/reactions", owner, repo, commentID)
+	case "issue_comment":
+		path = fmt.Sprintf("repos/%s/%s/issues/comments/%d/reactions", owner, repo, commentID)
+	default:
+		return fmt.Errorf("cannot react to a %s", kind)
+	}
+	jsonData, err := json.Marshal(map[string]string{"content": content})
+	if err != nil {
+		return fmt.Errorf("encode request body: %w", err)
+	}
+	var reaction struct {
+		ID int64 `json:"id"`
+	}
+	if err := c.rest.Post(path, bytes.NewBuffer(jsonData), &reaction); err != nil {
+		return fmt.Errorf("add reaction: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) CreateReviewComment(owner, repo string, prNumber int, comment NewReviewComment) (*ReviewComment, error) {
+	var created ReviewComment
+	path := fmt.Sprintf("repos/%s/%s/pulls/%d/comments", owner, repo, prNumber)
+	jsonData, err := json.Marshal(comment)
+	if err != nil {
+		return nil, fmt.Errorf("encode request body: %w", err)
+	}
+	if err := c.rest.Post(path, bytes.NewBuffer(jsonData), &created); err != nil {
+		return nil, fmt.Errorf("create review comment: %w", err)
+	}
+	return &created, nil
+}
+
 func (pr *PRReference) ResolveOwnerRepo(c *Client) error {
 	if pr.Owner != "" && pr.Repo != "" {
 		return nil
@@ -311,45 +1253,249 @@ func TruncateString(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
 	}
-	return s[:maxLen-3] + "..."
-}
-
-func GetCurrentBranch() (string, error) {
-	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
-	output, err := cmd.Output()
-	if err != nil {
-		return "", fmt.Errorf("get current branch: %w", err)
+	// Cut on a rune boundary so emoji and other multi-byte text stay valid.
+	cut := maxLen - 3
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
 	}
-	return strings.TrimSpace(string(output)), nil
+	return s[:cut] + "..."
 }
 
 type PRSearchResult struct {
-	Number int    `json:"number"`
-	Title  string `json:"title"`
-	State  string `json:"state"`
-	Head   struct {
+	Number   int        `json:"number"`
+	Title    string     `json:"title"`
+	State    string     `json:"state"`
+	MergedAt *time.Time `json:"merged_at"`
+	Head     struct {
 		Ref string `json:"ref"`
 	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+// BranchPROptions narrows the PRs considered when detecting the PR for the
+// current branch or commit. State is open, closed, or merged; Base is the
+// base branch. If several PRs remain, Choose picks one (what names the branch
+// or commit); without Choose, detection fails and lists the candidates.
+type BranchPROptions struct {
+	State  string
+	Base   string
+	Choose func(what string, prs []PRSearchResult) (int, error)
+}
+
+// BranchPRs is used by FindPRForBranch and FindPRForCommit.
+var BranchPRs BranchPROptions
+
+func (o BranchPROptions) matches(pr PRSearchResult) bool {
+	switch o.State {
+	case "merged":
+		if pr.MergedAt == nil {
+			return false
+		}
+	case "closed":
+		if pr.State != "closed" || pr.MergedAt != nil {
+			return false
+		}
+	case "open":
+		if pr.State != "open" {
+			return false
+		}
+	}
+	return o.Base == "" || pr.Base.Ref == o.Base
+}
+
+// errNoBranchPR is returned when a branch or commit has no PR in the
+// repository, so callers can try another one.
+var errNoBranchPR = errors.New("no pull request found")
+
+// FindPRForBranch finds the PR in owner/repo whose head is headOwner:branch.
+func (c *Client) FindPRForBranch(owner, repo, headOwner, branch string) (*PRReference, error) {
+	var prs []PRSearchResult
+	path := fmt.Sprintf("repos/%s/%s/pulls?head=%s:%s&state=all", owner, repo, url.QueryEscape(headOwner), url.QueryEscape(branch))
+	if err := c.rest.Get(path, &prs); err != nil {
+		return nil, fmt.Errorf("search PRs: %w", err)
+	}
+	return selectPR(owner, repo, fmt.Sprintf("branch '%s'", branch), prs)
 }
 
-func (c *Client) FindPRForBranch(owner, repo, branch string) (*PRReference, error) {
+// FindPRForCommit finds the PR in owner/repo that contains the commit sha.
+func (c *Client) FindPRForCommit(owner, repo, sha string) (*PRReference, error) {
 	var prs []PRSearchResult
-	path := fmt.Sprintf("repos/%s/%s/pulls?head=%s:%s&state=all", owner, repo, url.QueryEscape(owner), url.QueryEscape(branch))
+	path := fmt.Sprintf("repos/%s/%s/commits/%s/pulls", owner, repo, sha)
 	if err := c.rest.Get(path, &prs); err != nil {
 		return nil, fmt.Errorf("search PRs: %w", err)
 	}
+	short := sha
+	if len(short) > 7 {
+		short = short[:7]
+	}
+	return selectPR(owner, repo, "commit "+short, prs)
+}
+
+// selectPR applies BranchPRs to the PRs found for what (a branch or commit)
+// and returns the one that remains, asking BranchPRs.Choose if several do.
+func selectPR(owner, repo, what string, prs []PRSearchResult) (*PRReference, error) {
+	var candidates []PRSearchResult
+	for _, pr := range prs {
+		if BranchPRs.matches(pr) {
+			candidates = append(candidates, pr)
+		}
+	}
 
-	if len(prs) == 0 {
-		return nil, fmt.Errorf("no pull request found for branch '%s'", branch)
+	if len(candidates) == 0 {
+		if len(prs) > 0 {
+			return nil, fmt.Errorf("no pull request for %s matches the --pr-state/--pr-base filters", what)
+		}
+		return nil, fmt.Errorf("%w for %s", errNoBranchPR, what)
+	}
+
+	chosen := 0
+	if len(candidates) > 1 {
+		if BranchPRs.Choose == nil {
+			return nil, ambiguousPRError(what, candidates)
+		}
+		i, err := BranchPRs.Choose(what, candidates)
+		if err != nil {
+			return nil, err
+		}
+		chosen = i
 	}
 
 	return &PRReference{
 		Owner:  owner,
 		Repo:   repo,
-		Number: prs[0].Number,
+		Number: candidates[chosen].Number,
 	}, nil
 }
 
+// findPRForCurrentBranch looks for the branch's PR in the current repository
+// and then, for fork workflows, in the repository the branch is pushed to,
+// that repository's parent, and the "upstream" remote, searching for heads
+// owned by the push remote's owner. Heads are searched under the local
+// branch name, then under the remote branch it tracks (branch.<name>.merge),
+// or, when it has no upstream, under any branch on its push remote at the
+// same commit, so branches pushed under a different name are found with or
+// without an upstream. The default branch is never searched this way: a
+// branch created from it tracks it or points at its commit without being
+// the PR's head.
+func (c *Client) findPRForCurrentBranch(owner, repo, branch string) (*PRReference, error) {
+	heads := []string{branch}
+	var remoteNames []string
+	if merge := BranchMergeName(branch); merge != "" {
+		remoteNames = []string{merge}
+	} else {
+		remoteNames = PushedBranchNames(BranchRemote(branch), branch)
+	}
+	if len(remoteNames) > 0 && !slices.Equal(remoteNames, heads) {
+		defaultBranch := ""
+		if r, err := c.GetRepository(owner, repo); err == nil {
+			defaultBranch = r.DefaultBranch
+		}
+		for _, name := range remoteNames {
+			if name != defaultBranch && !slices.Contains(heads, name) {
+				heads = append(heads, name)
+			}
+		}
+	}
+	find := func(owner, repo, headOwner string) (*PRReference, error) {
+		var err error
+		for _, head := range heads {
+			var prRef *PRReference
+			prRef, err = c.FindPRForBranch(owner, repo, headOwner, head)
+			if !errors.Is(err, errNoBranchPR) {
+				return prRef, err
+			}
+		}
+		return nil, err
+	}
+
+	prRef, err := find(owner, repo, owner)
+	if !errors.Is(err, errNoBranchPR) {
+		return prRef, err
+	}
+	notFound := err
+
+	headOwner, headRepo, err := RemoteRepo(BranchRemote(branch))
+	if err != nil {
+		return nil, notFound
+	}
+
+	type target struct{ owner, repo string }
+	tried := map[target]bool{{owner, repo}: headOwner == owner}
+	var targets []target
+	targets = append(targets, target{owner, repo}, target{headOwner, headRepo})
+	if r, err := c.GetRepository(headOwner, headRepo); err == nil && r.Fork && r.Parent != nil {
+		targets = append(targets, target{r.Parent.Owner.Login, r.Parent.Name})
+	}
+	if upOwner, upRepo, err := RemoteRepo("upstream"); err == nil {
+		targets = append(targets, target{upOwner, upRepo})
+	}
+
+	for _, t := range targets {
+		if tried[t] {
+			continue
+		}
+		tried[t] = true
+		prRef, err := find(t.owner, t.repo, headOwner)
+		if !errors.Is(err, errNoBranchPR) {
+			return prRef, err
+		}
+	}
+	return nil, notFound
+}
+
+func ambiguousPRError(what string, prs []PRSearchResult) error {
+	lines := []string{fmt.Sprintf("%s has %d pull requests:", what, len(prs))}
+	for _, pr := range prs {
+		lines = append(lines, fmt.Sprintf("  #%d [%s → %s] %s", pr.Number, pr.Status(), pr.Base.Ref, pr.Title))
+	}
+	lines = append(lines, "use --pr, --pr-state, or --pr-base to pick one")
+	return errors.New(strings.Join(lines, "\n"))
+}
+
+// Status is open, closed, or merged.
+func (pr *PRSearchResult) Status() string {
+	if pr.MergedAt != nil {
+		return "merged"
+	}
+	return pr.State
+}
+
+// githubRefPattern matches the ref GitHub Actions checks out for
+// pull_request events.
+var githubRefPattern = regexp.MustCompile(`^refs/pull/(\d+)/(?:merge|head)$`)
+
+// findPRForDetachedHead detects the PR for a detached checkout, as in CI.
+// Inside GitHub Actions the PR number comes from GITHUB_REF; otherwise the PR
+// containing HEAD is searched for. For merge commits (such as the merge ref
+// Actions checks out), the second parent, which is the PR head, is tried too.
+func (c *Client) findPRForDetachedHead(owner, repo string) (*PRReference, error) {
+	if m := githubRefPattern.FindStringSubmatch(os.Getenv("GITHUB_REF")); m != nil {
+		number, _ := strconv.Atoi(m[1])
+		if full := os.Getenv("GITHUB_REPOSITORY"); full != "" {
+			if o, r, ok := strings.Cut(full, "/"); ok {
+				owner, repo = o, r
+			}
+		}
+		return &PRReference{Owner: owner, Repo: repo, Number: number}, nil
+	}
+
+	notFound := fmt.Errorf("%w for detached HEAD", errNoBranchPR)
+	for _, rev := range []string{"HEAD", "HEAD^2"} {
+		sha, err := RevParse(rev)
+		if err != nil {
+			continue
+		}
+		prRef, err := c.FindPRForCommit(owner, repo, sha)
+		if !errors.Is(err, errNoBranchPR) {
+			return prRef, err
+		}
+		notFound = err
+	}
+	return nil, notFound
+}
+
 func (c *Client) ResolvePRReference(args []string) (*PRReference, error) {
 	if len(args) > 0 && args[0] != "" {
 		prRef, err := ParsePRReference(args[0])
@@ -372,7 +1518,15 @@ func (c *Client) ResolvePRReference(args []string) (*PRReference, error) {
 		return nil, fmt.Errorf("no PR specified and %w", err)
 	}
 
-	prRef, err := c.FindPRForBranch(owner, repo, branch)
+	if branch == "HEAD" {
+		prRef, err := c.findPRForDetachedHead(owner, repo)
+		if err != nil {
+			return nil, fmt.Errorf("no PR specified and %w", err)
+		}
+		return prRef, nil
+	}
+
+	prRef, err := c.findPRForCurrentBranch(owner, repo, branch)
 	if err != nil {
 		return nil, fmt.Errorf("no PR specified and %w", err)
 	}
@@ -408,3 +1562,28 @@ func (c *Client) MinimizeComment(nodeID string, classifier CommentClassifier) er
 	return nil
 }
 
+func (c *Client) UnminimizeComment(nodeID string) error {
+	var mutation struct {
+		UnminimizeComment struct {
+			UnminimizedComment struct {
+				IsMinimized bool
+			}
+		} `graphql:"unminimizeComment(input: $input)"`
+	}
+
+	type UnminimizeCommentInput struct {
+		SubjectID graphql.ID `json:"subjectId"`
+	}
+
+	variables := map[string]interface{}{
+		"input": UnminimizeCommentInput{
+			SubjectID: graphql.ID(nodeID),
+		},
+	}
+
+	if err := c.graphql.Mutate("UnminimizeComment", &mutation, variables); err != nil {
+		return fmt.Errorf("unminimize comment: %w", err)
+	}
+
+	return nil
+}
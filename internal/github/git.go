@@ -0,0 +1,241 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// GetCurrentBranch returns the checked-out branch, or "HEAD" when HEAD is
+// detached. A branch being rebased counts as checked out. Both work in
+// linked worktrees, which have their own HEAD and rebase state.
+func GetCurrentBranch() (string, error) {
+	output, err := exec.Command("git", "symbolic-ref", "--quiet", "--short", "HEAD").Output()
+	if err == nil {
+		return strings.TrimSpace(string(output)), nil
+	}
+	if _, err := exec.Command("git", "rev-parse", "--git-dir").Output(); err != nil {
+		return "", fmt.Errorf("get current branch: %w", err)
+	}
+	if branch := rebasingBranch(); branch != "" {
+		return branch, nil
+	}
+	return "HEAD", nil
+}
+
+// rebasingBranch returns the branch an in-progress rebase will update, or "".
+func rebasingBranch() string {
+	for _, state := range []string{"rebase-merge/head-name", "rebase-apply/head-name"} {
+		output, err := exec.Command("git", "rev-parse", "--git-path", state).Output()
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(strings.TrimSpace(string(output)))
+		if err != nil {
+			continue
+		}
+		if branch, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "refs/heads/"); ok {
+			return branch
+		}
+	}
+	return ""
+}
+
+// BranchMergeName returns the name of the remote branch that branch tracks
+// (from branch.<name>.merge), or "" if it tracks none.
+func BranchMergeName(branch string) string {
+	output, err := exec.Command("git", "config", "--get", "branch."+branch+".merge").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.TrimSpace(string(output)), "refs/heads/")
+}
+
+// RemoteDefaultBranch returns the name of remote's default branch as
+// recorded in refs/remotes/<remote>/HEAD, or "" if it is not known.
+func RemoteDefaultBranch(remote string) string {
+	output, err := exec.Command("git", "symbolic-ref", "--quiet", "refs/remotes/"+remote+"/HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.TrimSpace(string(output)), "refs/remotes/"+remote+"/")
+}
+
+// PushedBranchNames returns the names of the branches on remote whose
+// remote-tracking ref points at the same commit as branch. This finds the
+// pushed name of a branch pushed without setting an upstream, as with
+// 'git push origin HEAD:feature-x'. The remote's default branch is never
+// returned: a branch just created from it points at the same commit without
+// having been pushed as it.
+func PushedBranchNames(remote, branch string) []string {
+	output, err := exec.Command("git", "for-each-ref", "--points-at", "refs/heads/"+branch,
+		"--format=%(refname)", "refs/remotes/"+remote+"/").Output()
+	if err != nil {
+		return nil
+	}
+	defaultBranch := RemoteDefaultBranch(remote)
+	var names []string
+	for _, ref := range strings.Fields(string(output)) {
+		name := strings.TrimPrefix(ref, "refs/remotes/"+remote+"/")
+		if name != "HEAD" && name != defaultBranch {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// RevParse resolves a git revision such as HEAD to a commit SHA.
+func RevParse(rev string) (string, error) {
+	output, err := exec.Command("git", "rev-parse", "--verify", "--quiet", rev+"^{commit}").Output()
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", rev, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// remoteURLPattern matches the owner/repo at the end of https, ssh, and
+// scp-style remote URLs.
+var remoteURLPattern = regexp.MustCompile(`[:/]([^/:]+)/([^/]+?)(?:\.git)?/?$`)
+
+// RemoteRepo returns the owner and name of the repository a git remote
+// points at.
+func RemoteRepo(remote string) (owner, repo string, err error) {
+	output, err := exec.Command("git", "remote", "get-url", remote).Output()
+	if err != nil {
+		return "", "", fmt.Errorf("get url of remote %s: %w", remote, err)
+	}
+	m := remoteURLPattern.FindStringSubmatch(strings.TrimSpace(string(output)))
+	if m == nil {
+		return "", "", fmt.Errorf("cannot parse url of remote %s", remote)
+	}
+	return m[1], m[2], nil
+}
+
+// BranchRemote returns the remote a branch is pushed to: its pushRemote,
+// the repository's pushDefault, its tracking remote, or "origin".
+func BranchRemote(branch string) string {
+	for _, key := range []string{"branch." + branch + ".pushRemote", "remote.pushDefault", "branch." + branch + ".remote"} {
+		output, err := exec.Command("git", "config", "--get", key).Output()
+		if remote := strings.TrimSpace(string(output)); err == nil && remote != "" && remote != "." {
+			return remote
+		}
+	}
+	return "origin"
+}
+
+// Diff returns the uncolored 'git diff base...HEAD': the changes HEAD makes
+// since it diverged from base.
+func Diff(base string) (string, error) {
+	output, err := exec.Command("git", "diff", "--no-color", "--no-ext-diff", base+"...HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("git diff %s...HEAD: %w", base, err)
+	}
+	return string(output), nil
+}
+
+// GitConfigBool reports whether a boolean git config key is set to true.
+func GitConfigBool(key string) bool {
+	output, err := exec.Command("git", "config", "--type=bool", "--get", key).Output()
+	return err == nil && strings.TrimSpace(string(output)) == "true"
+}
+
+// GitConfigAll returns every value of a multi-valued git config key, in
+// the order git reads them. A key that is not set has no values.
+func GitConfigAll(key string) []string {
+	output, err := exec.Command("git", "config", "--get-all", key).Output()
+	if err != nil {
+		return nil
+	}
+	var values []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			values = append(values, line)
+		}
+	}
+	return values
+}
+
+// GitPath returns the path git uses for name inside the repository's git
+// directory, such as "hooks" (which honors core.hooksPath).
+func GitPath(name string) (string, error) {
+	output, err := exec.Command("git", "rev-parse", "--git-path", name).Output()
+	if err != nil {
+		return "", fmt.Errorf("locate git %s: %w", name, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// AmendTrailers adds trailer lines such as "Key: value" to the message of
+// the HEAD commit, keeping the rest of the commit as it is. Staged changes
+// are left out of the amended commit.
+func AmendTrailers(trailers []string) error {
+	args := []string{"commit", "--amend", "--only", "--allow-empty", "--no-edit", "--quiet"}
+	for _, t := range trailers {
+		args = append(args, "--trailer", t)
+	}
+	cmd := exec.Command("git", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("amend HEAD: %s", msg)
+		}
+		return fmt.Errorf("amend HEAD: %w", err)
+	}
+	return nil
+}
+
+func GetRepoRoot() (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("get repository root: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+type BlameInfo struct {
+	SHA    string `json:"sha"`
+	Author string `json:"author"`
+}
+
+func (b *BlameInfo) ShortSHA() string {
+	if len(b.SHA) > 7 {
+		return b.SHA[:7]
+	}
+	return b.SHA
+}
+
+// BlameLine reports the commit that last touched line in path (relative to
+// the repository root) in the local working tree.
+func BlameLine(path string, line int) (*BlameInfo, error) {
+	cmd := exec.Command("git", "blame", "--porcelain", "-L", strconv.Itoa(line)+","+strconv.Itoa(line), "--", path)
+	cmd.Dir, _ = GetRepoRoot()
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git blame %s:%d: %w", path, line, err)
+	}
+
+	var info BlameInfo
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		text := scanner.Text()
+		if info.SHA == "" {
+			info.SHA, _, _ = strings.Cut(text, " ")
+			continue
+		}
+		if author, ok := strings.CutPrefix(text, "author "); ok {
+			info.Author = author
+			break
+		}
+	}
+	if info.SHA == "" {
+		return nil, fmt.Errorf("git blame %s:%d: no output", path, line)
+	}
+	return &info, nil
+}
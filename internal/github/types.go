@@ -36,24 +36,58 @@ func ParseClassifier(s string) (CommentClassifier, error) {
 	}
 }
 
+// ParseReaction returns the reaction content GitHub expects for s, which
+// may be the content itself ("+1", "eyes"), a shortcode name ("thumbsup",
+// "tada"), or the emoji.
+func ParseReaction(s string) (string, error) {
+	switch strings.ToLower(strings.Trim(s, ":")) {
+	case "+1", "thumbsup", "👍":
+		return "+1", nil
+	case "-1", "thumbsdown", "👎":
+		return "-1", nil
+	case "laugh", "smile", "😄":
+		return "laugh", nil
+	case "confused", "😕":
+		return "confused", nil
+	case "heart", "❤️", "❤":
+		return "heart", nil
+	case "hooray", "tada", "🎉":
+		return "hooray", nil
+	case "rocket", "🚀":
+		return "rocket", nil
+	case "eyes", "👀":
+		return "eyes", nil
+	default:
+		return "", fmt.Errorf("invalid reaction: %s (valid: +1, -1, laugh, confused, heart, hooray, rocket, eyes)", s)
+	}
+}
+
 type User struct {
 	Login string `json:"login"`
+	Type  string `json:"type,omitempty"`
+}
+
+func (u User) IsBot() bool {
+	return u.Type == "Bot" || strings.HasSuffix(u.Login, "[bot]")
 }
 
 type Review struct {
-	ID          int64     `json:"id"`
-	NodeID      string    `json:"node_id"`
-	User        User      `json:"user"`
-	Body        string    `json:"body"`
-	State       string    `json:"state"`
-	HTMLURL     string    `json:"html_url"`
-	SubmittedAt time.Time `json:"submitted_at"`
+	ID              int64     `json:"id"`
+	NodeID          string    `json:"node_id"`
+	User            User      `json:"user"`
+	Body            string    `json:"body"`
+	State           string    `json:"state"`
+	HTMLURL         string    `json:"html_url"`
+	SubmittedAt     time.Time `json:"submitted_at"`
+	IsMinimized     bool      `json:"is_minimized"`
+	MinimizedReason string    `json:"minimized_reason,omitempty"`
 }
 
 type ReviewComment struct {
 	ID                    int64     `json:"id"`
 	NodeID                string    `json:"node_id"`
 	PullRequestReviewID   int64     `json:"pull_request_review_id"`
+	InReplyToID           int64     `json:"in_reply_to_id,omitempty"`
 	DiffHunk              string    `json:"diff_hunk"`
 	Path                  string    `json:"path"`
 	Position              *int      `json:"position"`
@@ -73,31 +107,343 @@ type ReviewComment struct {
 	StartSide             string    `json:"start_side"`
 	SubjectType           string    `json:"subject_type"`
 	IsResolved            bool      `json:"is_resolved"`
+	IsMinimized           bool      `json:"is_minimized"`
+	MinimizedReason       string    `json:"minimized_reason,omitempty"`
 }
 
 func (rc *ReviewComment) IsOutdated() bool {
+	if rc.IsFileLevel() {
+		return false
+	}
 	return rc.Position == nil || rc.Line == nil
 }
 
+// IsFileLevel reports whether the comment is attached to a whole file rather
+// than to specific lines.
+func (rc *ReviewComment) IsFileLevel() bool {
+	return rc.SubjectType == "file"
+}
+
+// CurrentLine prefers the line in the latest diff, falling back to the line
+// the comment was originally made on.
+func (rc *ReviewComment) CurrentLine() int {
+	if rc.IsFileLevel() {
+		return 0
+	}
+	if rc.Line != nil {
+		return *rc.Line
+	}
+	if rc.OriginalLine != nil {
+		return *rc.OriginalLine
+	}
+	return 0
+}
+
+// LineRange formats the commented lines as "10" or, for multi-line comments,
+// "10-24". It is empty for file-level comments.
+func (rc *ReviewComment) LineRange() string {
+	if rc.IsFileLevel() || rc.OriginalLine == nil {
+		return ""
+	}
+	if rc.OriginalStartLine != nil && *rc.OriginalStartLine != *rc.OriginalLine {
+		return fmt.Sprintf("%d-%d", *rc.OriginalStartLine, *rc.OriginalLine)
+	}
+	return fmt.Sprintf("%d", *rc.OriginalLine)
+}
+
+func (rc *ReviewComment) Location() string {
+	if lines := rc.LineRange(); lines != "" {
+		return rc.Path + ":" + lines
+	}
+	return rc.Path
+}
+
 type IssueComment struct {
+	ID              int64     `json:"id"`
+	NodeID          string    `json:"node_id"`
+	User            User      `json:"user"`
+	Body            string    `json:"body"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	HTMLURL         string    `json:"html_url"`
+	IsMinimized     bool      `json:"is_minimized"`
+	MinimizedReason string    `json:"minimized_reason,omitempty"`
+}
+
+// CommitComment is a comment attached to a commit rather than to the PR diff.
+// Path and Line are empty for comments on the commit as a whole.
+type CommitComment struct {
 	ID        int64     `json:"id"`
 	NodeID    string    `json:"node_id"`
+	CommitID  string    `json:"commit_id"`
 	User      User      `json:"user"`
 	Body      string    `json:"body"`
+	Path      string    `json:"path,omitempty"`
+	Line      *int      `json:"line,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	HTMLURL   string    `json:"html_url"`
 }
 
+type PRCommit struct {
+	SHA    string `json:"sha"`
+	Commit struct {
+		Message string    `json:"message"`
+		Author  GitPerson `json:"author"`
+		// Committer.Date changes when a commit is rebased or amended, so it
+		// is the closer approximation of when the commit was pushed.
+		Committer GitPerson `json:"committer"`
+	} `json:"commit"`
+	Files []CommitFile `json:"files,omitempty"`
+}
+
+type GitPerson struct {
+	Name string    `json:"name"`
+	Date time.Time `json:"date"`
+}
+
+// CommitFile is a file changed by a commit or pull request. Patch holds the
+// file's diff hunks and is empty for binary or very large changes.
+type CommitFile struct {
+	Filename  string `json:"filename"`
+	Status    string `json:"status"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Patch     string `json:"patch,omitempty"`
+}
+
+// Subject returns the first line of the commit message.
+func (c *PRCommit) Subject() string {
+	subject, _, _ := strings.Cut(c.Commit.Message, "\n")
+	return subject
+}
+
+type Issue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+}
+
 type PullRequest struct {
-	Number int    `json:"number"`
-	Title  string `json:"title"`
-	State  string `json:"state"`
-	User   User   `json:"user"`
+	Number         int      `json:"number"`
+	Title          string   `json:"title"`
+	Body           string   `json:"body"`
+	State          string   `json:"state"`
+	Draft          bool     `json:"draft"`
+	User           User     `json:"user"`
+	HTMLURL        string   `json:"html_url"`
+	Labels         []Label  `json:"labels"`
+	Head           PRBranch `json:"head"`
+	Base           PRBranch `json:"base"`
+	Mergeable      *bool    `json:"mergeable"`
+	MergeableState string   `json:"mergeable_state"`
+
+	// Comments and ReviewComments are GitHub's counts of general and
+	// review comments on the PR.
+	Comments       int `json:"comments"`
+	ReviewComments int `json:"review_comments"`
+}
+
+// MergeStatus describes whether the PR can be merged. GitHub computes
+// mergeability in the background, so it may still be "unknown".
+func (pr *PullRequest) MergeStatus() string {
+	if pr.Mergeable == nil {
+		return "unknown"
+	}
+	status := "not mergeable"
+	if *pr.Mergeable {
+		status = "mergeable"
+	}
+	if pr.MergeableState != "" && pr.MergeableState != "unknown" {
+		status += " (" + pr.MergeableState + ")"
+	}
+	return status
+}
+
+// LabelNames returns the names of the PR's labels.
+func (pr *PullRequest) LabelNames() []string {
+	names := make([]string, 0, len(pr.Labels))
+	for _, l := range pr.Labels {
+		names = append(names, l.Name)
+	}
+	return names
+}
+
+// MergeRequirements are the review-related rules a PR's base branch imposes
+// before merging, combined from classic branch protection and rulesets.
+type MergeRequirements struct {
+	RequiredApprovals             int      `json:"required_approvals"`
+	RequireCodeOwnerReview        bool     `json:"require_code_owner_review"`
+	DismissStaleReviews           bool     `json:"dismiss_stale_reviews"`
+	RequireLastPushApproval       bool     `json:"require_last_push_approval"`
+	RequireConversationResolution bool     `json:"require_conversation_resolution"`
+	Sources                       []string `json:"sources"`
+	Warnings                      []string `json:"warnings,omitempty"`
+}
+
+// merge tightens r with the rules of another source; the strictest wins.
+func (r *MergeRequirements) merge(source string, approvals int, codeOwner, dismissStale, lastPush, conversations bool) {
+	r.Sources = append(r.Sources, source)
+	if approvals > r.RequiredApprovals {
+		r.RequiredApprovals = approvals
+	}
+	r.RequireCodeOwnerReview = r.RequireCodeOwnerReview || codeOwner
+	r.DismissStaleReviews = r.DismissStaleReviews || dismissStale
+	r.RequireLastPushApproval = r.RequireLastPushApproval || lastPush
+	r.RequireConversationResolution = r.RequireConversationResolution || conversations
+}
+
+type Repository struct {
+	Name          string      `json:"name"`
+	FullName      string      `json:"full_name"`
+	Owner         User        `json:"owner"`
+	Fork          bool        `json:"fork"`
+	Parent        *Repository `json:"parent,omitempty"`
+	DefaultBranch string      `json:"default_branch"`
+}
+
+type Label struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type PRBranch struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+
+	// Repo is the repository the branch lives in; nil when it was deleted.
+	Repo *Repository `json:"repo,omitempty"`
+}
+
+// NewReviewComment is the payload for creating a review comment. Leaving Line
+// at zero creates a file-level comment.
+type NewReviewComment struct {
+	Body        string `json:"body"`
+	CommitID    string `json:"commit_id"`
+	Path        string `json:"path"`
+	Line        int    `json:"line,omitempty"`
+	StartLine   int    `json:"start_line,omitempty"`
+	StartSide   string `json:"start_side,omitempty"`
+	Side        string `json:"side,omitempty"`
+	SubjectType string `json:"subject_type,omitempty"`
 }
 
 type ReviewThread struct {
-	ID         string
-	IsResolved bool
-	CommentIDs []int64
+	ID          string
+	IsResolved  bool
+	ResolvedBy  string
+	IsOutdated  bool
+	Path        string
+	Line        *int
+	StartLine   *int
+	SubjectType string
+	CommentIDs  []int64
+	Comments    []ThreadComment
+}
+
+func (t *ReviewThread) Location() string {
+	if t.SubjectType == "FILE" || t.Line == nil {
+		return t.Path
+	}
+	if t.StartLine != nil && *t.StartLine != *t.Line {
+		return fmt.Sprintf("%s:%d-%d", t.Path, *t.StartLine, *t.Line)
+	}
+	return fmt.Sprintf("%s:%d", t.Path, *t.Line)
+}
+
+type ThreadComment struct {
+	ID        int64     `json:"id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (t *ReviewThread) LastComment() *ThreadComment {
+	if len(t.Comments) == 0 {
+		return nil
+	}
+	return &t.Comments[len(t.Comments)-1]
+}
+
+// CommentEdit is one revision of a comment body. Body is the full text
+// after the edit; GitHub's oldest entry holds the original text.
+type CommentEdit struct {
+	EditedAt time.Time `json:"edited_at"`
+	Editor   string    `json:"editor"`
+	Body     string    `json:"body"`
+}
+
+// TimelineEvent is one entry of a pull request's timeline. Kind is review,
+// comment, commit, force_push, review_requested, or review_request_removed.
+// SHA is the pushed commit, or the new head for force pushes, whose old head
+// is BeforeSHA.
+type TimelineEvent struct {
+	Kind      string    `json:"kind"`
+	CreatedAt time.Time `json:"created_at"`
+	Actor     string    `json:"actor"`
+	Detail    string    `json:"detail"`
+	SHA       string    `json:"sha,omitempty"`
+	BeforeSHA string    `json:"before_sha,omitempty"`
+	URL       string    `json:"url,omitempty"`
+}
+
+// CheckRun is a GitHub Actions job or app check on a commit.
+type CheckRun struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	HTMLURL    string `json:"html_url"`
+}
+
+// CommitStatus is a legacy status reported through the statuses API.
+type CommitStatus struct {
+	Context   string `json:"context"`
+	State     string `json:"state"`
+	TargetURL string `json:"target_url"`
+}
+
+// Notification is a thread in the authenticated user's GitHub inbox.
+type Notification struct {
+	ID      string `json:"id"`
+	Unread  bool   `json:"unread"`
+	Reason  string `json:"reason"`
+	Subject struct {
+		Title string `json:"title"`
+		URL   string `json:"url"`
+		Type  string `json:"type"`
+	} `json:"subject"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// IsForPullRequest reports whether the notification is about the given PR.
+// Subject URLs point at the API host, which differs on GitHub Enterprise, so
+// only the path is compared.
+func (n *Notification) IsForPullRequest(owner, repo string, number int) bool {
+	if n.Subject.Type != "PullRequest" {
+		return false
+	}
+	suffix := fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repo, number)
+	return strings.HasSuffix(strings.ToLower(n.Subject.URL), strings.ToLower(suffix))
+}
+
+type IssueSearchResult struct {
+	Number        int       `json:"number"`
+	Title         string    `json:"title"`
+	State         string    `json:"state"`
+	HTMLURL       string    `json:"html_url"`
+	RepositoryURL string    `json:"repository_url"`
+	User          User      `json:"user"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func (r *IssueSearchResult) PRReference() *PRReference {
+	parts := strings.Split(strings.TrimSuffix(r.RepositoryURL, "/"), "/")
+	if len(parts) < 2 {
+		return &PRReference{Number: r.Number}
+	}
+	return &PRReference{
+		Owner:  parts[len(parts)-2],
+		Repo:   parts[len(parts)-1],
+		Number: r.Number,
+	}
 }
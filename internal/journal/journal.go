@@ -0,0 +1,178 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/STRRL/gh-pr-comments/internal/state"
+)
+
+const fileName = "journal.jsonl"
+
+// maxEntries is how many entries the journal keeps. Once the file grows
+// past maxBytes, the oldest entries beyond it are dropped, so long-running
+// commands such as the daemon cannot grow it without bound.
+const (
+	maxEntries = 1000
+	maxBytes   = 1 << 20
+)
+
+// Entry is one mutating action taken against GitHub. Only the fields that
+// apply to the action are set.
+type Entry struct {
+	ID        string    `json:"id"`
+	Time      time.Time `json:"time"`
+	Action    string    `json:"action"`
+	ThreadID  string    `json:"thread_id,omitempty"`
+	NodeID    string    `json:"node_id,omitempty"`
+	CommentID int64     `json:"comment_id,omitempty"`
+	Repo      string    `json:"repo,omitempty"`
+	PR        int       `json:"pr,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	Reverts   string    `json:"reverts,omitempty"`
+}
+
+// Inverse returns the action that reverses e, or "" if e cannot be undone.
+func (e *Entry) Inverse() string {
+	switch e.Action {
+	case "resolve":
+		return "unresolve"
+	case "hide":
+		return "unhide"
+	}
+	return ""
+}
+
+func path() string {
+	return filepath.Join(state.Dir(), fileName)
+}
+
+// Record appends an entry to the journal, filling in its ID and time.
+func Record(e Entry) error {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	if e.ID == "" {
+		e.ID = strconv.FormatInt(e.Time.UnixNano(), 36)
+	}
+
+	if err := os.MkdirAll(state.Dir(), 0o755); err != nil {
+		return fmt.Errorf("create state directory: %w", err)
+	}
+	f, err := os.OpenFile(path(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open journal: %w", err)
+	}
+	defer f.Close()
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("encode journal entry: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write journal: %w", err)
+	}
+	if info, err := f.Stat(); err == nil && info.Size() > maxBytes {
+		return trim()
+	}
+	return nil
+}
+
+// trim rewrites the journal with only its newest maxEntries entries. An
+// entry is always newer than the one it reverts, so no revert outlives its
+// original.
+func trim() error {
+	entries, err := Read()
+	if err != nil {
+		return err
+	}
+	if len(entries) > maxEntries {
+		entries = entries[len(entries)-maxEntries:]
+	}
+	var buf []byte
+	for _, e := range entries {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("encode journal entry: %w", err)
+		}
+		buf = append(append(buf, data...), '\n')
+	}
+	tmp := path() + ".tmp"
+	if err := os.WriteFile(tmp, buf, 0o600); err != nil {
+		return fmt.Errorf("trim journal: %w", err)
+	}
+	if err := os.Rename(tmp, path()); err != nil {
+		return fmt.Errorf("trim journal: %w", err)
+	}
+	return nil
+}
+
+// Read returns all journal entries, oldest first. A missing journal is empty.
+func Read() ([]Entry, error) {
+	f, err := os.Open(path())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open journal: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("decode journal: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read journal: %w", err)
+	}
+	return entries, nil
+}
+
+// ForPR returns the entries recorded for one pull request, given as
+// owner/repo and number.
+func ForPR(entries []Entry, repo string, pr int) []Entry {
+	var result []Entry
+	for _, e := range entries {
+		if e.PR == pr && strings.EqualFold(e.Repo, repo) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Reversible returns the entries that can still be undone, newest first.
+func Reversible(entries []Entry) []Entry {
+	reverted := make(map[string]bool)
+	for _, e := range entries {
+		if e.Reverts != "" {
+			reverted[e.Reverts] = true
+		}
+	}
+
+	var result []Entry
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if e.Inverse() != "" && !reverted[e.ID] {
+			result = append(result, e)
+		}
+	}
+	return result
+}
@@ -0,0 +1,33 @@
+// Package marker reads the hidden markers bots embed in comment bodies.
+//
+// Bots that keep one comment per PR up to date, such as coverage reports and
+// review summaries, tag it with an HTML comment (for example
+// "<!-- codecov-comment -->" or "<!-- fingerprinting:phantom:medusa -->") so
+// they can find it again. The marker identifies which bot surface a comment
+// belongs to.
+package marker
+
+import (
+	"regexp"
+	"strings"
+)
+
+// htmlCommentPattern matches a hidden HTML comment and captures its text.
+var htmlCommentPattern = regexp.MustCompile(`<!--([\s\S]*?)-->`)
+
+// Of returns the text of the first non-empty HTML comment in body, with
+// runs of whitespace collapsed, or "" when body has none.
+func Of(body string) string {
+	for _, m := range htmlCommentPattern.FindAllStringSubmatch(body, -1) {
+		if text := strings.Join(strings.Fields(m[1]), " "); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// Match reports whether marker contains pattern, ignoring case. An empty
+// marker never matches.
+func Match(marker, pattern string) bool {
+	return marker != "" && strings.Contains(strings.ToLower(marker), strings.ToLower(pattern))
+}
@@ -0,0 +1,112 @@
+// Package redact masks credentials in text that leaves the tool, such as
+// exported review conversations, so a token pasted into a comment is not
+// archived along with it.
+package redact
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// Placeholder replaces every redacted secret.
+const Placeholder = "[REDACTED]"
+
+// builtinPatterns match well-known token formats. When a pattern has a
+// group named "secret", only that group is replaced, so the surrounding
+// context ("Authorization: Bearer", "password=") stays readable.
+var builtinPatterns = []string{
+	// GitHub personal access, OAuth, user-to-server, server-to-server and
+	// refresh tokens, and fine-grained personal access tokens.
+	`\bgh[pousr]_[A-Za-z0-9]{36,255}\b`,
+	`\bgithub_pat_[A-Za-z0-9_]{22,255}\b`,
+	// AWS access key IDs.
+	`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,
+	// Slack tokens.
+	`\bxox[abprs]-[A-Za-z0-9-]{10,}`,
+	// Google API keys.
+	`\bAIza[0-9A-Za-z_-]{35}\b`,
+	// Stripe live keys.
+	`\b[rs]k_live_[0-9A-Za-z]{24,}\b`,
+	// OpenAI and Anthropic API keys.
+	`\bsk-(?:ant-|proj-)?[A-Za-z0-9_-]{20,}`,
+	// JSON Web Tokens.
+	`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`,
+	// PEM private key blocks.
+	`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`,
+	// Bearer tokens in Authorization headers.
+	`(?i)\bbearer\s+(?P<secret>[A-Za-z0-9._~+/=-]{20,})`,
+	// Quoted values assigned to credential-like names.
+	`(?i)\b(?:password|passwd|secret|token|api[_-]?key|access[_-]?key)["']?\s*[:=]\s*["'](?P<secret>[^"'\s]{8,})["']`,
+}
+
+// Redactor replaces secrets matched by its patterns with Placeholder. A nil
+// Redactor leaves text unchanged.
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+// New returns a Redactor for the built-in token patterns plus the extra
+// regular expressions, which follow the same "secret" group convention.
+func New(extra []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, p := range builtinPatterns {
+		r.patterns = append(r.patterns, regexp.MustCompile(p))
+	}
+	for _, p := range extra {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", p, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// String returns s with every secret replaced by Placeholder.
+func (r *Redactor) String(s string) string {
+	if r == nil {
+		return s
+	}
+	for _, re := range r.patterns {
+		group := re.SubexpIndex("secret")
+		if group < 0 {
+			s = re.ReplaceAllLiteralString(s, Placeholder)
+			continue
+		}
+		s = re.ReplaceAllStringFunc(s, func(match string) string {
+			loc := re.FindStringSubmatchIndex(match)
+			if loc == nil || loc[2*group] < 0 {
+				return Placeholder
+			}
+			return match[:loc[2*group]] + Placeholder + match[loc[2*group+1]:]
+		})
+	}
+	return s
+}
+
+// jsonStringPattern matches a JSON string literal.
+var jsonStringPattern = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+
+// JSON returns the encoded JSON document data with secrets in its string
+// values replaced. The document's layout and key order are kept.
+func (r *Redactor) JSON(data []byte) []byte {
+	if r == nil {
+		return data
+	}
+	return jsonStringPattern.ReplaceAllFunc(data, func(literal []byte) []byte {
+		var s string
+		if err := json.Unmarshal(literal, &s); err != nil {
+			return literal
+		}
+		redacted := r.String(s)
+		if redacted == s {
+			return literal
+		}
+		encoded, err := json.Marshal(redacted)
+		if err != nil {
+			return literal
+		}
+		return encoded
+	})
+}
@@ -0,0 +1,148 @@
+package severity
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Level orders findings from review bots. Unknown sorts below everything so
+// that comments without a recognizable severity drop out of --min-severity.
+type Level int
+
+const (
+	Unknown Level = iota
+	Info
+	Trivial
+	Minor
+	Major
+	Critical
+)
+
+var levelNames = map[Level]string{
+	Info:     "info",
+	Trivial:  "trivial",
+	Minor:    "minor",
+	Major:    "major",
+	Critical: "critical",
+}
+
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// ParseLevel accepts the level names plus the aliases used by the supported
+// bots (nitpick, blocker).
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "info":
+		return Info, nil
+	case "trivial", "nit", "nitpick":
+		return Trivial, nil
+	case "minor":
+		return Minor, nil
+	case "major":
+		return Major, nil
+	case "critical", "blocker":
+		return Critical, nil
+	}
+	return Unknown, fmt.Errorf("invalid severity: %s (valid: info, trivial, minor, major, critical)", s)
+}
+
+// Classification is what a bot comment says about its own finding.
+type Classification struct {
+	Bot      string
+	Severity Level
+	Category string
+}
+
+// Classify recognizes comments from CodeRabbit, GitHub Copilot, and
+// SonarCloud by author and extracts severity and category from their
+// structured bodies. Other comments get a zero Classification.
+func Classify(author, body string) Classification {
+	login := strings.ToLower(author)
+	switch {
+	case strings.Contains(login, "coderabbit"):
+		return classifyCodeRabbit(body)
+	case strings.Contains(login, "copilot"):
+		return classifyCopilot(body)
+	case strings.Contains(login, "sonarcloud"), strings.Contains(login, "sonarqube"):
+		return classifySonar(body)
+	}
+	return Classification{}
+}
+
+// CodeRabbit opens each finding with a line like
+// "_⚠️ Potential issue_ | _🟠 Major_" or "_🧹 Nitpick (assertive)_".
+var (
+	codeRabbitHeader = regexp.MustCompile(`^\s*_([^_]+)_(?:\s*\|\s*_([^_]+)_)?`)
+	leadingSymbols   = regexp.MustCompile(`^[^\p{L}]+`)
+)
+
+func classifyCodeRabbit(body string) Classification {
+	c := Classification{Bot: "coderabbit"}
+	m := codeRabbitHeader.FindStringSubmatch(body)
+	if m == nil {
+		return c
+	}
+
+	category := cleanLabel(m[1])
+	if i := strings.Index(category, " ("); i >= 0 {
+		category = category[:i]
+	}
+	c.Category = strings.ToLower(category)
+
+	if m[2] != "" {
+		if level, err := ParseLevel(cleanLabel(m[2])); err == nil {
+			c.Severity = level
+		}
+	}
+	if c.Severity == Unknown {
+		switch c.Category {
+		case "nitpick":
+			c.Severity = Trivial
+		case "potential issue":
+			c.Severity = Major
+		case "refactor suggestion":
+			c.Severity = Minor
+		}
+	}
+	return c
+}
+
+// Copilot has no severity markup; it only prefixes minor remarks with
+// "[nitpick]".
+func classifyCopilot(body string) Classification {
+	c := Classification{Bot: "copilot"}
+	if strings.HasPrefix(strings.TrimSpace(strings.ToLower(body)), "[nitpick]") {
+		c.Severity = Trivial
+		c.Category = "nitpick"
+	}
+	return c
+}
+
+// SonarCloud renders issue type and severity as badge images whose alt text
+// or URL name them, e.g. ![Code Smell](…/code_smell.png) ![Major](…/major.png).
+var (
+	sonarSeverity = regexp.MustCompile(`(?i)\b(blocker|critical|major|minor|info)\b`)
+	sonarCategory = regexp.MustCompile(`(?i)\b(bug|vulnerability|code[ _]smell|security[ _]hotspot)\b`)
+)
+
+func classifySonar(body string) Classification {
+	c := Classification{Bot: "sonarcloud"}
+	if m := sonarSeverity.FindStringSubmatch(body); m != nil {
+		c.Severity, _ = ParseLevel(m[1])
+	}
+	if m := sonarCategory.FindStringSubmatch(body); m != nil {
+		c.Category = strings.ReplaceAll(strings.ToLower(m[1]), "_", " ")
+	}
+	return c
+}
+
+func cleanLabel(s string) string {
+	return strings.TrimSpace(leadingSymbols.ReplaceAllString(strings.TrimSpace(s), ""))
+}
@@ -0,0 +1,59 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/cli/go-gh/v2/pkg/config"
+)
+
+func Dir() string {
+	if dir := os.Getenv("GH_PR_COMMENTS_STATE_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(config.StateDir(), "pr-comments")
+}
+
+func PRKey(owner, repo string, number int) string {
+	return fmt.Sprintf("%s/%s/%d", owner, repo, number)
+}
+
+// Load decodes the named state file into v. A missing file leaves v untouched.
+func Load(name string, v interface{}) error {
+	data, err := os.ReadFile(filepath.Join(Dir(), name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read state %s: %w", name, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decode state %s: %w", name, err)
+	}
+	return nil
+}
+
+func Save(name string, v interface{}) error {
+	path := filepath.Join(Dir(), name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode state %s: %w", name, err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write state %s: %w", name, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("write state %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package suggestion
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Extract returns the contents of every ```suggestion block in a comment
+// body. Each block is the replacement text for the commented lines; an empty
+// block suggests deleting them.
+func Extract(body string) []string {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+
+	var blocks []string
+	for i := 0; i < len(lines); i++ {
+		fence, ok := openingFence(lines[i])
+		if !ok {
+			continue
+		}
+		var block []string
+		closed := false
+		for i++; i < len(lines); i++ {
+			if isClosingFence(lines[i], fence) {
+				closed = true
+				break
+			}
+			block = append(block, lines[i])
+		}
+		if closed {
+			blocks = append(blocks, strings.Join(block, "\n"))
+		}
+	}
+	return blocks
+}
+
+// Format wraps replacement in a ```suggestion block, using a longer fence
+// when the replacement contains backtick runs of its own, so Extract
+// returns it unchanged.
+func Format(replacement string) string {
+	longest := 0
+	for _, line := range strings.Split(replacement, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if n := len(trimmed) - len(strings.TrimLeft(trimmed, "`")); n > longest {
+			longest = n
+		}
+	}
+	fence := strings.Repeat("`", max(3, longest+1))
+	return fence + "suggestion\n" + replacement + "\n" + fence
+}
+
+// openingFence reports whether line opens a suggestion block and returns its
+// backtick fence, which may be longer than three characters when the
+// suggestion itself contains a code fence.
+func openingFence(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	n := len(trimmed) - len(strings.TrimLeft(trimmed, "`"))
+	if n < 3 {
+		return "", false
+	}
+	if strings.TrimSpace(trimmed[n:]) != "suggestion" {
+		return "", false
+	}
+	return trimmed[:n], true
+}
+
+func isClosingFence(line, fence string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, "`") == ""
+}
+
+// Edit replaces lines Start through End (1-based, inclusive) with Replacement.
+type Edit struct {
+	Start       int
+	End         int
+	Replacement []string
+}
+
+const patchContext = 3
+
+// Patch renders edits to a file as a unified diff that git apply accepts.
+// Edits must be sorted by Start and must not overlap.
+func Patch(path string, lines []string, edits []Edit) string {
+	if len(edits) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "diff --git a/%s b/%s\n", path, path)
+	fmt.Fprintf(&b, "--- a/%s\n", path)
+	fmt.Fprintf(&b, "+++ b/%s\n", path)
+
+	offset := 0
+	for len(edits) > 0 {
+		// Group edits whose context would overlap into a single hunk.
+		n := 1
+		for n < len(edits) && edits[n].Start-edits[n-1].End <= 2*patchContext+1 {
+			n++
+		}
+		group := edits[:n]
+		edits = edits[n:]
+
+		oldStart := max(group[0].Start-patchContext, 1)
+		oldEnd := min(group[len(group)-1].End+patchContext, len(lines))
+
+		var body []string
+		newCount := 0
+		pos := oldStart
+		for _, e := range group {
+			for ; pos < e.Start; pos++ {
+				body = append(body, " "+lines[pos-1])
+				newCount++
+			}
+			for ; pos <= e.End; pos++ {
+				body = append(body, "-"+lines[pos-1])
+			}
+			for _, r := range e.Replacement {
+				body = append(body, "+"+r)
+				newCount++
+			}
+		}
+		for ; pos <= oldEnd; pos++ {
+			body = append(body, " "+lines[pos-1])
+			newCount++
+		}
+
+		oldCount := oldEnd - oldStart + 1
+		newStart := oldStart + offset
+		if newCount == 0 {
+			newStart--
+		}
+		offset += newCount - oldCount
+
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+		for _, l := range body {
+			b.WriteString(l)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,35 @@
+package textsim
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Similarity returns the Jaccard similarity of the word sets of a and b, from
+// 0 (nothing in common) to 1 (same words). Case and punctuation are ignored,
+// so re-posted bot comments that only differ in formatting score close to 1.
+func Similarity(a, b string) float64 {
+	wa, wb := words(a), words(b)
+	if len(wa) == 0 && len(wb) == 0 {
+		return 1
+	}
+
+	shared := 0
+	for w := range wa {
+		if wb[w] {
+			shared++
+		}
+	}
+	union := len(wa) + len(wb) - shared
+	return float64(shared) / float64(union)
+}
+
+func words(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		set[w] = true
+	}
+	return set
+}
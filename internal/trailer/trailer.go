@@ -0,0 +1,57 @@
+package trailer
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// ResolvesComment is the commit trailer key that marks a review comment as
+// fixed by the commit, e.g. "Resolves-Comment: 2621968472". The value may
+// also be the comment's permalink, ending in "#discussion_r2621968472".
+const ResolvesComment = "Resolves-Comment"
+
+var (
+	resolvesPattern = regexp.MustCompile(`(?im)^` + ResolvesComment + `:\s*(?:#|\S*#discussion_r)?(\d+)\s*$`)
+	mentionPattern  = regexp.MustCompile(`(?i)\b(?:address(?:es|ed)?|fix(?:es|ed)?|resolve[sd]?)\s+(?:review\s+)?(?:comment\s+)?#?(\d{6,})\b`)
+)
+
+// ResolvedComments returns the comment IDs named by Resolves-Comment trailers
+// in a commit message.
+func ResolvedComments(message string) []int64 {
+	return collectIDs(resolvesPattern, message)
+}
+
+// MentionedComments returns comment IDs referenced by trailers or by phrases
+// such as "addresses #2621968472" anywhere in a commit message. Only numbers
+// long enough to be comment IDs are considered, so issue references like
+// "fixes #12" are ignored.
+func MentionedComments(message string) []int64 {
+	ids := ResolvedComments(message)
+	seen := make(map[int64]bool)
+	for _, id := range ids {
+		seen[id] = true
+	}
+	for _, id := range collectIDs(mentionPattern, message) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func collectIDs(re *regexp.Regexp, message string) []int64 {
+	var ids []int64
+	for _, m := range re.FindAllStringSubmatch(message, -1) {
+		if id, err := strconv.ParseInt(m[1], 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Format returns a Resolves-Comment trailer line for value, a comment ID or
+// permalink.
+func Format(value string) string {
+	return ResolvesComment + ": " + value
+}